@@ -0,0 +1,117 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeSink 配置变更接收端，由 Manager 实现
+// 配置层（如 framework-config 或第三方配置库）只依赖该接口投递变更，无需导入 Manager 的其他实现，从而避免循环依赖
+type ChangeSink interface {
+	// Apply 投递携带来源信息的配置变更
+	Apply(ctx context.Context, change Change) error
+
+	// ReportSourceStatus 上报配置源的连接状态（用于健康检查）
+	ReportSourceStatus(source string, connected bool)
+}
+
+var _ ChangeSink = (*Manager)(nil)
+
+// Adapter 配置层适配器接口
+// 用于替代“配置中心直接调用 HandleChange”的隐式约定：配置层通过 Attach 获得变更接收端，
+// 并通过 Subscribe 得知管理器关心的配置键模式，只需投递匹配这些模式的变更。
+// 需要启停的配置层可同时实现 Source，以便由依赖注入集成绑定到应用生命周期
+type Adapter interface {
+	// Name 返回配置层名称（建议作为投递变更的 Source）
+	Name() string
+
+	// Attach 绑定变更接收端，此后配置层通过 sink 投递配置变更
+	Attach(sink ChangeSink) error
+
+	// Subscribe 更新订阅的配置键模式（支持通配符，语义与处理器注册相同）
+	// 绑定时以及之后每次注册新的处理器时都会以完整的模式列表调用
+	Subscribe(patterns []string) error
+}
+
+// Attach 绑定配置层适配器：将管理器作为变更接收端交给适配器，并订阅当前已注册的配置键模式
+func (m *Manager) Attach(adapter Adapter) error {
+	if m == nil || adapter == nil {
+		return fmt.Errorf("manager or adapter is nil")
+	}
+	if err := adapter.Attach(m); err != nil {
+		return fmt.Errorf("failed to attach config adapter %s: %w", adapter.Name(), err)
+	}
+	if err := adapter.Subscribe(m.Patterns()); err != nil {
+		return fmt.Errorf("failed to subscribe config adapter %s: %w", adapter.Name(), err)
+	}
+
+	m.mu.Lock()
+	m.adapters = append(m.adapters, adapter)
+	m.mu.Unlock()
+
+	m.logger.Info("Config adapter attached", "adapter", adapter.Name())
+	return nil
+}
+
+// Patterns 按字典序返回所有已注册的配置键模式（字段设置器的允许前缀以 "<prefix>.*" 表示）
+func (m *Manager) Patterns() []string {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := make(map[string]struct{}, len(m.handlers)+len(m.allowedPrefixes))
+	for pattern := range m.handlers {
+		seen[pattern] = struct{}{}
+	}
+	for _, target := range m.fieldTargetsLocked() {
+		for _, prefix := range target.allowedPrefixes {
+			seen[strings.TrimSuffix(prefix, ".")+".*"] = struct{}{}
+		}
+	}
+	patterns := make([]string, 0, len(seen))
+	for pattern := range seen {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// resubscribeAdapters 注册变化后以完整的模式列表更新所有适配器的订阅
+func (m *Manager) resubscribeAdapters() {
+	m.mu.RLock()
+	adapters := m.adapters
+	m.mu.RUnlock()
+	if len(adapters) == 0 {
+		return
+	}
+
+	patterns := m.Patterns()
+	for _, adapter := range adapters {
+		if err := adapter.Subscribe(patterns); err != nil {
+			m.logger.Warn("Failed to update config adapter subscription",
+				"adapter", adapter.Name(),
+				"error", err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPatternsFromFieldSetterPrefixes(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	m.SetFieldSetter(func(module, fieldPath, value string) error { return nil },
+		[]string{"server.features.", "gateway.features"})
+
+	patterns := m.Patterns()
+	for _, want := range []string{"server.features.*", "gateway.features.*"} {
+		if !slices.Contains(patterns, want) {
+			t.Fatalf("Patterns() = %v, want %s", patterns, want)
+		}
+		if !matchPattern(want, want[:len(want)-1]+"rate_limit") {
+			t.Fatalf("pattern %s does not match keys under its prefix", want)
+		}
+	}
+}
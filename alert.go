@@ -0,0 +1,226 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"text/template"
+	"time"
+)
+
+// defaultAlertTimeout 默认的告警发送超时时间
+const defaultAlertTimeout = 10 * time.Second
+
+// AlertType 告警类型
+type AlertType string
+
+const (
+	// AlertChangeFailed 配置变更最终处理失败
+	AlertChangeFailed AlertType = "change_failed"
+	// AlertHandlerQuarantined 处理器因连续失败被隔离
+	AlertHandlerQuarantined AlertType = "handler_quarantined"
+	// AlertKeyFlapping 配置键在检测窗口内反复回退到先前的值
+	AlertKeyFlapping AlertType = "key_flapping"
+)
+
+// AlertEvent 告警事件
+type AlertEvent struct {
+	// 告警类型
+	Type AlertType `json:"type"`
+	// 配置键
+	Key string `json:"key"`
+	// 旧值
+	OldValue string `json:"old_value"`
+	// 新值
+	NewValue string `json:"new_value"`
+	// 变更来源（如配置中心名称、admin-http）
+	Source string `json:"source,omitempty"`
+	// 操作者身份（如配置中心用户、管理接口调用方）
+	Actor string `json:"actor,omitempty"`
+	// 配置源的修订号
+	SourceRevision string `json:"source_revision,omitempty"`
+	// 处理失败（或被隔离）的处理器模式
+	Pattern string `json:"pattern,omitempty"`
+	// 处理失败（或被隔离）的重载器/处理器名称
+	Reloader string `json:"reloader,omitempty"`
+	// 错误分类（配置变更失败时有效）
+	ErrorKind ErrorKind `json:"error_kind,omitempty"`
+	// 错误信息
+	Error string `json:"error"`
+	// 发生时间
+	Time time.Time `json:"time"`
+}
+
+// Alerter 告警器接口
+// 配置变更最终失败或处理器被隔离时，Manager 会异步调用 Alert
+type Alerter interface {
+	Alert(ctx context.Context, event AlertEvent) error
+}
+
+// WithAlerter 设置告警器
+func WithAlerter(alerter Alerter) Option {
+	return func(m *Manager) {
+		m.alerter = alerter
+	}
+}
+
+// emitAlert 异步发送告警，发送失败只记录日志，不影响配置变更处理
+func (m *Manager) emitAlert(event AlertEvent) {
+	if m.alerter == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	alerter := m.alerter
+	m.goBackground(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultAlertTimeout)
+		defer cancel()
+
+		if err := alerter.Alert(ctx, event); err != nil {
+			m.logger.Warn("Failed to send hot reload alert",
+				"type", string(event.Type),
+				"key", event.Key,
+				"error", err)
+		}
+	})
+}
+
+// WebhookConfig Webhook 告警配置
+type WebhookConfig struct {
+	// Webhook 地址
+	URL string
+
+	// 请求体模板（text/template 语法，数据为 AlertEvent）
+	// 为空时请求体为 AlertEvent 的 JSON 编码
+	// 模板中可使用 json 函数输出 JSON 转义后的字符串，如 {{ json .Error }}
+	Template string
+
+	// 请求的 Content-Type，默认 "application/json"
+	ContentType string
+
+	// 额外的请求头（如鉴权 Token）
+	Headers map[string]string
+
+	// HTTP 客户端，为空时使用带超时的默认客户端
+	Client *http.Client
+}
+
+// WebhookAlerter 通过 HTTP POST 发送告警的告警器
+type WebhookAlerter struct {
+	url         string
+	tmpl        *template.Template
+	contentType string
+	headers     map[string]string
+	client      *http.Client
+}
+
+var _ Alerter = (*WebhookAlerter)(nil)
+
+// NewWebhookAlerter 创建 Webhook 告警器
+func NewWebhookAlerter(cfg WebhookConfig) (*WebhookAlerter, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("webhook url is empty")
+	}
+
+	alerter := &WebhookAlerter{
+		url:         cfg.URL,
+		contentType: cfg.ContentType,
+		headers:     cfg.Headers,
+		client:      cfg.Client,
+	}
+	if alerter.contentType == "" {
+		alerter.contentType = "application/json"
+	}
+	if alerter.client == nil {
+		alerter.client = &http.Client{Timeout: defaultAlertTimeout}
+	}
+
+	if cfg.Template != "" {
+		tmpl, err := template.New("webhook").Funcs(template.FuncMap{
+			"json": jsonString,
+		}).Parse(cfg.Template)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse webhook template: %w", err)
+		}
+		alerter.tmpl = tmpl
+	}
+
+	return alerter, nil
+}
+
+// Alert 发送告警
+func (a *WebhookAlerter) Alert(ctx context.Context, event AlertEvent) error {
+	body, err := a.render(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", a.contentType)
+	for k, v := range a.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// render 渲染请求体
+func (a *WebhookAlerter) render(event AlertEvent) ([]byte, error) {
+	if a.tmpl == nil {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode alert event: %w", err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := a.tmpl.Execute(&buf, event); err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonString 将字符串编码为 JSON 字符串字面量（含引号）
+func jsonString(s string) (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
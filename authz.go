@@ -0,0 +1,161 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrUnauthorized 配置变更被授权策略拒绝（ErrorKindUnauthorized，见 WithAuthorizer）
+var ErrUnauthorized = errors.New("unauthorized")
+
+// AuthorizationRequest 授权请求
+type AuthorizationRequest struct {
+	// 待应用的配置变更（Source、Actor 即变更来源与操作者身份）
+	Change Change
+	// 匹配的授权策略模式（策略未限定模式时为 "*"）
+	Pattern string
+	// 匹配配置键的处理器模式
+	HandlerPatterns []string
+	// 不为 0 时表示该变更是对指定修订号的回滚
+	RollbackOf uint64
+}
+
+// Authorizer 配置变更授权策略
+// 在配置变更被暂存或应用之前调用，返回错误时拒绝该变更；可结合 ctx 中的调用方信息（如 ActorFromContext）
+// 限制只有特定角色或来源才能修改敏感前缀（如 security.*）
+type Authorizer interface {
+	Authorize(ctx context.Context, request AuthorizationRequest) error
+}
+
+// AuthorizerFunc 函数形式的 Authorizer
+type AuthorizerFunc func(ctx context.Context, request AuthorizationRequest) error
+
+// Authorize 实现 Authorizer
+func (f AuthorizerFunc) Authorize(ctx context.Context, request AuthorizationRequest) error {
+	return f(ctx, request)
+}
+
+// authorizerRoute 授权策略路由
+type authorizerRoute struct {
+	patterns   []string
+	authorizer Authorizer
+}
+
+// matches 检查配置键是否匹配授权策略，返回匹配的模式
+func (r authorizerRoute) matches(key string) (string, bool) {
+	if len(r.patterns) == 0 {
+		return "*", true
+	}
+	for _, pattern := range r.patterns {
+		if pattern == "*" || matchPattern(pattern, key) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// WithAuthorizer 添加授权策略
+// patterns 为配置键模式（支持通配符），为空时对所有配置变更生效；可多次使用，配置变更需通过所有匹配的授权策略。
+// 被拒绝的变更以 ErrorKindUnauthorized 返回（可通过 errors.Is(err, ErrUnauthorized) 判断），
+// 并记录到变更历史（OutcomeDenied）、日志与 EventChangeDenied 事件中用于审计；以当前值重新应用（见 ReloadAll）的变更不经过授权
+func WithAuthorizer(authorizer Authorizer, patterns ...string) Option {
+	return func(m *Manager) {
+		if authorizer == nil {
+			return
+		}
+		m.authorizers = append(m.authorizers, authorizerRoute{patterns: patterns, authorizer: authorizer})
+	}
+}
+
+// authorize 依次调用匹配的授权策略，拒绝时记录审计信息并返回 ErrorKindUnauthorized 错误
+func (m *Manager) authorize(ctx context.Context, change Change, rollbackOf uint64) error {
+	if len(m.authorizers) == 0 || change.Reloaded {
+		return nil
+	}
+
+	var handlerPatterns []string
+	for _, route := range m.authorizers {
+		pattern, ok := route.matches(change.Key)
+		if !ok {
+			continue
+		}
+		if handlerPatterns == nil {
+			handlerPatterns = make([]string, 0)
+			for _, reg := range m.match(change.Key, nil) {
+				handlerPatterns = append(handlerPatterns, reg.pattern)
+			}
+		}
+
+		err := route.authorizer.Authorize(ctx, AuthorizationRequest{
+			Change:          change,
+			Pattern:         pattern,
+			HandlerPatterns: handlerPatterns,
+			RollbackOf:      rollbackOf,
+		})
+		if err != nil {
+			return m.denyChange(change, pattern, rollbackOf, err)
+		}
+	}
+	return nil
+}
+
+// denyChange 记录被授权策略拒绝的配置变更
+func (m *Manager) denyChange(change Change, pattern string, rollbackOf uint64, err error) error {
+	cerr := &ChangeError{Kind: ErrorKindUnauthorized, Key: change.Key, Pattern: pattern, Err: err}
+	now := time.Now()
+
+	m.counters.recordFailure(ErrorKindUnauthorized)
+	m.logger.Warn("Config change denied by authorizer",
+		"key", change.Key,
+		"new_value", change.NewValue,
+		"source", change.Source,
+		"actor", change.Actor,
+		"pattern", pattern,
+		"error", err)
+	m.history.add(HistoryEntry{
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Deleted:        change.Deleted,
+		Defaulted:      change.Defaulted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Outcome:        OutcomeDenied,
+		Error:          cerr.Error(),
+		RollbackOf:     rollbackOf,
+		Time:           now,
+	})
+	m.publishEvent(Event{
+		Type:           EventChangeDenied,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Deleted:        change.Deleted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Pattern:        pattern,
+		ErrorKind:      ErrorKindUnauthorized,
+		Error:          cerr.Error(),
+		Time:           now,
+	})
+	return cerr
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAuthorizerIgnoresCallerSetReloaded(t *testing.T) {
+	deny := AuthorizerFunc(func(ctx context.Context, request AuthorizationRequest) error {
+		if request.Change.Source == "admin" {
+			return nil
+		}
+		return errors.New("denied")
+	})
+	m := NewManager(WithAuthorizer(deny, "secret.*"))
+	defer m.Close(context.Background())
+	if err := m.RegisterHandler("secret.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := m.Apply(ctx, Change{Key: "secret.token", NewValue: "v1", Source: "admin"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	err := m.Apply(ctx, Change{Key: "secret.token", NewValue: "v2", Source: "nacos", Reloaded: true})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Apply() with caller-set Reloaded error = %v, want ErrUnauthorized", err)
+	}
+
+	if _, err := m.ReloadAll(ctx); err != nil {
+		t.Fatalf("ReloadAll() error = %v", err)
+	}
+	if applied, _ := m.LastApplied("secret.token"); applied.Value != "v1" {
+		t.Fatalf("LastApplied() value = %q, want v1", applied.Value)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// binaryPrefix 二进制配置值的可选前缀
+const binaryPrefix = "base64:"
+
+// BinaryChange 二进制配置变更
+type BinaryChange struct {
+	Change
+	// 解码后的旧值（旧值为空或无法解码时为 nil）
+	OldBytes []byte
+	// 解码后的新值（新值为空时为 nil）
+	NewBytes []byte
+}
+
+// BinaryHandler 二进制配置变更处理器
+// 用于证书、密钥库、protobuf 编码的策略等二进制配置，避免以字符串传递时被截断或转码
+type BinaryHandler func(ctx context.Context, change BinaryChange) error
+
+// EncodeBinary 将二进制配置值编码为传输格式（"base64:" 前缀加标准 base64 编码）
+func EncodeBinary(data []byte) string {
+	return binaryPrefix + base64.StdEncoding.EncodeToString(data)
+}
+
+// DecodeBinary 解码二进制配置值
+// 支持带 "base64:" 前缀或不带前缀的标准 base64 编码，空值解码为 nil
+func DecodeBinary(value string) ([]byte, error) {
+	value = strings.TrimSpace(strings.TrimPrefix(value, binaryPrefix))
+	if value == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64 binary value: %w", err)
+	}
+	return data, nil
+}
+
+// RegisterBinaryHandler 注册二进制配置变更处理器
+// 匹配 pattern 的配置值按 base64 解码后以 []byte 交给处理器，无法解码的新值以验证失败拒绝；
+// 与 WithValueDecompression 同时使用时，压缩的内容应为 base64 文本
+func (m *Manager) RegisterBinaryHandler(pattern string, handler BinaryHandler, opts ...RegisterOption) error {
+	if m == nil || handler == nil {
+		return fmt.Errorf("manager or handler is nil")
+	}
+
+	// 释放锁后通知配置层适配器更新订阅
+	defer m.resubscribeAdapters()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pattern = m.NormalizeKey(pattern)
+	options := newRegisterOptions(opts)
+	name := options.name
+	if name == "" {
+		name = funcName(handler)
+	}
+
+	m.addRegistrationLocked(&registration{
+		pattern: pattern,
+		name:    name,
+		kind:    RegistrationHandler,
+		site:    callerSite(1),
+		handler: func(ctx context.Context, change Change) error {
+			newBytes, err := DecodeBinary(change.NewValue)
+			if err != nil {
+				return &ChangeError{Kind: ErrorKindValidation, Key: change.Key, Err: err}
+			}
+			oldBytes, _ := DecodeBinary(change.OldValue)
+			return handler(ctx, BinaryChange{Change: change, OldBytes: oldBytes, NewBytes: newBytes})
+		},
+		validate: func(key, value string) error {
+			_, err := DecodeBinary(value)
+			return err
+		},
+		parallel: options.mode == ExecutionParallel,
+	})
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// SourceRollback 回滚操作产生的配置变更来源
+	SourceRollback = "rollback"
+	// SourceAdminHTTP HTTP 管理接口产生的配置变更来源
+	SourceAdminHTTP = "admin-http"
+	// SourceAdminGRPC gRPC 管理服务产生的配置变更来源
+	SourceAdminGRPC = "admin-grpc"
+)
+
+// Change 配置变更
+type Change struct {
+	// 配置键
+	Key string `json:"key"`
+	// 旧值
+	OldValue string `json:"old_value"`
+	// 新值
+	NewValue string `json:"new_value"`
+	// 配置键已从配置源删除（此时 NewValue 为空）
+	// 与“新值为空字符串”区分：DeleteAwareReloader 会以 OnDelete 代替 Validate 与 OnChange 接收删除事件
+	Deleted bool `json:"deleted,omitempty"`
+	// 新值来自默认值注册表（配置键被删除后回退到默认值，见 SetDefault）
+	Defaulted bool `json:"defaulted,omitempty"`
+	// 以当前值强制重新应用（见 ReloadAll），此时旧值与新值相同
+	// 仅由 ReloadAll/ReloadPrefix 设置，调用方自行设置的值在分发时会被清除
+	Reloaded bool `json:"reloaded,omitempty"`
+
+	// 变更来源（如配置中心名称 "nacos"、"admin-http"）
+	Source string `json:"source,omitempty"`
+	// 操作者身份（如配置中心用户、管理接口调用方），为空时从 context 中读取
+	Actor string `json:"actor,omitempty"`
+	// 配置源的修订号（如配置中心的配置版本号）
+	SourceRevision string `json:"source_revision,omitempty"`
+	// 配置源内单调递增的序号（0 表示未提供），开启 WithSourceOrdering 时用于丢弃乱序到达的旧变更
+	Sequence uint64 `json:"sequence,omitempty"`
+	// 变更发生的时间，为空时取 Manager 收到变更的时间
+	Timestamp time.Time `json:"timestamp,omitzero"`
+}
+
+// Apply 处理携带来源信息的配置变更
+// 与 HandleChange 相同，但来源、修订号与操作者身份会记录到日志、事件、告警、通知与变更历史中，
+// 用于回答“这次变更是谁做的”，并完整传递给 ChangeHandler 与 ChangeReloader
+func (m *Manager) Apply(ctx context.Context, change Change) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return m.dispatch(ctx, change, 0)
+}
+
+// actorContextKey 操作者身份的 context 键
+type actorContextKey struct{}
+
+// ContextWithActor 返回携带操作者身份的 context
+// 管理接口的鉴权中间件可通过它传递调用方身份
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext 从 context 中读取操作者身份
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	return actor
+}
@@ -0,0 +1,289 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package chaos 提供热加载管理器的随机故障注入器，用于测试或金丝雀环境
+//
+//	injector := chaos.New(chaos.Config{ErrorRate: 0.1, DelayRate: 0.2, MaxDelay: 2 * time.Second})
+//	m := hotreload.NewManager(hotreload.WithFaultInjector(injector))
+//
+// 注入器本身也可注册为重载器，通过 "chaos.*" 配置键在运行时调整（这些配置键不会被注入故障）：
+//
+//	chaos.error_rate      处理器返回 ErrInjected 的概率（0~1）
+//	chaos.panic_rate      处理器 panic 的概率（0~1）
+//	chaos.delay_rate      处理器执行前延迟的概率（0~1）
+//	chaos.max_delay       最大延迟（如 "2s"，实际延迟在 (0, max_delay] 内均匀分布）
+//	chaos.duplicate_rate  重复投递配置变更的概率（0~1）
+//	chaos.patterns        注入故障的配置键模式列表（为空表示所有配置键）
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/go-anyway/framework-hotreload/internal/values"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "chaos"
+
+// ErrInjected 注入的处理器错误
+var ErrInjected = errors.New("injected fault")
+
+// Config 故障注入配置
+type Config struct {
+	// 处理器返回 ErrInjected 的概率
+	ErrorRate float64
+	// 处理器 panic 的概率
+	PanicRate float64
+	// 处理器执行前延迟的概率
+	DelayRate float64
+	// 最大延迟
+	MaxDelay time.Duration
+	// 重复投递配置变更的概率
+	DuplicateRate float64
+	// 注入故障的配置键模式列表（为空表示所有配置键）
+	Patterns []string
+}
+
+// validate 验证故障注入配置
+func (c Config) validate() error {
+	for name, rate := range map[string]float64{
+		"error_rate":     c.ErrorRate,
+		"panic_rate":     c.PanicRate,
+		"delay_rate":     c.DelayRate,
+		"duplicate_rate": c.DuplicateRate,
+	} {
+		// 取反判断以同时拒绝 NaN
+		if !(rate >= 0 && rate <= 1) {
+			return fmt.Errorf("%s must be within [0, 1], got %v", name, rate)
+		}
+	}
+	if c.MaxDelay < 0 {
+		return fmt.Errorf("max_delay must not be negative, got %s", c.MaxDelay)
+	}
+	return nil
+}
+
+// Option 故障注入器配置选项
+type Option func(*Injector)
+
+// WithPrefix 设置配置键前缀（默认 "chaos"）
+func WithPrefix(prefix string) Option {
+	return func(i *Injector) {
+		if prefix != "" {
+			i.prefix = prefix
+		}
+	}
+}
+
+// WithSeed 设置随机数种子，便于复现故障序列
+func WithSeed(seed uint64) Option {
+	return func(i *Injector) {
+		i.rnd = rand.New(rand.NewPCG(seed, seed))
+	}
+}
+
+// Injector 随机故障注入器，实现 hotreload.FaultInjector 与 hotreload.Reloader
+type Injector struct {
+	prefix string
+	config atomic.Pointer[Config]
+
+	// 串行化配置变更
+	mu sync.Mutex
+
+	rndMu sync.Mutex
+	rnd   *rand.Rand
+}
+
+var (
+	_ hotreload.FaultInjector = (*Injector)(nil)
+	_ hotreload.Reloader      = (*Injector)(nil)
+)
+
+// New 创建故障注入器，配置无效时不注入任何故障
+func New(cfg Config, opts ...Option) *Injector {
+	i := &Injector{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(i)
+		}
+	}
+	if i.rnd == nil {
+		i.rnd = rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	if cfg.validate() != nil {
+		cfg = Config{}
+	}
+	i.config.Store(&cfg)
+	return i
+}
+
+// Config 返回当前故障注入配置
+func (i *Injector) Config() Config {
+	return *i.config.Load()
+}
+
+// SetConfig 替换故障注入配置
+func (i *Injector) SetConfig(cfg Config) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
+	i.config.Store(&cfg)
+	return nil
+}
+
+// BeforeHandler 按配置概率注入延迟、错误或 panic
+func (i *Injector) BeforeHandler(ctx context.Context, change hotreload.Change, pattern, reloader string) error {
+	cfg := i.config.Load()
+	if !i.targets(cfg, change.Key) {
+		return nil
+	}
+
+	if cfg.MaxDelay > 0 && i.hit(cfg.DelayRate) {
+		delay := time.Duration(i.float64()*float64(cfg.MaxDelay)) + 1
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+	if i.hit(cfg.PanicRate) {
+		panic(fmt.Sprintf("chaos: injected panic in %s for key %s", reloader, change.Key))
+	}
+	if i.hit(cfg.ErrorRate) {
+		return fmt.Errorf("%w in %s for key %s", ErrInjected, reloader, change.Key)
+	}
+	return nil
+}
+
+// Duplicate 按配置概率重复投递配置变更
+func (i *Injector) Duplicate(change hotreload.Change) bool {
+	cfg := i.config.Load()
+	return i.targets(cfg, change.Key) && i.hit(cfg.DuplicateRate)
+}
+
+// targets 返回配置键是否在故障注入范围内（注入器自身的配置键始终排除）
+func (i *Injector) targets(cfg *Config, key string) bool {
+	if strings.HasPrefix(key, i.prefix+".") {
+		return false
+	}
+	if len(cfg.Patterns) == 0 {
+		return true
+	}
+	for _, pattern := range cfg.Patterns {
+		if hotreload.MatchPattern(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// hit 以 rate 的概率返回 true
+func (i *Injector) hit(rate float64) bool {
+	return rate > 0 && i.float64() < rate
+}
+
+// float64 返回 [0, 1) 内的随机数
+func (i *Injector) float64() float64 {
+	i.rndMu.Lock()
+	defer i.rndMu.Unlock()
+	return i.rnd.Float64()
+}
+
+// Name 返回重载器名称
+func (i *Injector) Name() string {
+	return "chaos"
+}
+
+// Patterns 返回配置键模式列表
+func (i *Injector) Patterns() []string {
+	return []string{i.prefix + ".*"}
+}
+
+// Validate 验证故障注入配置值
+func (i *Injector) Validate(key, value string) error {
+	cfg := i.Config()
+	return i.apply(&cfg, key, value)
+}
+
+// OnChange 更新故障注入配置
+func (i *Injector) OnChange(key, oldValue, newValue string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	cfg := i.Config()
+	if err := i.apply(&cfg, key, newValue); err != nil {
+		return err
+	}
+	return i.SetConfig(cfg)
+}
+
+// apply 将配置值写入 cfg 并验证
+func (i *Injector) apply(cfg *Config, key, value string) error {
+	setting, ok := strings.CutPrefix(key, i.prefix+".")
+	if !ok {
+		return fmt.Errorf("unsupported chaos key: %s", key)
+	}
+	value = strings.TrimSpace(value)
+
+	rate := func(target *float64) error {
+		if value == "" {
+			*target = 0
+			return nil
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", setting, err)
+		}
+		*target = v
+		return nil
+	}
+
+	var err error
+	switch setting {
+	case "error_rate":
+		err = rate(&cfg.ErrorRate)
+	case "panic_rate":
+		err = rate(&cfg.PanicRate)
+	case "delay_rate":
+		err = rate(&cfg.DelayRate)
+	case "duplicate_rate":
+		err = rate(&cfg.DuplicateRate)
+	case "max_delay":
+		cfg.MaxDelay = 0
+		if value != "" {
+			cfg.MaxDelay, err = time.ParseDuration(value)
+		}
+	case "patterns":
+		cfg.Patterns, err = values.ParseList(value)
+	default:
+		return fmt.Errorf("unsupported chaos key: %s", key)
+	}
+	if err != nil {
+		return err
+	}
+	return cfg.validate()
+}
@@ -0,0 +1,132 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+func TestValidate(t *testing.T) {
+	i := New(Config{})
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"chaos.error_rate", "0.1", false},
+		{"chaos.error_rate", "", false},
+		{"chaos.error_rate", "1.5", true},
+		{"chaos.panic_rate", "-0.1", true},
+		{"chaos.delay_rate", "NaN", true},
+		{"chaos.duplicate_rate", "often", true},
+		{"chaos.max_delay", "2s", false},
+		{"chaos.max_delay", "-1s", true},
+		{"chaos.patterns", "app.*, db.*", false},
+		{"chaos.unknown", "1", true},
+		{"faults.error_rate", "0.1", true},
+	}
+	for _, tt := range tests {
+		if err := i.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNewDiscardsInvalidConfig(t *testing.T) {
+	if cfg := New(Config{ErrorRate: 2}).Config(); cfg.ErrorRate != 0 {
+		t.Fatalf("Config().ErrorRate = %v, want 0", cfg.ErrorRate)
+	}
+	if err := New(Config{}).SetConfig(Config{MaxDelay: -time.Second}); err == nil {
+		t.Fatal("SetConfig() with negative max_delay error = nil")
+	}
+}
+
+func TestBeforeHandlerTargetsPatterns(t *testing.T) {
+	i := New(Config{ErrorRate: 1, DuplicateRate: 1, Patterns: []string{"app.*"}}, WithSeed(1))
+	ctx := context.Background()
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"app.mode", true},
+		{"db.pool", false},
+		// 注入器自身的配置键始终排除
+		{"chaos.error_rate", false},
+	}
+	for _, tt := range tests {
+		err := i.BeforeHandler(ctx, hotreload.Change{Key: tt.key}, "", "handler")
+		if got := errors.Is(err, ErrInjected); got != tt.want {
+			t.Errorf("BeforeHandler(%q) error = %v, want injected %v", tt.key, err, tt.want)
+		}
+		if got := i.Duplicate(hotreload.Change{Key: tt.key}); got != tt.want {
+			t.Errorf("Duplicate(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestBeforeHandlerPanicsAndDelays(t *testing.T) {
+	i := New(Config{PanicRate: 1})
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("BeforeHandler() did not panic")
+			}
+		}()
+		_ = i.BeforeHandler(context.Background(), hotreload.Change{Key: "app.mode"}, "", "handler")
+	}()
+
+	i = New(Config{DelayRate: 1, MaxDelay: time.Hour})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := i.BeforeHandler(ctx, hotreload.Change{Key: "app.mode"}, "", "handler"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("BeforeHandler() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestInjectorAsReloader(t *testing.T) {
+	i := New(Config{})
+	m := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()), hotreload.WithFaultInjector(i))
+	defer m.Close(context.Background())
+	if err := m.RegisterReloader(i); err != nil {
+		t.Fatalf("RegisterReloader() error = %v", err)
+	}
+	if err := m.RegisterHandler("app.*", func(string, string, string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	ctx := context.Background()
+	if err := m.Apply(ctx, hotreload.Change{Key: "app.mode", NewValue: "on"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if err := m.Apply(ctx, hotreload.Change{Key: "chaos.error_rate", NewValue: "1"}); err != nil {
+		t.Fatalf("Apply(chaos.error_rate) error = %v", err)
+	}
+	if err := m.Apply(ctx, hotreload.Change{Key: "app.mode", OldValue: "on", NewValue: "off"}); !errors.Is(err, ErrInjected) {
+		t.Fatalf("Apply() error = %v, want %v", err, ErrInjected)
+	}
+	// 故障注入期间仍可通过配置关闭注入
+	if err := m.Apply(ctx, hotreload.Change{Key: "chaos.error_rate", OldValue: "1", NewValue: "0"}); err != nil {
+		t.Fatalf("Apply(chaos.error_rate) error = %v", err)
+	}
+	if err := m.Apply(ctx, hotreload.Change{Key: "app.mode", OldValue: "on", NewValue: "off"}); err != nil {
+		t.Fatalf("Apply() after injection disabled error = %v", err)
+	}
+}
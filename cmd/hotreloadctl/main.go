@@ -0,0 +1,532 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// hotreloadctl 热加载管理命令行工具
+// 通过 HTTP 管理接口（见 httpadmin 包）查看和操作运行中服务的热加载状态
+//
+// 用法：
+//
+//	hotreloadctl [-addr URL] [-token TOKEN] <command> [args]
+//
+// 命令：
+//
+//	state                         查看运行状态
+//	list                          列出支持热加载的配置键模式及其处理器
+//	history [-limit N]            查看变更历史
+//	effective [key]               查看配置键的生效值及其来源层
+//	push <key> <value> [-old V]   推送一次测试配置变更
+//	delete <key> [-old V]         推送一次配置键删除事件
+//	dry-run <key> <value>         预演配置值（只验证，不应用）
+//	rollback <revision>           回滚指定修订号的变更
+//	pause                         暂停配置变更分发
+//	resume                        恢复配置变更分发
+//	frozen                        列出因抖动被冻结的配置键
+//	unfreeze <key>                解除配置键的冻结
+//	reload [prefix]               以当前值强制重新应用配置键
+//	tail [-pattern P]             实时查看热加载事件
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/go-anyway/framework-hotreload/httpadmin"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "hotreloadctl:", err)
+		os.Exit(1)
+	}
+}
+
+// run 解析参数并执行命令
+func run(args []string) error {
+	fs := flag.NewFlagSet("hotreloadctl", flag.ContinueOnError)
+	addr := fs.String("addr", envOr("HOTRELOAD_ADDR", "http://127.0.0.1:8080/admin/hotreload"), "admin API base URL (env HOTRELOAD_ADDR)")
+	token := fs.String("token", os.Getenv("HOTRELOAD_TOKEN"), "bearer token sent as Authorization header (env HOTRELOAD_TOKEN)")
+	timeout := fs.Duration("timeout", 10*time.Second, "request timeout (not applied to tail)")
+	output := fs.String("o", "table", "output format: table or json")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: hotreloadctl [flags] <state|list|history|effective|push|delete|dry-run|rollback|pause|resume|frozen|unfreeze|reload|tail> [args]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return fmt.Errorf("command is required")
+	}
+
+	var opts []httpadmin.ClientOption
+	if *token != "" {
+		opts = append(opts, httpadmin.WithRequestHook(func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+*token)
+		}))
+	}
+	c := &cli{
+		client: httpadmin.NewClient(*addr, opts...),
+		json:   *output == "json",
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cmd, cmdArgs := fs.Arg(0), fs.Args()[1:]
+	if cmd != "tail" {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	switch cmd {
+	case "state":
+		return c.state(ctx)
+	case "list":
+		return c.list(ctx)
+	case "history":
+		return c.history(ctx, cmdArgs)
+	case "push":
+		return c.push(ctx, cmdArgs)
+	case "delete":
+		return c.delete(ctx, cmdArgs)
+	case "dry-run":
+		return c.dryRun(ctx, cmdArgs)
+	case "rollback":
+		return c.rollback(ctx, cmdArgs)
+	case "pause":
+		return c.pause(ctx)
+	case "resume":
+		return c.resume(ctx)
+	case "effective":
+		return c.effective(ctx, cmdArgs)
+	case "frozen":
+		return c.frozen(ctx)
+	case "unfreeze":
+		return c.unfreeze(ctx, cmdArgs)
+	case "reload":
+		return c.reload(ctx, cmdArgs)
+	case "tail":
+		return c.tail(ctx, cmdArgs)
+	default:
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", cmd)
+	}
+}
+
+// cli 命令实现
+type cli struct {
+	client *httpadmin.Client
+	json   bool
+}
+
+// state 查看运行状态
+func (c *cli) state(ctx context.Context) error {
+	state, err := c.client.State(ctx)
+	if err != nil {
+		return err
+	}
+	return c.printState(state)
+}
+
+// list 列出支持热加载的配置键模式
+func (c *cli) list(ctx context.Context) error {
+	regs, err := c.client.Registrations(ctx)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(regs)
+	}
+
+	tw := newTabWriter()
+	fmt.Fprintln(tw, "PATTERN\tKIND\tNAME\tMODE\tQUARANTINED\tSITE")
+	for _, r := range regs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%s\n", r.Pattern, r.Kind, r.Name, orDash(string(r.Mode)), r.Quarantined, orDash(r.Site))
+	}
+	return tw.Flush()
+}
+
+// history 查看变更历史
+func (c *cli) history(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "max entries to show (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	entries, err := c.client.History(ctx, *limit)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(entries)
+	}
+
+	tw := newTabWriter()
+	fmt.Fprintln(tw, "TIME\tREVISION\tOUTCOME\tKEY\tOLD\tNEW\tSOURCE\tACTOR\tERROR")
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%q\t%q\t%s\t%s\t%s\n",
+			e.Time.Format(time.RFC3339), formatRevision(e.Revision), e.Outcome, e.Key, e.OldValue, e.NewValue,
+			orDash(e.Source), orDash(e.Actor), e.Error)
+	}
+	return tw.Flush()
+}
+
+// push 推送一次配置变更
+func (c *cli) push(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("push", flag.ContinueOnError)
+	old := fs.String("old", "", "old value passed to handlers")
+	if err := fs.Parse(reorderFlags(args)); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: hotreloadctl push <key> <value> [-old value]")
+	}
+
+	return c.trigger(ctx, httpadmin.ChangeRequest{
+		Key:      fs.Arg(0),
+		OldValue: *old,
+		NewValue: fs.Arg(1),
+	})
+}
+
+// delete 推送一次配置键删除事件
+func (c *cli) delete(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	old := fs.String("old", "", "value before deletion passed to handlers")
+	if err := fs.Parse(reorderFlags(args)); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: hotreloadctl delete <key> [-old value]")
+	}
+
+	return c.trigger(ctx, httpadmin.ChangeRequest{
+		Key:      fs.Arg(0),
+		OldValue: *old,
+		Deleted:  true,
+	})
+}
+
+// trigger 通过管理接口触发配置变更并输出结果
+func (c *cli) trigger(ctx context.Context, req httpadmin.ChangeRequest) error {
+	resp, err := c.client.TriggerChange(ctx, req)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(resp)
+	}
+	switch {
+	case resp.Deferred:
+		fmt.Println("change deferred: hot reload is paused or rate limited")
+		return nil
+	case resp.Outcome == hotreload.OutcomeUnmatched:
+		fmt.Println("change ignored: no handler matched")
+		return nil
+	case resp.Outcome == hotreload.OutcomeSuperseded:
+		fmt.Println("change ignored: superseded by a newer change")
+		return nil
+	}
+	fmt.Printf("change applied (revision %d)\n", resp.Revision)
+	if len(resp.Handlers) == 0 {
+		return nil
+	}
+
+	tw := newTabWriter()
+	fmt.Fprintln(tw, "HANDLER\tPATTERN\tMODE\tSTATUS\tDURATION")
+	for _, h := range resp.Handlers {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", h.Name, h.Pattern, h.Mode, h.Status, h.Duration)
+	}
+	return tw.Flush()
+}
+
+// dryRun 预演配置值
+func (c *cli) dryRun(ctx context.Context, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: hotreloadctl dry-run <key> <value>")
+	}
+
+	result, err := c.client.DryRun(ctx, httpadmin.DryRunRequest{Key: args[0], Value: args[1]})
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(result)
+	}
+
+	fmt.Printf("matched patterns: %s\n", strings.Join(result.MatchedPatterns, ", "))
+	if !result.Valid {
+		return fmt.Errorf("invalid: %s", result.Error)
+	}
+	fmt.Println("valid")
+	return nil
+}
+
+// rollback 回滚指定修订号的变更
+func (c *cli) rollback(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: hotreloadctl rollback <revision>")
+	}
+	revision, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid revision %q: %w", args[0], err)
+	}
+
+	resp, err := c.client.Rollback(ctx, revision)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(resp)
+	}
+	fmt.Printf("revision %d rolled back (revision %d)\n", revision, resp.Revision)
+	return nil
+}
+
+// pause 暂停配置变更分发
+func (c *cli) pause(ctx context.Context) error {
+	state, err := c.client.Pause(ctx)
+	if err != nil {
+		return err
+	}
+	return c.printState(state)
+}
+
+// resume 恢复配置变更分发
+func (c *cli) resume(ctx context.Context) error {
+	state, err := c.client.Resume(ctx)
+	if err != nil {
+		return err
+	}
+	return c.printState(state)
+}
+
+// effective 查看配置键的生效值及其来源层
+func (c *cli) effective(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: hotreloadctl effective [key]")
+	}
+
+	var values []hotreload.EffectiveValue
+	if len(args) == 1 {
+		value, err := c.client.Effective(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		if c.json {
+			return printJSON(value)
+		}
+		values = []hotreload.EffectiveValue{*value}
+	} else {
+		var err error
+		if values, err = c.client.EffectiveAll(ctx); err != nil {
+			return err
+		}
+	}
+	if c.json {
+		return printJSON(values)
+	}
+
+	tw := newTabWriter()
+	fmt.Fprintln(tw, "KEY\tVALUE\tLAYER\tSOURCE\tREVISION\tDEFAULT")
+	for _, v := range values {
+		def := "-"
+		if v.HasDefault {
+			def = fmt.Sprintf("%q", v.Default)
+		}
+		fmt.Fprintf(tw, "%s\t%q\t%s\t%s\t%d\t%s\n", v.Key, v.Value, v.Layer, orDash(v.Source), v.Revision, def)
+	}
+	return tw.Flush()
+}
+
+// frozen 列出因抖动被冻结的配置键
+func (c *cli) frozen(ctx context.Context) error {
+	frozen, err := c.client.Frozen(ctx)
+	if err != nil {
+		return err
+	}
+	return c.printFrozen(frozen)
+}
+
+// unfreeze 解除配置键的冻结
+func (c *cli) unfreeze(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: hotreloadctl unfreeze <key>")
+	}
+
+	frozen, err := c.client.Unfreeze(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(frozen)
+	}
+	fmt.Printf("key %s unfrozen (%d still frozen)\n", args[0], len(frozen))
+	return nil
+}
+
+// reload 以当前值强制重新应用配置键
+func (c *cli) reload(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: hotreloadctl reload [prefix]")
+	}
+	prefix := ""
+	if len(args) == 1 {
+		prefix = args[0]
+	}
+
+	resp, err := c.client.Reload(ctx, prefix)
+	if err != nil {
+		return err
+	}
+	if c.json {
+		return printJSON(resp)
+	}
+	fmt.Printf("%d keys reloaded (revision %d)\n", resp.Reloaded, resp.Revision)
+	return nil
+}
+
+// printFrozen 输出被冻结的配置键
+func (c *cli) printFrozen(frozen []hotreload.FrozenKey) error {
+	if c.json {
+		return printJSON(frozen)
+	}
+
+	tw := newTabWriter()
+	fmt.Fprintln(tw, "KEY\tVALUE\tREVERSIONS\tFROZEN AT")
+	for _, f := range frozen {
+		fmt.Fprintf(tw, "%s\t%q\t%d\t%s\n", f.Key, f.Value, f.Reversions, f.FrozenAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+// tail 实时查看热加载事件
+func (c *cli) tail(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tail", flag.ContinueOnError)
+	pattern := fs.String("pattern", "", "only show events for keys matching pattern")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	err := c.client.TailEvents(ctx, *pattern, func(event hotreload.Event) error {
+		if c.json {
+			return printJSON(event)
+		}
+		fmt.Printf("%s %-20s %s %q -> %q", event.Time.Format(time.RFC3339), event.Type, event.Key, event.OldValue, event.NewValue)
+		if event.Revision > 0 {
+			fmt.Printf(" revision=%d", event.Revision)
+		}
+		if event.Actor != "" {
+			fmt.Printf(" actor=%s", event.Actor)
+		}
+		if event.Error != "" {
+			fmt.Printf(" pattern=%s error=%q", event.Pattern, event.Error)
+		}
+		fmt.Println()
+		return nil
+	})
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// printState 输出运行状态
+func (c *cli) printState(state *httpadmin.State) error {
+	if c.json {
+		return printJSON(state)
+	}
+
+	tw := newTabWriter()
+	fmt.Fprintf(tw, "revision:\t%d\n", state.Revision)
+	if state.LastAppliedAt != nil {
+		fmt.Fprintf(tw, "last applied:\t%s\n", state.LastAppliedAt.Format(time.RFC3339))
+	}
+	fmt.Fprintf(tw, "paused:\t%t\n", state.Paused)
+	fmt.Fprintf(tw, "pending changes:\t%d\n", state.PendingChanges)
+	fmt.Fprintf(tw, "health:\t%s\n", state.Health.Status)
+	for _, reason := range state.Health.Reasons {
+		fmt.Fprintf(tw, "\t- %s\n", reason)
+	}
+	return tw.Flush()
+}
+
+// printJSON 以 JSON 格式输出
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// newTabWriter 创建表格输出
+func newTabWriter() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+}
+
+// formatRevision 格式化修订号（0 表示未应用）
+func formatRevision(revision uint64) string {
+	if revision == 0 {
+		return "-"
+	}
+	return strconv.FormatUint(revision, 10)
+}
+
+// orDash 空字符串显示为 "-"
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// reorderFlags 将标志参数移到位置参数之前，允许 "push key value -old v" 的写法
+func reorderFlags(args []string) []string {
+	var flags, positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "-") && len(arg) > 1 {
+			flags = append(flags, arg)
+			if !strings.Contains(arg, "=") && i+1 < len(args) {
+				flags = append(flags, args[i+1])
+				i++
+			}
+			continue
+		}
+		positional = append(positional, arg)
+	}
+	return append(flags, positional...)
+}
+
+// envOr 读取环境变量，未设置时返回默认值
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
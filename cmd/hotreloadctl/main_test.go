@@ -0,0 +1,106 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/go-anyway/framework-hotreload/httpadmin"
+)
+
+func TestReorderFlags(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"key", "value"}, []string{"key", "value"}},
+		{[]string{"key", "value", "-old", "v0"}, []string{"-old", "v0", "key", "value"}},
+		{[]string{"key", "-old=v0", "value"}, []string{"-old=v0", "key", "value"}},
+		{[]string{"-old", "v0", "key"}, []string{"-old", "v0", "key"}},
+		{[]string{"key", "-"}, []string{"key", "-"}},
+	}
+	for _, tt := range tests {
+		if got := reorderFlags(tt.args); !slices.Equal(got, tt.want) {
+			t.Errorf("reorderFlags(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestRunRejectsInvalidArguments(t *testing.T) {
+	t.Setenv("HOTRELOAD_ADDR", "http://127.0.0.1:0")
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, "command is required"},
+		{[]string{"bogus"}, `unknown command "bogus"`},
+		{[]string{"-limit", "5", "history"}, "flag provided but not defined"},
+		{[]string{"push", "server.port"}, "usage: hotreloadctl push"},
+		{[]string{"delete"}, "usage: hotreloadctl delete"},
+		{[]string{"rollback", "abc"}, `invalid revision "abc"`},
+		{[]string{"rollback"}, "usage: hotreloadctl rollback"},
+		{[]string{"unfreeze"}, "usage: hotreloadctl unfreeze"},
+		{[]string{"reload", "a", "b"}, "usage: hotreloadctl reload"},
+	}
+	for _, tt := range tests {
+		err := run(tt.args)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("run(%q) error = %v, want %q", tt.args, err, tt.want)
+		}
+	}
+}
+
+func TestRunPushSendsTokenAndOldValue(t *testing.T) {
+	manager := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	defer manager.Close(context.Background())
+	var oldValues []string
+	if err := manager.RegisterHandler("server.*", func(key, oldValue, newValue string) error {
+		oldValues = append(oldValues, oldValue)
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	server := httptest.NewServer(httpadmin.Handler(manager, httpadmin.WithAuth(func(r *http.Request) (string, error) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			return "", errors.New("invalid token")
+		}
+		return "ops", nil
+	})))
+	defer server.Close()
+
+	if err := run([]string{"-addr", server.URL, "push", "server.port", "8080"}); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("run(push) without token error = %v, want status 401", err)
+	}
+	if err := run([]string{"-addr", server.URL, "-token", "secret", "-o", "json", "push", "server.port", "8080", "-old", "80"}); err != nil {
+		t.Fatalf("run(push) error = %v", err)
+	}
+
+	applied, ok := manager.LastApplied("server.port")
+	if !ok || applied.Value != "8080" || applied.Actor != "ops" {
+		t.Fatalf("LastApplied() = %+v, want 8080 by ops", applied)
+	}
+	if !slices.Equal(oldValues, []string{"80"}) {
+		t.Fatalf("handler old values = %q, want [80]", oldValues)
+	}
+}
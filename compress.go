@@ -0,0 +1,190 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// DefaultMaxDecompressedSize 默认的解压后配置值大小上限
+const DefaultMaxDecompressedSize = 16 << 20
+
+// Compression 配置值压缩算法
+type Compression string
+
+const (
+	// CompressionGzip gzip 压缩，配置值前缀为 "gzip:"
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd zstd 压缩，配置值前缀为 "zstd:"
+	CompressionZstd Compression = "zstd"
+)
+
+// prefix 返回压缩配置值的前缀
+func (c Compression) prefix() string {
+	return string(c) + ":"
+}
+
+// CompressValue 将配置值压缩并编码为带前缀的字符串（如 "gzip:H4sI..."），用于向有大小限制的配置中心推送大配置值
+func CompressValue(compression Compression, value string) (string, error) {
+	var buf bytes.Buffer
+	switch compression {
+	case CompressionGzip:
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write([]byte(value)); err != nil {
+			return "", err
+		}
+		if err := w.Close(); err != nil {
+			return "", err
+		}
+	case CompressionZstd:
+		// 一次性编码会在帧头写入内容大小，解压端按内容大小而非默认窗口分配内存
+		w, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+		if err != nil {
+			return "", err
+		}
+		buf.Write(w.EncodeAll([]byte(value), nil))
+		if err := w.Close(); err != nil {
+			return "", err
+		}
+	default:
+		return "", fmt.Errorf("unsupported compression: %s", compression)
+	}
+	return compression.prefix() + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// WithValueDecompression 开启压缩配置值的透明解压
+// 以 "gzip:" 或 "zstd:" 为前缀、其后为 base64 编码压缩数据的配置值（见 CompressValue）在过滤器、验证与分发前解压，
+// 处理器、变更历史与快照中看到的都是解压后的值；maxSize 为解压后的大小上限（<= 0 表示 DefaultMaxDecompressedSize），
+// 解压失败或超出上限的配置变更以验证失败拒绝
+func WithValueDecompression(maxSize int64) Option {
+	return func(m *Manager) {
+		if maxSize <= 0 {
+			maxSize = DefaultMaxDecompressedSize
+		}
+		m.decompressor = &decompressor{maxSize: maxSize}
+	}
+}
+
+// decompressor 压缩配置值解压器
+type decompressor struct {
+	// 解压后的大小上限
+	maxSize int64
+
+	// zstd 解码器（首次使用时创建，可并发使用）
+	zstdOnce    sync.Once
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+}
+
+// decode 解压带压缩前缀的配置值，其他配置值原样返回
+func (d *decompressor) decode(value string) (string, error) {
+	for _, compression := range []Compression{CompressionGzip, CompressionZstd} {
+		encoded, ok := strings.CutPrefix(value, compression.prefix())
+		if !ok {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return "", fmt.Errorf("invalid base64 in %s value: %w", compression, err)
+		}
+		var decoded []byte
+		switch compression {
+		case CompressionGzip:
+			decoded, err = d.gunzip(data)
+		case CompressionZstd:
+			decoded, err = d.unzstd(data)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress %s value: %w", compression, err)
+		}
+		return string(decoded), nil
+	}
+	return value, nil
+}
+
+// gunzip 解压 gzip 数据，超出大小上限时返回错误
+func (d *decompressor) gunzip(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	decoded, err := io.ReadAll(io.LimitReader(r, d.maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decoded)) > d.maxSize {
+		return nil, fmt.Errorf("decompressed size exceeds limit of %d bytes", d.maxSize)
+	}
+	return decoded, nil
+}
+
+// unzstd 解压 zstd 数据，超出大小上限时返回错误
+func (d *decompressor) unzstd(data []byte) ([]byte, error) {
+	d.zstdOnce.Do(func() {
+		// 解码器的内存上限不能小于 zstd 帧的最小窗口，过小的 maxSize 由解压后的长度检查兜底
+		d.zstdDecoder, d.zstdErr = zstd.NewReader(nil,
+			zstd.WithDecoderConcurrency(0),
+			zstd.WithDecoderMaxMemory(uint64(max(d.maxSize, 1<<20))))
+	})
+	if d.zstdErr != nil {
+		return nil, d.zstdErr
+	}
+	decoded, err := d.zstdDecoder.DecodeAll(data, nil)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(decoded)) > d.maxSize {
+		return nil, fmt.Errorf("decompressed size exceeds limit of %d bytes", d.maxSize)
+	}
+	return decoded, nil
+}
+
+// close 释放 zstd 解码器
+func (d *decompressor) close() {
+	d.zstdOnce.Do(func() {
+		d.zstdErr = ErrManagerClosed
+	})
+	if d.zstdDecoder != nil {
+		d.zstdDecoder.Close()
+	}
+}
+
+// decompressChange 解压配置变更的新旧值，失败时返回验证失败错误
+func (m *Manager) decompressChange(change *Change) *ChangeError {
+	if m.decompressor == nil {
+		return nil
+	}
+	newValue, err := m.decompressor.decode(change.NewValue)
+	if err != nil {
+		return &ChangeError{Kind: ErrorKindValidation, Key: change.Key, Err: err}
+	}
+	// 旧值无法解压时保留原值，不影响新值的应用
+	if oldValue, err := m.decompressor.decode(change.OldValue); err == nil {
+		change.OldValue = oldValue
+	}
+	change.NewValue = newValue
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// ConcurrencyMetrics 可选的指标扩展接口
+// 通过 WithMetrics 设置的指标实现同时实现该接口时，开启 WithMaxConcurrentChanges 后每次排队等待执行槽位都会被记录
+type ConcurrencyMetrics interface {
+	// ObserveConcurrencyWait 记录一次排队等待
+	// wait: 等待耗时（等待被取消时为取消前的耗时）
+	// queueDepth: 开始等待时排队中的配置变更数（包括本次）
+	ObserveConcurrencyWait(wait time.Duration, queueDepth int)
+}
+
+// ConcurrencyStats 配置变更并发限制的运行状态
+type ConcurrencyStats struct {
+	// 同时执行的配置变更数上限（0 表示不限制）
+	Limit int `json:"limit"`
+	// 正在执行的配置变更数
+	InFlight int `json:"in_flight"`
+	// 正在排队等待的配置变更数
+	Waiting int64 `json:"waiting"`
+	// 历史最大排队数
+	MaxWaiting int64 `json:"max_waiting"`
+	// 需要排队等待的配置变更数
+	Waits uint64 `json:"waits"`
+	// 因 context 取消而放弃等待的配置变更数
+	WaitsCanceled uint64 `json:"waits_canceled"`
+	// 累计等待时间
+	TotalWait time.Duration `json:"total_wait"`
+	// 最长一次等待时间
+	MaxWait time.Duration `json:"max_wait"`
+}
+
+// concurrencyLimiter 全局配置变更并发限制器
+type concurrencyLimiter struct {
+	// 执行槽位（nil 表示不限制）
+	slots chan struct{}
+
+	waiting       atomic.Int64
+	maxWaiting    atomic.Int64
+	waits         atomic.Uint64
+	waitsCanceled atomic.Uint64
+	waitNanos     atomic.Int64
+	maxWaitNanos  atomic.Int64
+}
+
+// concurrencySlotKey 标记 context 已持有执行槽位
+type concurrencySlotKey struct{}
+
+// WithMaxConcurrentChanges 限制同时执行（调用处理器）的配置变更数（默认不限制）
+// 超出限制的配置变更排队等待执行槽位，等待期间 context 被取消时放弃分发并返回错误，
+// 避免配置变更风暴（如大量并发分发或 ApplyBatch 并发分发的并行处理器）产生无界的处理器负载。
+// 处理器内部以收到的 context 再次分发配置变更时沿用已持有的槽位，不会因槽位耗尽而死锁
+func WithMaxConcurrentChanges(n int) Option {
+	return func(m *Manager) {
+		if n > 0 {
+			m.concurrency.slots = make(chan struct{}, n)
+		} else {
+			m.concurrency.slots = nil
+		}
+	}
+}
+
+// acquireSlot 获取执行槽位，返回标记了已持有槽位的 context 与释放函数
+// 未开启并发限制或 context 已持有槽位时直接返回
+func (m *Manager) acquireSlot(ctx context.Context, key string) (context.Context, func(), error) {
+	l := &m.concurrency
+	if l.slots == nil || ctx.Value(concurrencySlotKey{}) != nil {
+		return ctx, func() {}, nil
+	}
+
+	release := func() { <-l.slots }
+	select {
+	case l.slots <- struct{}{}:
+		return context.WithValue(ctx, concurrencySlotKey{}, true), release, nil
+	default:
+	}
+
+	depth := l.waiting.Add(1)
+	defer l.waiting.Add(-1)
+	for {
+		current := l.maxWaiting.Load()
+		if depth <= current || l.maxWaiting.CompareAndSwap(current, depth) {
+			break
+		}
+	}
+	l.waits.Add(1)
+
+	start := time.Now()
+	var err error
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	wait := time.Since(start)
+	l.waitNanos.Add(wait.Nanoseconds())
+	for {
+		current := l.maxWaitNanos.Load()
+		if wait.Nanoseconds() <= current || l.maxWaitNanos.CompareAndSwap(current, wait.Nanoseconds()) {
+			break
+		}
+	}
+	if cm, ok := m.metrics.(ConcurrencyMetrics); ok {
+		cm.ObserveConcurrencyWait(wait, int(depth))
+	}
+
+	if err != nil {
+		l.waitsCanceled.Add(1)
+		m.logger.Warn("Config change abandoned while waiting for a concurrency slot",
+			"key", key,
+			"wait", wait,
+			"error", err)
+		return ctx, nil, fmt.Errorf("waiting for concurrency slot for key %s: %w", key, err)
+	}
+	return context.WithValue(ctx, concurrencySlotKey{}, true), release, nil
+}
+
+// ConcurrencyStats 返回配置变更并发限制的运行状态（排队深度与等待时间）
+func (m *Manager) ConcurrencyStats() ConcurrencyStats {
+	if m == nil {
+		return ConcurrencyStats{}
+	}
+
+	l := &m.concurrency
+	return ConcurrencyStats{
+		Limit:         cap(l.slots),
+		InFlight:      len(l.slots),
+		Waiting:       l.waiting.Load(),
+		MaxWaiting:    l.maxWaiting.Load(),
+		Waits:         l.waits.Load(),
+		WaitsCanceled: l.waitsCanceled.Load(),
+		TotalWait:     time.Duration(l.waitNanos.Load()),
+		MaxWait:       time.Duration(l.maxWaitNanos.Load()),
+	}
+}
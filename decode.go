@@ -0,0 +1,219 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultTagName 结构化配置值解码默认使用的结构体标签
+const defaultTagName = "json"
+
+// DecodeOption 结构化配置值解码选项
+type DecodeOption func(*decodeOptions)
+
+// decodeOptions 解码配置
+type decodeOptions struct {
+	tagName string
+	strict  bool
+}
+
+// WithTagName 设置字段名映射使用的结构体标签（默认 "json"）
+func WithTagName(tagName string) DecodeOption {
+	return func(o *decodeOptions) {
+		if tagName != "" {
+			o.tagName = tagName
+		}
+	}
+}
+
+// WithStrictDecode 配置值中存在目标结构体没有的字段时返回错误
+func WithStrictDecode() DecodeOption {
+	return func(o *decodeOptions) {
+		o.strict = true
+	}
+}
+
+// Decode 将 JSON/YAML 格式的配置值解码为 T
+// 使用 mapstructure 弱类型解码（如 "8080" 可解码为 int，"5s" 可解码为 time.Duration），
+// 结构体字段的 default 标签作为配置值中缺失字段的默认值；配置值为空时只应用默认值
+func Decode[T any](value string, opts ...DecodeOption) (T, error) {
+	var result T
+	err := DecodeInto(value, &result, opts...)
+	return result, err
+}
+
+// DecodeInto 将 JSON/YAML 格式的配置值解码到 target（必须为指针）
+// target 中已有的值在配置值缺失对应字段时保留，default 标签只应用于零值字段
+func DecodeInto(value string, target any, opts ...DecodeOption) error {
+	o := &decodeOptions{tagName: defaultTagName}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", target)
+	}
+	if err := applyDefaults(rv.Elem(), o); err != nil {
+		return err
+	}
+
+	input, err := parseStructured(value)
+	if err != nil || input == nil {
+		return err
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           target,
+		TagName:          o.tagName,
+		WeaklyTypedInput: true,
+		ErrorUnused:      o.strict,
+		DecodeHook:       decodeHook(),
+	})
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(input); err != nil {
+		return fmt.Errorf("failed to decode value: %w", err)
+	}
+	return nil
+}
+
+// decodeHook 返回字符串到时长、列表与 encoding.TextUnmarshaler 类型的解码钩子
+func decodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.TextUnmarshallerHookFunc(),
+	)
+}
+
+// parseStructured 解析 JSON/YAML 格式的配置值，空值返回 nil
+func parseStructured(value string) (any, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	var input any
+	if strings.HasPrefix(value, "{") || strings.HasPrefix(value, "[") {
+		if err := json.Unmarshal([]byte(value), &input); err != nil {
+			return nil, fmt.Errorf("invalid json value: %w", err)
+		}
+		return input, nil
+	}
+	if err := yaml.Unmarshal([]byte(value), &input); err != nil {
+		return nil, fmt.Errorf("invalid yaml value: %w", err)
+	}
+	return input, nil
+}
+
+// applyDefaults 将 default 标签应用到零值字段（递归处理嵌套结构体）
+func applyDefaults(v reflect.Value, o *decodeOptions) error {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fv := v.Field(i)
+		if field.Type.Kind() == reflect.Struct {
+			if err := applyDefaults(fv, o); err != nil {
+				return err
+			}
+			continue
+		}
+		def, ok := field.Tag.Lookup("default")
+		if !ok || !fv.IsZero() {
+			continue
+		}
+		// 借助弱类型解码将字符串默认值转换为字段类型
+		holder := reflect.New(field.Type)
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:           holder.Interface(),
+			WeaklyTypedInput: true,
+			DecodeHook:       decodeHook(),
+		})
+		if err == nil {
+			err = decoder.Decode(def)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid default for field %s: %w", field.Name, err)
+		}
+		fv.Set(holder.Elem())
+	}
+	return nil
+}
+
+// subscription 结构化配置值订阅，以重载器形式注册
+type subscription[T any] struct {
+	pattern string
+	fn      func(ctx context.Context, key string, value T) error
+	opts    []DecodeOption
+}
+
+// Subscribe 订阅匹配 pattern 的结构化配置键，配置值按 Decode 解码为 T 后交给 fn
+// 解码失败按验证失败处理，fn 不会被调用
+func Subscribe[T any](m *Manager, pattern string, fn func(ctx context.Context, key string, value T) error, opts ...DecodeOption) error {
+	if fn == nil {
+		return fmt.Errorf("subscribe handler is nil")
+	}
+	return m.RegisterReloader(&subscription[T]{pattern: pattern, fn: fn, opts: opts})
+}
+
+// Name 返回订阅名称
+func (s *subscription[T]) Name() string {
+	return "subscribe[" + reflect.TypeFor[T]().String() + "]"
+}
+
+// Patterns 返回配置键模式
+func (s *subscription[T]) Patterns() []string {
+	return []string{s.pattern}
+}
+
+// Validate 验证配置值可以解码为 T
+func (s *subscription[T]) Validate(key, value string) error {
+	_, err := Decode[T](value, s.opts...)
+	return err
+}
+
+// OnChange 解码配置值并调用订阅函数
+func (s *subscription[T]) OnChange(key, oldValue, newValue string) error {
+	return s.OnChangeContext(context.Background(), Change{Key: key, OldValue: oldValue, NewValue: newValue})
+}
+
+// OnChangeContext 解码配置值并调用订阅函数
+func (s *subscription[T]) OnChangeContext(ctx context.Context, change Change) error {
+	value, err := Decode[T](change.NewValue, s.opts...)
+	if err != nil {
+		return err
+	}
+	return s.fn(ctx, change.Key, value)
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+)
+
+// DefaultsProvider 可选的重载器扩展接口
+// 实现该接口的重载器在注册时将 Defaults 返回的默认值登记到管理器（见 SetDefault）
+type DefaultsProvider interface {
+	// Defaults 返回配置键（精确配置键，不支持通配符）到默认值的映射
+	Defaults() map[string]string
+}
+
+// defaultsRegistry 配置键默认值注册表
+type defaultsRegistry struct {
+	values map[string]string
+
+	mu sync.RWMutex
+}
+
+// SetDefault 登记配置键的默认值
+// 配置键被删除（Change.Deleted）时，管理器改为以默认值作为新值走完整的验证与分发流程（Change.Defaulted 为 true），
+// 组件不会停留在已被移除的覆盖配置上；重复登记时以最后一次为准
+func (m *Manager) SetDefault(key, value string) {
+	if m == nil {
+		return
+	}
+	m.SetDefaults(map[string]string{key: value})
+}
+
+// SetDefaults 批量登记配置键的默认值
+func (m *Manager) SetDefaults(defaults map[string]string) {
+	if m == nil || len(defaults) == 0 {
+		return
+	}
+
+	m.defaults.mu.Lock()
+	defer m.defaults.mu.Unlock()
+
+	if m.defaults.values == nil {
+		m.defaults.values = make(map[string]string, len(defaults))
+	}
+	for key, value := range defaults {
+		m.defaults.values[m.NormalizeKey(key)] = value
+	}
+}
+
+// RemoveDefault 移除配置键的默认值，此后该配置键被删除时按删除事件分发
+func (m *Manager) RemoveDefault(key string) {
+	if m == nil {
+		return
+	}
+
+	m.defaults.mu.Lock()
+	defer m.defaults.mu.Unlock()
+	delete(m.defaults.values, m.NormalizeKey(key))
+}
+
+// Default 查询配置键登记的默认值
+func (m *Manager) Default(key string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+
+	m.defaults.mu.RLock()
+	defer m.defaults.mu.RUnlock()
+	value, ok := m.defaults.values[m.NormalizeKey(key)]
+	return value, ok
+}
+
+// Defaults 返回所有已登记默认值的副本
+func (m *Manager) Defaults() map[string]string {
+	if m == nil {
+		return nil
+	}
+
+	m.defaults.mu.RLock()
+	defer m.defaults.mu.RUnlock()
+	result := maps.Clone(m.defaults.values)
+	if result == nil {
+		result = map[string]string{}
+	}
+	return result
+}
+
+// applyDefault 将已登记默认值的删除事件改写为以默认值为新值的变更（配置键需已规范化）
+func (m *Manager) applyDefault(change *Change) {
+	if !change.Deleted {
+		return
+	}
+
+	m.defaults.mu.RLock()
+	value, ok := m.defaults.values[change.Key]
+	m.defaults.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	change.NewValue = value
+	change.Deleted = false
+	change.Defaulted = true
+}
+
+// RevertSource 撤销指定配置源下发的所有配置：对最近一次由 source 成功应用的每个配置键投递删除事件，
+// 已登记默认值的配置键回退到默认值，其余配置键交给 DeleteAwareReloader 处理
+// 用于配置源被移除或永久下线时，避免组件停留在该配置源遗留的覆盖配置上；所有变更作为一个批次应用（见 ApplyBatch）
+func (m *Manager) RevertSource(ctx context.Context, source string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if source == "" {
+		return fmt.Errorf("source is empty")
+	}
+
+	var changes []Change
+	for _, applied := range m.store.list() {
+		if applied.Source != source {
+			continue
+		}
+		changes = append(changes, Change{
+			Key:      applied.Key,
+			OldValue: applied.Value,
+			Deleted:  true,
+			Source:   source,
+		})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	m.logger.Info("Reverting config applied by source",
+		"source", source,
+		"key_count", len(changes))
+	return m.ApplyBatch(ctx, changes)
+}
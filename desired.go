@@ -0,0 +1,170 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SourceDesiredState ApplyDesiredState 未指定来源时产生的配置变更来源
+const SourceDesiredState = "desired-state"
+
+// DesiredStateOption ApplyDesiredState 选项
+type DesiredStateOption func(*desiredStateOptions)
+
+// desiredStateOptions ApplyDesiredState 选项
+type desiredStateOptions struct {
+	source string
+	prefix string
+}
+
+// WithDesiredSource 设置期望状态的来源：产生的变更以 source 作为 Source，
+// 且只删除最近一次由 source 应用的配置键，其他来源（如管理接口）下发的配置键不受影响
+// 适用于只下发完整配置文档的配置源
+func WithDesiredSource(source string) DesiredStateOption {
+	return func(o *desiredStateOptions) {
+		o.source = source
+	}
+}
+
+// WithDesiredPrefix 将期望状态限定在前缀下：只比较、创建、更新与删除前缀下的配置键
+func WithDesiredPrefix(prefix string) DesiredStateOption {
+	return func(o *desiredStateOptions) {
+		o.prefix = prefix
+	}
+}
+
+// DesiredStateResult 期望状态与已应用配置的差异（配置键均按字典序）
+type DesiredStateResult struct {
+	// 尚未应用、需要创建的配置键
+	Created []string `json:"created,omitempty"`
+	// 值不一致、需要更新的配置键
+	Updated []string `json:"updated,omitempty"`
+	// 不在期望状态中、需要删除的配置键
+	Deleted []string `json:"deleted,omitempty"`
+	// 没有任何处理器匹配而被忽略的配置键
+	Ignored []string `json:"ignored,omitempty"`
+}
+
+// PlanDesiredState 计算使已应用的配置达到期望状态所需的最小变更集合，不应用任何变更
+func (m *Manager) PlanDesiredState(desired map[string]string, opts ...DesiredStateOption) DesiredStateResult {
+	if m == nil {
+		return DesiredStateResult{}
+	}
+	result, _ := m.planDesiredState(desired, opts)
+	return result
+}
+
+// ApplyDesiredState 将完整的期望配置（配置键 -> 值）与已应用的配置比较，计算出最小的创建、更新与删除变更集合，
+// 并通过 ApplyBatch 作为一个批次应用；适用于只下发完整配置文档的配置源，以及从保存的快照恢复配置
+// 返回计算出的差异以及所有失败的合并错误
+func (m *Manager) ApplyDesiredState(ctx context.Context, desired map[string]string, opts ...DesiredStateOption) (DesiredStateResult, error) {
+	if m == nil {
+		return DesiredStateResult{}, fmt.Errorf("manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, changes := m.planDesiredState(desired, opts)
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	m.logger.Info("Applying desired config state",
+		"created", len(result.Created),
+		"updated", len(result.Updated),
+		"deleted", len(result.Deleted),
+		"ignored", len(result.Ignored))
+	return result, m.ApplyBatch(ctx, changes)
+}
+
+// planDesiredState 计算期望状态与已应用配置的差异及对应的配置变更
+func (m *Manager) planDesiredState(desired map[string]string, opts []DesiredStateOption) (DesiredStateResult, []Change) {
+	var options desiredStateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	source := options.source
+	if source == "" {
+		source = SourceDesiredState
+	}
+	prefix := m.NormalizeKey(strings.TrimSuffix(options.prefix, "."))
+
+	wanted := make(map[string]string, len(desired))
+	for key, value := range desired {
+		key = m.NormalizeKey(key)
+		if underPrefix(key, prefix) {
+			wanted[key] = value
+		}
+	}
+
+	var result DesiredStateResult
+	var changes []Change
+	applied := make(map[string]struct{})
+	for _, current := range m.store.list() {
+		if !underPrefix(current.Key, prefix) {
+			continue
+		}
+		applied[current.Key] = struct{}{}
+
+		value, ok := wanted[current.Key]
+		switch {
+		case !ok:
+			if options.source != "" && current.Source != options.source {
+				continue
+			}
+			// 删除已回退到默认值的配置键只会再次应用默认值
+			if m.atDefault(current) {
+				continue
+			}
+			result.Deleted = append(result.Deleted, current.Key)
+			changes = append(changes, Change{Key: current.Key, OldValue: current.Value, Deleted: true, Source: source})
+		case value != current.Value:
+			result.Updated = append(result.Updated, current.Key)
+			changes = append(changes, Change{Key: current.Key, OldValue: current.Value, NewValue: value, Source: source})
+		}
+	}
+	for key, value := range wanted {
+		if _, ok := applied[key]; ok {
+			continue
+		}
+		if len(m.match(key, nil)) == 0 {
+			result.Ignored = append(result.Ignored, key)
+			continue
+		}
+		result.Created = append(result.Created, key)
+		changes = append(changes, Change{Key: key, NewValue: value, Source: source})
+	}
+
+	sort.Strings(result.Created)
+	sort.Strings(result.Ignored)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return result, changes
+}
+
+// atDefault 判断已应用的值是否为配置键的默认值（来自默认值注册表，或与登记的默认值相同）
+func (m *Manager) atDefault(current AppliedValue) bool {
+	if current.Defaulted {
+		return true
+	}
+	value, ok := m.Default(current.Key)
+	return ok && value == current.Value
+}
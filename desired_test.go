@@ -0,0 +1,63 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"testing"
+)
+
+func TestApplyDesiredStateIsStableWithDefaults(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+	if err := m.RegisterHandler("server.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	m.SetDefault("server.timeout", "30s")
+
+	ctx := context.Background()
+	if _, err := m.ApplyDesiredState(ctx, map[string]string{
+		"server.port":    "8080",
+		"server.timeout": "10s",
+	}); err != nil {
+		t.Fatalf("ApplyDesiredState() error = %v", err)
+	}
+
+	desired := map[string]string{"server.port": "8080"}
+	result, err := m.ApplyDesiredState(ctx, desired)
+	if err != nil {
+		t.Fatalf("ApplyDesiredState() error = %v", err)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != "server.timeout" {
+		t.Fatalf("ApplyDesiredState() deleted = %v, want [server.timeout]", result.Deleted)
+	}
+	if applied, _ := m.LastApplied("server.timeout"); !applied.Defaulted || applied.Value != "30s" {
+		t.Fatalf("LastApplied(server.timeout) = %+v, want default 30s", applied)
+	}
+
+	revision := m.Revision()
+	result, err = m.ApplyDesiredState(ctx, desired)
+	if err != nil {
+		t.Fatalf("ApplyDesiredState() error = %v", err)
+	}
+	if len(result.Created)+len(result.Updated)+len(result.Deleted) != 0 {
+		t.Fatalf("second ApplyDesiredState() = %+v, want no changes", result)
+	}
+	if got := m.Revision(); got != revision {
+		t.Fatalf("Revision() = %d after identical ApplyDesiredState, want %d", got, revision)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"testing"
+)
+
+// newDispatchBenchManager 创建用于分发基准测试的管理器（静默日志、关闭 pprof 标签）
+func newDispatchBenchManager(tb testing.TB) *Manager {
+	tb.Helper()
+	m := NewManager(WithLogger(NopLogger()), WithPprofLabels(false))
+	tb.Cleanup(func() { _ = m.Close(context.Background()) })
+	if err := m.RegisterHandler("server.http.port", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		tb.Fatalf("RegisterHandler() error = %v", err)
+	}
+	if err := m.RegisterHandler("server.features.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		tb.Fatalf("RegisterHandler() error = %v", err)
+	}
+	return m
+}
+
+func TestExactMatchDispatchDoesNotAllocate(t *testing.T) {
+	m := newDispatchBenchManager(t)
+	ctx := context.Background()
+	change := Change{Key: "server.http.port", OldValue: "8080", NewValue: "8081"}
+	if err := m.Apply(ctx, change); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := m.Apply(ctx, change); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("exact-match Apply allocations = %v, want 0", allocs)
+	}
+}
+
+func TestUnmatchedDispatchDoesNotAllocate(t *testing.T) {
+	m := newDispatchBenchManager(t)
+	ctx := context.Background()
+	change := Change{Key: "unknown.key", OldValue: "a", NewValue: "b"}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = m.Apply(ctx, change)
+	})
+	if allocs != 0 {
+		t.Fatalf("unmatched Apply allocations = %v, want 0", allocs)
+	}
+}
+
+func BenchmarkDispatchExactMatch(b *testing.B) {
+	m := newDispatchBenchManager(b)
+	ctx := context.Background()
+	change := Change{Key: "server.http.port", OldValue: "8080", NewValue: "8081"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = m.Apply(ctx, change)
+	}
+}
+
+func BenchmarkDispatchWildcardMatch(b *testing.B) {
+	m := newDispatchBenchManager(b)
+	ctx := context.Background()
+	change := Change{Key: "server.features.rate_limit.rate", OldValue: "100", NewValue: "200"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = m.Apply(ctx, change)
+	}
+}
+
+func BenchmarkDispatchUnmatched(b *testing.B) {
+	m := newDispatchBenchManager(b)
+	ctx := context.Background()
+	change := Change{Key: "unknown.key", OldValue: "a", NewValue: "b"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = m.Apply(ctx, change)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"sort"
+	"time"
+)
+
+// ConfigLayer 生效配置值的来源层
+type ConfigLayer string
+
+const (
+	// LayerDefault 默认值：配置键未被应用过，或被删除后回退到默认值（见 SetDefault）
+	LayerDefault ConfigLayer = "default"
+	// LayerSource 配置源：由文件、配置中心等配置源下发
+	LayerSource ConfigLayer = "source"
+	// LayerOverride 人工覆盖：由管理接口、回滚或 WithOverrideSources 指定的来源产生
+	LayerOverride ConfigLayer = "override"
+	// LayerFallback 快照回退：配置源不可用时从最近一次快照恢复（见 WithSnapshotFallback）
+	LayerFallback ConfigLayer = "fallback"
+	// LayerProfile 配置档：由当前激活的配置档覆盖（见 ActivateProfile）
+	LayerProfile ConfigLayer = "profile"
+)
+
+// EffectiveValue 配置键当前的生效值及其来源层
+type EffectiveValue struct {
+	// 配置键
+	Key string `json:"key"`
+	// 生效值
+	Value string `json:"value"`
+	// 提供生效值的来源层
+	Layer ConfigLayer `json:"layer"`
+	// 变更来源（如 file、nacos、admin-http；来自默认值且未被应用过时为空）
+	Source string `json:"source,omitempty"`
+	// 最近一次应用后的修订号（未被应用过时为 0）
+	Revision uint64 `json:"revision,omitempty"`
+	// 最近一次应用时间
+	AppliedAt time.Time `json:"applied_at,omitzero"`
+	// 登记的默认值（HasDefault 为 true 时有效）
+	Default string `json:"default,omitempty"`
+	// 是否登记了默认值
+	HasDefault bool `json:"has_default"`
+	// 生效值是否覆盖了默认值（登记了默认值且生效值来自其他来源层）
+	OverridesDefault bool `json:"overrides_default,omitempty"`
+}
+
+// WithOverrideSources 将额外的变更来源视为人工覆盖层（LayerOverride）
+// 管理接口（SourceAdminHTTP、SourceAdminGRPC）与回滚（SourceRollback）默认即为覆盖层
+func WithOverrideSources(sources ...string) Option {
+	return func(m *Manager) {
+		if m.overrideSources == nil {
+			m.overrideSources = make(map[string]struct{}, len(sources))
+		}
+		for _, source := range sources {
+			m.overrideSources[source] = struct{}{}
+		}
+	}
+}
+
+// Effective 查询配置键当前的生效值以及提供该值的来源层（默认值、配置源、人工覆盖或快照回退）
+// 用于排查“这个值到底是从哪里来的”这类优先级问题；配置键既未被应用过也未登记默认值时返回 false
+func (m *Manager) Effective(key string) (EffectiveValue, bool) {
+	if m == nil {
+		return EffectiveValue{}, false
+	}
+
+	key = m.NormalizeKey(key)
+	applied, appliedOK := m.store.get(key)
+	defaultValue, defaultOK := m.Default(key)
+	if !appliedOK && !defaultOK {
+		return EffectiveValue{}, false
+	}
+	return m.effectiveValue(key, applied, appliedOK, defaultValue, defaultOK), true
+}
+
+// EffectiveAll 按配置键排序返回所有已应用或登记了默认值的配置键的生效值
+func (m *Manager) EffectiveAll() []EffectiveValue {
+	if m == nil {
+		return nil
+	}
+
+	defaults := m.Defaults()
+	result := make([]EffectiveValue, 0, len(defaults))
+	for _, applied := range m.store.list() {
+		defaultValue, defaultOK := defaults[applied.Key]
+		delete(defaults, applied.Key)
+		result = append(result, m.effectiveValue(applied.Key, applied, true, defaultValue, defaultOK))
+	}
+	for key, defaultValue := range defaults {
+		result = append(result, m.effectiveValue(key, AppliedValue{}, false, defaultValue, true))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// effectiveValue 合并最近一次应用的记录与登记的默认值
+func (m *Manager) effectiveValue(key string, applied AppliedValue, appliedOK bool, defaultValue string, defaultOK bool) EffectiveValue {
+	value := EffectiveValue{
+		Key:        key,
+		Default:    defaultValue,
+		HasDefault: defaultOK,
+	}
+	if !appliedOK {
+		value.Value = defaultValue
+		value.Layer = LayerDefault
+		return value
+	}
+
+	value.Value = applied.Value
+	value.Layer = m.layerOf(applied)
+	value.Source = applied.Source
+	value.Revision = applied.Revision
+	value.AppliedAt = applied.AppliedAt
+	value.OverridesDefault = defaultOK && value.Layer != LayerDefault
+	return value
+}
+
+// layerOf 根据最近一次应用的记录判断生效值的来源层
+func (m *Manager) layerOf(applied AppliedValue) ConfigLayer {
+	if applied.Defaulted {
+		return LayerDefault
+	}
+	switch applied.Source {
+	case SourceAdminHTTP, SourceAdminGRPC, SourceRollback:
+		return LayerOverride
+	case SourceSnapshotFallback:
+		return LayerFallback
+	case SourceProfile:
+		return LayerProfile
+	}
+	if _, ok := m.overrideSources[applied.Source]; ok {
+		return LayerOverride
+	}
+	return LayerSource
+}
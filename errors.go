@@ -0,0 +1,240 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrorKind 配置变更失败的错误分类
+// 用于告警与指标区分"操作人员提交了非法配置"与"重载器自身故障"
+type ErrorKind string
+
+const (
+	// ErrorKindValidation 配置值未通过重载器校验（Validate 返回错误）
+	ErrorKindValidation ErrorKind = "validation"
+	// ErrorKindApply 处理器应用配置时返回错误
+	ErrorKindApply ErrorKind = "apply"
+	// ErrorKindTimeout 处理器执行超时（见 WithHandlerTimeout）
+	ErrorKindTimeout ErrorKind = "timeout"
+	// ErrorKindPanic 处理器执行期间发生 panic
+	ErrorKindPanic ErrorKind = "panic"
+	// ErrorKindQuarantine 匹配的处理器均处于隔离状态，变更未被应用
+	ErrorKindQuarantine ErrorKind = "quarantine"
+	// ErrorKindRateLimited 配置键的变更频率超过限制，变更被拒绝（见 WithRateLimit）
+	ErrorKindRateLimited ErrorKind = "rate_limited"
+	// ErrorKindFrozen 配置键因抖动被冻结，变更被拒绝（见 WithFlapDetection）
+	ErrorKindFrozen ErrorKind = "frozen"
+	// ErrorKindImmutable 配置键不允许在运行期间变更，变更被拒绝（见 WithImmutableKeys）
+	ErrorKindImmutable ErrorKind = "immutable"
+	// ErrorKindUnauthorized 配置变更被授权策略拒绝（见 WithAuthorizer）
+	ErrorKindUnauthorized ErrorKind = "unauthorized"
+)
+
+// errorKinds 所有错误分类，顺序与 counters.failuresByKind 下标一致
+var errorKinds = []ErrorKind{
+	ErrorKindValidation,
+	ErrorKindApply,
+	ErrorKindTimeout,
+	ErrorKindPanic,
+	ErrorKindQuarantine,
+	ErrorKindRateLimited,
+	ErrorKindFrozen,
+	ErrorKindImmutable,
+	ErrorKindUnauthorized,
+}
+
+// 配置变更失败原因的哨兵错误，可通过 errors.Is 判断，无需匹配错误信息
+// *ChangeError 按错误分类匹配对应的哨兵错误（如 Kind 为 ErrorKindValidation 时 errors.Is(err, ErrValidationFailed) 为 true）
+var (
+	// ErrValidationFailed 配置值未通过校验（ErrorKindValidation）
+	ErrValidationFailed = errors.New("validation failed")
+	// ErrApplyFailed 处理器应用配置失败（ErrorKindApply）
+	ErrApplyFailed = errors.New("apply failed")
+	// ErrHandlerTimeout 处理器执行超时（ErrorKindTimeout）
+	ErrHandlerTimeout = errors.New("handler timed out")
+	// ErrHandlerPanicked 处理器执行期间发生 panic（ErrorKindPanic）
+	ErrHandlerPanicked = errors.New("handler panicked")
+	// ErrHandlerQuarantined 匹配的处理器处于隔离状态（ErrorKindQuarantine）
+	ErrHandlerQuarantined = errors.New("handler is quarantined")
+	// ErrKeyImmutable 配置键不允许在运行期间变更（ErrorKindImmutable，见 WithImmutableKeys）
+	ErrKeyImmutable = errors.New("key is immutable")
+	// ErrNoHandlerMatched 没有任何处理器匹配配置键（见 WithRejectUnmatched）
+	ErrNoHandlerMatched = errors.New("no handler matched")
+)
+
+// kindSentinels 错误分类对应的哨兵错误
+// ErrorKindRateLimited、ErrorKindFrozen 的原始错误即为 ErrRateLimited、ErrKeyFrozen
+var kindSentinels = map[ErrorKind]error{
+	ErrorKindValidation:   ErrValidationFailed,
+	ErrorKindApply:        ErrApplyFailed,
+	ErrorKindTimeout:      ErrHandlerTimeout,
+	ErrorKindPanic:        ErrHandlerPanicked,
+	ErrorKindQuarantine:   ErrHandlerQuarantined,
+	ErrorKindImmutable:    ErrKeyImmutable,
+	ErrorKindUnauthorized: ErrUnauthorized,
+}
+
+// ErrRestartRequired 配置变更已被接受但需重启服务才能生效
+// 处理器返回包装了该错误的错误（见 RestartRequired）时，变更不视为失败，
+// 变更历史中的处理结果记录为 OutcomeRestartRequired
+var ErrRestartRequired = errors.New("restart required")
+
+// RestartRequired 返回表示配置变更需重启才能生效的错误
+func RestartRequired(reason string) error {
+	return fmt.Errorf("%w: %s", ErrRestartRequired, reason)
+}
+
+// ChangeError 配置变更失败的错误
+// HandleChange、Apply 等方法返回的错误均可通过 errors.As 取得 *ChangeError，
+// 原始错误可通过 errors.Unwrap / errors.Is 访问
+type ChangeError struct {
+	// 错误分类
+	Kind ErrorKind
+	// 配置键
+	Key string
+	// 失败处理器注册时使用的配置键模式
+	Pattern string
+	// 失败的重载器/处理器名称
+	Reloader string
+	// 原始错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *ChangeError) Error() string {
+	switch e.Kind {
+	case ErrorKindValidation:
+		return fmt.Sprintf("validation failed for key %s: %v", e.Key, e.Err)
+	case ErrorKindTimeout:
+		return fmt.Sprintf("handler %s timed out for key %s: %v", e.Reloader, e.Key, e.Err)
+	case ErrorKindPanic:
+		return fmt.Sprintf("handler %s panicked for key %s: %v", e.Reloader, e.Key, e.Err)
+	case ErrorKindQuarantine:
+		return fmt.Sprintf("key %s not applied: handler %s is quarantined", e.Key, e.Reloader)
+	case ErrorKindRateLimited, ErrorKindFrozen, ErrorKindImmutable, ErrorKindUnauthorized:
+		return fmt.Sprintf("key %s not applied: %v", e.Key, e.Err)
+	default:
+		return fmt.Sprintf("apply failed for key %s: %v", e.Key, e.Err)
+	}
+}
+
+// Unwrap 返回原始错误
+func (e *ChangeError) Unwrap() error {
+	return e.Err
+}
+
+// Is 判断错误分类是否对应 target 哨兵错误（见 ErrValidationFailed 等）
+func (e *ChangeError) Is(target error) bool {
+	sentinel, ok := kindSentinels[e.Kind]
+	return ok && sentinel == target
+}
+
+// ErrorKindOf 返回错误的分类，可直接用作指标标签
+// err 为 nil 时返回空字符串，非 *ChangeError 的错误归类为 ErrorKindApply
+func ErrorKindOf(err error) ErrorKind {
+	if err == nil {
+		return ""
+	}
+	var ce *ChangeError
+	if errors.As(err, &ce) && ce.Kind != "" {
+		return ce.Kind
+	}
+	return ErrorKindApply
+}
+
+// WithHandlerTimeout 设置单个处理器的执行超时时间（默认不限制）
+// 超时后处理器收到的 context 会被取消，分发立即返回 ErrorKindTimeout 错误，
+// 未响应 context 取消的处理器会在后台继续执行直至返回
+func WithHandlerTimeout(timeout time.Duration) Option {
+	return func(m *Manager) {
+		m.handlerTimeout = timeout
+	}
+}
+
+// classifyError 将处理器返回的错误归类为 *ChangeError，并补全配置键与处理器信息
+func classifyError(reg *registration, change Change, err error) *ChangeError {
+	var ce *ChangeError
+	if errors.As(err, &ce) {
+		if ce.Key == "" {
+			ce.Key = change.Key
+		}
+		if ce.Pattern == "" {
+			ce.Pattern = reg.pattern
+		}
+		if ce.Reloader == "" {
+			ce.Reloader = reg.name
+		}
+		if ce.Kind == "" {
+			ce.Kind = ErrorKindApply
+		}
+		return ce
+	}
+	return &ChangeError{
+		Kind:     ErrorKindApply,
+		Key:      change.Key,
+		Pattern:  reg.pattern,
+		Reloader: reg.name,
+		Err:      err,
+	}
+}
+
+// callHandler 调用处理器，配置了超时时间时在超时后返回 ErrorKindTimeout 错误
+func (m *Manager) callHandler(ctx context.Context, reg *registration, change Change) error {
+	if m.handlerTimeout <= 0 {
+		return m.invokeHandler(ctx, reg, change)
+	}
+	return m.callHandlerWithTimeout(ctx, reg, change)
+}
+
+// callHandlerWithTimeout 在独立的 goroutine 中调用处理器，超时后返回 ErrorKindTimeout 错误
+// 与 callHandler 分开，未配置超时时间时 change 不会因被 goroutine 捕获而逃逸到堆上
+func (m *Manager) callHandlerWithTimeout(ctx context.Context, reg *registration, change Change) error {
+	ctx, cancel := context.WithTimeout(ctx, m.handlerTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.invokeHandler(ctx, reg, change)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		kind := ErrorKindApply
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			kind = ErrorKindTimeout
+		}
+		return &ChangeError{Kind: kind, Err: ctx.Err()}
+	}
+}
+
+// FailuresByKind 返回按错误分类统计的配置变更失败次数
+func (m *Manager) FailuresByKind() map[ErrorKind]uint64 {
+	if m == nil {
+		return nil
+	}
+	result := make(map[ErrorKind]uint64, len(errorKinds))
+	for i, kind := range errorKinds {
+		result[kind] = m.counters.failuresByKind[i].Load()
+	}
+	return result
+}
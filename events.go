@@ -0,0 +1,198 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultEventBuffer 默认的事件订阅缓冲区大小
+const defaultEventBuffer = 64
+
+// EventType 热加载事件类型
+type EventType string
+
+const (
+	// EventChangeApplied 配置变更已成功应用
+	EventChangeApplied EventType = "change_applied"
+	// EventChangeFailed 配置变更处理失败
+	EventChangeFailed EventType = "change_failed"
+	// EventHandlerQuarantined 处理器因连续失败被隔离
+	EventHandlerQuarantined EventType = "handler_quarantined"
+	// EventSlowHandler 处理器单次调用耗时超过慢处理器阈值
+	EventSlowHandler EventType = "slow_handler"
+	// EventChangeRateLimited 配置变更因超过变更频率限制被合并或拒绝
+	EventChangeRateLimited EventType = "change_rate_limited"
+	// EventKeyFlapping 配置键在检测窗口内反复回退到先前的值
+	EventKeyFlapping EventType = "key_flapping"
+	// EventKeyUnfrozen 配置键的冻结已解除
+	EventKeyUnfrozen EventType = "key_unfrozen"
+	// EventDriftDetected 对账发现已应用的配置与配置源不一致
+	EventDriftDetected EventType = "drift_detected"
+	// EventFallbackActivated 前缀的配置源均不可用，已回退到持久化快照（Key 为前缀）
+	EventFallbackActivated EventType = "fallback_activated"
+	// EventFallbackDeactivated 前缀的配置源已恢复，解除快照回退（Key 为前缀）
+	EventFallbackDeactivated EventType = "fallback_deactivated"
+	// EventChangeSuperseded 配置变更已过期或被更高优先级的配置源覆盖，未被应用
+	EventChangeSuperseded EventType = "change_superseded"
+	// EventChangeDenied 配置变更被授权策略拒绝（Pattern 为匹配的授权策略模式）
+	EventChangeDenied EventType = "change_denied"
+	// EventShadowMismatch 影子管理器的处理结果与实时管理器不一致（ErrorKind、Error 为影子管理器的错误）
+	EventShadowMismatch EventType = "shadow_mismatch"
+	// EventProfileActivated 配置档已切换（OldValue、NewValue 为切换前后的配置档名称）
+	EventProfileActivated EventType = "profile_activated"
+)
+
+// Event 热加载事件
+type Event struct {
+	// 事件类型
+	Type EventType `json:"type"`
+	// 配置键
+	Key string `json:"key"`
+	// 旧值
+	OldValue string `json:"old_value"`
+	// 新值
+	NewValue string `json:"new_value"`
+	// 配置键已被删除
+	Deleted bool `json:"deleted,omitempty"`
+	// 新值来自默认值注册表
+	Defaulted bool `json:"defaulted,omitempty"`
+	// 变更来源（如配置中心名称、admin-http）
+	Source string `json:"source,omitempty"`
+	// 操作者身份（如配置中心用户、管理接口调用方）
+	Actor string `json:"actor,omitempty"`
+	// 配置源的修订号
+	SourceRevision string `json:"source_revision,omitempty"`
+	// 相关的处理器模式（处理失败或被隔离时有效）
+	Pattern string `json:"pattern,omitempty"`
+	// 相关的重载器/处理器名称
+	Reloader string `json:"reloader,omitempty"`
+	// 处理器调用耗时（慢处理器事件有效）
+	Duration time.Duration `json:"duration,omitempty"`
+	// 错误分类（处理失败时有效）
+	ErrorKind ErrorKind `json:"error_kind,omitempty"`
+	// 错误信息（处理失败或被隔离时有效）
+	Error string `json:"error,omitempty"`
+	// 应用后的修订号（成功应用时有效）
+	Revision uint64 `json:"revision,omitempty"`
+	// 发生时间
+	Time time.Time `json:"time"`
+}
+
+// eventSubscriber 事件订阅者
+type eventSubscriber struct {
+	ch chan Event
+}
+
+// eventBus 事件总线
+// 发布采用非阻塞方式，订阅者缓冲区已满时丢弃事件，避免慢消费者阻塞配置变更处理
+type eventBus struct {
+	subscribers map[uint64]*eventSubscriber
+	nextID      uint64
+	// 管理器关闭后不再接受订阅
+	closed bool
+
+	// 因订阅者缓冲区已满而丢弃的事件数
+	dropped atomic.Uint64
+
+	mu sync.RWMutex
+}
+
+// subscribe 添加订阅者
+func (b *eventBus) subscribe(buffer int) (<-chan Event, func()) {
+	if buffer <= 0 {
+		buffer = defaultEventBuffer
+	}
+	sub := &eventSubscriber{ch: make(chan Event, buffer)}
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	if b.subscribers == nil {
+		b.subscribers = make(map[uint64]*eventSubscriber)
+	}
+	b.nextID++
+	id := b.nextID
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			// 事件总线关闭时已移除并关闭了所有订阅者的通道
+			if _, ok := b.subscribers[id]; !ok {
+				return
+			}
+			delete(b.subscribers, id)
+			close(sub.ch)
+		})
+	}
+	return sub.ch, cancel
+}
+
+// publish 向所有订阅者发布事件
+func (b *eventBus) publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		select {
+		case sub.ch <- event:
+		default:
+			b.dropped.Add(1)
+		}
+	}
+}
+
+// close 关闭所有订阅者的事件通道
+func (b *eventBus) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for id, sub := range b.subscribers {
+		delete(b.subscribers, id)
+		close(sub.ch)
+	}
+}
+
+// SubscribeEvents 订阅热加载事件
+// buffer 为订阅缓冲区大小（<= 0 时使用默认值），缓冲区已满时新事件会被丢弃
+// 返回事件通道和取消订阅函数，取消订阅后通道会被关闭
+func (m *Manager) SubscribeEvents(buffer int) (<-chan Event, func()) {
+	return m.events.subscribe(buffer)
+}
+
+// publishEvent 发布热加载事件
+func (m *Manager) publishEvent(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	m.events.publish(event)
+	if event.Type == EventChangeApplied {
+		if dropped := m.watches.publish(event); dropped > 0 {
+			m.events.dropped.Add(uint64(dropped))
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSubscribeEventsCancelAfterClose(t *testing.T) {
+	m := NewManager()
+	events, cancel := m.SubscribeEvents(1)
+
+	if err := m.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, ok := <-events; ok {
+		t.Fatal("event channel still open after Close")
+	}
+
+	cancel()
+	cancel()
+}
+
+func TestSubscribeEventsCancelBeforeClose(t *testing.T) {
+	m := NewManager()
+	events, cancel := m.SubscribeEvents(1)
+
+	cancel()
+	if _, ok := <-events; ok {
+		t.Fatal("event channel still open after cancel")
+	}
+
+	if err := m.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+}
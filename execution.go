@@ -0,0 +1,129 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// batchParallelism ApplyBatch 并发分发的最大变更数
+// 重载器多为 IO 密集型（重建连接、加载证书），不按 CPU 数限制
+const batchParallelism = 32
+
+// ExecutionMode 处理器执行方式
+type ExecutionMode string
+
+const (
+	// ExecutionSerial 串行执行：与同一配置变更的其他处理器按注册顺序依次调用（默认）
+	ExecutionSerial ExecutionMode = "serial"
+	// ExecutionParallel 并行执行：处理器对应独立的组件，可与其他并行处理器、其他配置键的变更并发调用
+	ExecutionParallel ExecutionMode = "parallel"
+)
+
+// WithExecutionMode 设置处理器的执行方式（默认 ExecutionSerial）
+// 同一配置变更匹配的并行处理器在串行处理器全部成功后并发调用；
+// ApplyBatch 中匹配的处理器均为并行处理器的配置变更会与其他变更并发分发，
+// 适用于一次批量变更涉及数十个相互独立的重载器的场景
+func WithExecutionMode(mode ExecutionMode) RegisterOption {
+	return func(o *registerOptions) {
+		o.mode = mode
+	}
+}
+
+// mode 返回登记的执行方式
+func (reg *registration) mode() ExecutionMode {
+	if reg.parallel {
+		return ExecutionParallel
+	}
+	return ExecutionSerial
+}
+
+// callParallel 并发调用处理器，返回与 regs 下标一致的错误与耗时
+func (m *Manager) callParallel(ctx context.Context, regs []*registration, change Change) ([]error, []time.Duration) {
+	errs := make([]error, len(regs))
+	durations := make([]time.Duration, len(regs))
+
+	var wg sync.WaitGroup
+	for i, reg := range regs {
+		m.counters.handlerInvocations.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			invokedAt := time.Now()
+			errs[i] = m.callHandler(ctx, reg, change)
+			durations[i] = time.Since(invokedAt)
+		}()
+	}
+	wg.Wait()
+	return errs, durations
+}
+
+// parallelOnly 判断配置键匹配的处理器是否均为并行处理器
+func (m *Manager) parallelOnly(key string) bool {
+	matched := m.match(m.NormalizeKey(key), nil)
+	if len(matched) == 0 {
+		return false
+	}
+	for _, reg := range matched {
+		if !reg.parallel {
+			return false
+		}
+	}
+	return true
+}
+
+// dispatchBatch 分发批量变更：匹配的处理器均为并行处理器、且在批次中只出现一次的配置键并发分发，
+// 其余变更按顺序分发；返回所有失败的错误
+func (m *Manager) dispatchBatch(ctx context.Context, changes []Change) []error {
+	counts := make(map[string]int, len(changes))
+	for _, change := range changes {
+		counts[m.NormalizeKey(change.Key)]++
+	}
+
+	var (
+		errs []error
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+	)
+	record := func(err error) {
+		if err == nil {
+			return
+		}
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	}
+
+	sem := make(chan struct{}, batchParallelism)
+	for _, change := range changes {
+		if counts[m.NormalizeKey(change.Key)] == 1 && m.parallelOnly(change.Key) {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				record(m.dispatch(ctx, change, 0))
+			}()
+			continue
+		}
+		record(m.dispatch(ctx, change, 0))
+	}
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"expvar"
+	"fmt"
+	"time"
+)
+
+// DefaultExpvarName 默认的 expvar 发布名称
+const DefaultExpvarName = "hotreload"
+
+// PublishExpvar 将热加载计数器发布到 expvar
+// 发布后可通过 /debug/vars 中的 name 字段读取（name 为空时使用 DefaultExpvarName）
+// expvar 不支持重复发布同名变量，因此同一名称只能发布一次，重复发布会返回错误
+func (m *Manager) PublishExpvar(name string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if name == "" {
+		name = DefaultExpvarName
+	}
+	if expvar.Get(name) != nil {
+		return fmt.Errorf("expvar %s already published", name)
+	}
+
+	vars := new(expvar.Map).Init()
+	vars.Set("changes_total", expvar.Func(func() any { return m.counters.changesTotal.Load() }))
+	vars.Set("changes_applied", expvar.Func(func() any { return m.counters.changesApplied.Load() }))
+	vars.Set("changes_failed", expvar.Func(func() any { return m.counters.changesFailed.Load() }))
+	vars.Set("changes_failed_by_kind", expvar.Func(func() any { return m.FailuresByKind() }))
+	vars.Set("changes_unmatched", expvar.Func(func() any { return m.counters.changesUnmatched.Load() }))
+	vars.Set("handler_invocations", expvar.Func(func() any { return m.counters.handlerInvocations.Load() }))
+	vars.Set("concurrency", expvar.Func(func() any { return m.ConcurrencyStats() }))
+	vars.Set("last_revision", expvar.Func(func() any { return m.Revision() }))
+	vars.Set("last_applied_at", expvar.Func(func() any {
+		t := m.LastAppliedAt()
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339Nano)
+	}))
+
+	expvar.Publish(name, vars)
+	return nil
+}
@@ -0,0 +1,380 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SourceSnapshotFallback 从持久化快照回退应用的配置变更来源
+const SourceSnapshotFallback = "snapshot-fallback"
+
+// SnapshotStore 持久化最近一次已知可用（last-known-good）配置快照的存储
+type SnapshotStore interface {
+	// Load 读取持久化的快照，尚未保存过时返回空快照
+	Load(ctx context.Context) (map[string]string, error)
+
+	// Save 保存快照
+	Save(ctx context.Context, values map[string]string) error
+}
+
+// FileSnapshotStore 以 JSON 文件持久化配置快照
+// 写入时先写临时文件再重命名，进程崩溃不会留下不完整的快照
+type FileSnapshotStore struct {
+	path string
+}
+
+var _ SnapshotStore = (*FileSnapshotStore)(nil)
+
+// NewFileSnapshotStore 创建以 path 为快照文件的存储
+func NewFileSnapshotStore(path string) *FileSnapshotStore {
+	return &FileSnapshotStore{path: path}
+}
+
+// persistedSnapshot 快照文件格式
+type persistedSnapshot struct {
+	SavedAt time.Time         `json:"saved_at"`
+	Values  map[string]string `json:"values"`
+}
+
+// Load 读取快照文件，文件不存在时返回空快照
+func (s *FileSnapshotStore) Load(ctx context.Context) (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+
+	var snapshot persistedSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot file: %w", err)
+	}
+	if snapshot.Values == nil {
+		snapshot.Values = map[string]string{}
+	}
+	return snapshot.Values, nil
+}
+
+// Save 写入快照文件
+func (s *FileSnapshotStore) Save(ctx context.Context, values map[string]string) error {
+	data, err := json.Marshal(persistedSnapshot{SavedAt: time.Now(), Values: values})
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+	return nil
+}
+
+// FallbackRule 快照回退规则
+// Prefix 下的配置键由 Sources 中的配置源提供；这些配置源全部断开（见 ReportSourceStatus）时，
+// 以持久化快照中的值应用该前缀下尚未应用的配置键，并发布 EventFallbackActivated 事件
+type FallbackRule struct {
+	// 配置键前缀（空表示所有配置键）
+	Prefix string
+	// 提供该前缀配置的配置源名称（与 ReportSourceStatus 的 source 一致）
+	Sources []string
+	// 启动后在该时间内所有配置源均未连接时同样启用回退（0 表示只在配置源断开时启用）
+	StartupTimeout time.Duration
+}
+
+// WithSnapshotStore 设置持久化快照的存储
+// 配置快照变化后在后台保存（合并频繁的变化），关闭管理器时再保存一次；
+// 配合 WithSnapshotFallback 在配置源不可用时以最近一次已知可用的配置启动
+func WithSnapshotStore(store SnapshotStore) Option {
+	return func(m *Manager) {
+		m.fallback.store = store
+	}
+}
+
+// WithSnapshotFallback 添加快照回退规则（需同时设置 WithSnapshotStore）
+func WithSnapshotFallback(rule FallbackRule) Option {
+	return func(m *Manager) {
+		if len(rule.Sources) == 0 {
+			return
+		}
+		rule.Prefix = strings.TrimSuffix(rule.Prefix, ".")
+		m.fallback.rules = append(m.fallback.rules, rule)
+	}
+}
+
+// snapshotFallback 快照持久化与回退状态
+type snapshotFallback struct {
+	store SnapshotStore
+	rules []FallbackRule
+	// 快照发生变化，等待保存
+	dirty chan struct{}
+	// 已启用回退的前缀
+	active map[string]bool
+
+	mu sync.Mutex
+}
+
+// underPrefix 判断配置键是否位于前缀之下
+func underPrefix(key, prefix string) bool {
+	return prefix == "" || key == prefix || strings.HasPrefix(key, prefix+".")
+}
+
+// startSnapshotFallback 启动快照保存循环与启动超时检查
+func (m *Manager) startSnapshotFallback() {
+	f := &m.fallback
+	if f.store == nil {
+		return
+	}
+	for i := range f.rules {
+		f.rules[i].Prefix = m.NormalizeKey(f.rules[i].Prefix)
+	}
+	f.dirty = make(chan struct{}, 1)
+	f.active = make(map[string]bool)
+
+	m.goBackground(func() {
+		for {
+			select {
+			case <-m.lifecycle.done:
+				return
+			case <-f.dirty:
+			}
+			if err := m.saveSnapshot(context.Background()); err != nil {
+				m.logger.Warn("Failed to persist config snapshot", "error", err)
+			}
+		}
+	})
+
+	for _, rule := range f.rules {
+		if rule.StartupTimeout <= 0 {
+			continue
+		}
+		m.goBackground(func() {
+			timer := time.NewTimer(rule.StartupTimeout)
+			defer timer.Stop()
+			select {
+			case <-m.lifecycle.done:
+				return
+			case <-timer.C:
+			}
+			if !m.anySourceConnected(rule.Sources) {
+				m.activateFallback(rule, "no config source connected within startup timeout")
+			}
+		})
+	}
+}
+
+// markSnapshotDirty 通知保存循环快照已变化（非阻塞）
+func (m *Manager) markSnapshotDirty() {
+	if m.fallback.dirty == nil {
+		return
+	}
+	select {
+	case m.fallback.dirty <- struct{}{}:
+	default:
+	}
+}
+
+// saveSnapshot 保存当前快照
+func (m *Manager) saveSnapshot(ctx context.Context) error {
+	if m.fallback.store == nil {
+		return nil
+	}
+	return m.fallback.store.Save(ctx, m.Snapshot().Map())
+}
+
+// anySourceConnected 判断配置源中是否有已连接的
+func (m *Manager) anySourceConnected(sources []string) bool {
+	m.health.mu.RLock()
+	defer m.health.mu.RUnlock()
+	for _, source := range sources {
+		if m.health.sources[source] {
+			return true
+		}
+	}
+	return false
+}
+
+// allSourcesDisconnected 判断配置源是否均已上报断开
+func (m *Manager) allSourcesDisconnected(sources []string) bool {
+	m.health.mu.RLock()
+	defer m.health.mu.RUnlock()
+	for _, source := range sources {
+		connected, known := m.health.sources[source]
+		if !known || connected {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluateFallback 配置源连接状态变化后启用或解除相关前缀的回退
+func (m *Manager) evaluateFallback(source string, connected bool) {
+	f := &m.fallback
+	// 关闭期间停止配置源引起的断开不触发回退
+	if f.store == nil || m.Closed() {
+		return
+	}
+	for _, rule := range f.rules {
+		if !slices.Contains(rule.Sources, source) {
+			continue
+		}
+		if connected {
+			m.deactivateFallback(rule, source)
+		} else if m.allSourcesDisconnected(rule.Sources) {
+			m.activateFallback(rule, "all config sources disconnected: "+strings.Join(rule.Sources, ", "))
+		}
+	}
+}
+
+// activateFallback 启用前缀的回退：发布事件并在后台应用快照中尚未应用的配置键
+func (m *Manager) activateFallback(rule FallbackRule, reason string) {
+	f := &m.fallback
+	f.mu.Lock()
+	if f.active[rule.Prefix] {
+		f.mu.Unlock()
+		return
+	}
+	f.active[rule.Prefix] = true
+	f.mu.Unlock()
+
+	m.logger.Warn("Config snapshot fallback activated", "prefix", rule.Prefix, "reason", reason)
+	m.publishEvent(Event{
+		Type:  EventFallbackActivated,
+		Key:   rule.Prefix,
+		Error: reason,
+		Time:  time.Now(),
+	})
+	m.goBackground(func() {
+		if _, err := m.ApplyFallback(context.Background(), rule.Prefix); err != nil && !errors.Is(err, ErrManagerClosed) {
+			m.logger.Warn("Failed to apply config snapshot fallback", "prefix", rule.Prefix, "error", err)
+		}
+	})
+}
+
+// deactivateFallback 配置源恢复连接后解除前缀的回退
+func (m *Manager) deactivateFallback(rule FallbackRule, source string) {
+	f := &m.fallback
+	f.mu.Lock()
+	if !f.active[rule.Prefix] {
+		f.mu.Unlock()
+		return
+	}
+	delete(f.active, rule.Prefix)
+	f.mu.Unlock()
+
+	m.logger.Info("Config snapshot fallback deactivated", "prefix", rule.Prefix, "source", source)
+	m.publishEvent(Event{
+		Type:   EventFallbackDeactivated,
+		Key:    rule.Prefix,
+		Source: source,
+		Time:   time.Now(),
+	})
+}
+
+// ApplyFallback 以持久化快照中的值应用前缀下尚未应用的配置键，返回应用的配置键数
+// 已由配置源应用的配置键保持不变；变更来源为 SourceSnapshotFallback，通过 ApplyBatch 一次性应用。
+// 配置源不可用时自动调用（见 WithSnapshotFallback），也可在配置源启动失败时手动调用
+func (m *Manager) ApplyFallback(ctx context.Context, prefix string) (int, error) {
+	if m == nil {
+		return 0, fmt.Errorf("manager is nil")
+	}
+	if m.fallback.store == nil {
+		return 0, fmt.Errorf("snapshot store is not configured")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	values, err := m.fallback.store.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load config snapshot: %w", err)
+	}
+
+	prefix = m.NormalizeKey(strings.TrimSuffix(prefix, "."))
+	var changes []Change
+	for key, value := range values {
+		key = m.NormalizeKey(key)
+		if !underPrefix(key, prefix) {
+			continue
+		}
+		if _, ok := m.store.get(key); ok {
+			continue
+		}
+		changes = append(changes, Change{Key: key, NewValue: value, Source: SourceSnapshotFallback})
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+
+	m.logger.Info("Applying config snapshot fallback", "prefix", prefix, "key_count", len(changes))
+	return len(changes), m.ApplyBatch(ctx, changes)
+}
+
+// fallbackOwner 判断从快照回退应用的配置键是否归属配置源 source：前缀覆盖该配置键的回退规则列出了 source 时 owned 为 true，
+// 没有任何回退规则覆盖该配置键（如未配置规则时手动调用 ApplyFallback）时 known 为 false
+func (m *Manager) fallbackOwner(key, source string) (owned, known bool) {
+	for _, rule := range m.fallback.rules {
+		if !underPrefix(key, rule.Prefix) {
+			continue
+		}
+		known = true
+		if slices.Contains(rule.Sources, source) {
+			return true, true
+		}
+	}
+	return false, known
+}
+
+// FallbackPrefixes 按字典序返回已启用快照回退的前缀
+func (m *Manager) FallbackPrefixes() []string {
+	if m == nil {
+		return nil
+	}
+
+	m.fallback.mu.Lock()
+	defer m.fallback.mu.Unlock()
+
+	prefixes := make([]string, 0, len(m.fallback.active))
+	for prefix := range m.fallback.active {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
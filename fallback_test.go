@@ -0,0 +1,191 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFileSnapshotStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	store := NewFileSnapshotStore(path)
+	ctx := context.Background()
+
+	values, err := store.Load(ctx)
+	if err != nil || len(values) != 0 {
+		t.Fatalf("Load() of missing file = %v, %v, want empty snapshot", values, err)
+	}
+	if err := store.Save(ctx, map[string]string{"app.timeout": "10s"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	values, err = store.Load(ctx)
+	if err != nil || values["app.timeout"] != "10s" {
+		t.Fatalf("Load() = %v, %v, want saved snapshot", values, err)
+	}
+	// 临时文件在重命名后不残留
+	if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+		t.Fatalf("snapshot dir has %d entries, want 1", len(entries))
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if _, err := store.Load(ctx); err == nil {
+		t.Fatal("Load() of corrupt file error = nil")
+	}
+}
+
+func TestApplyFallbackSkipsAppliedKeys(t *testing.T) {
+	if _, err := NewManager(WithLogger(NopLogger())).ApplyFallback(context.Background(), "app"); err == nil {
+		t.Fatal("ApplyFallback() without snapshot store error = nil")
+	}
+
+	store := &memorySnapshotStore{values: map[string]string{
+		"app.timeout": "10s",
+		"app.retries": "3",
+		"db.pool":     "4",
+	}}
+	m := NewManager(WithLogger(NopLogger()), WithSnapshotStore(store))
+	defer m.Close(context.Background())
+	for _, pattern := range []string{"app.*", "db.*"} {
+		if err := m.RegisterHandler(pattern, func(key, oldValue, newValue string) error { return nil }); err != nil {
+			t.Fatalf("RegisterHandler(%s) error = %v", pattern, err)
+		}
+	}
+	ctx := context.Background()
+	if err := m.Apply(ctx, Change{Key: "app.retries", NewValue: "5", Source: "nacos"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	n, err := m.ApplyFallback(ctx, "app.")
+	if err != nil || n != 1 {
+		t.Fatalf("ApplyFallback() = %d, %v, want 1", n, err)
+	}
+	if applied, _ := m.LastApplied("app.timeout"); applied.Value != "10s" || applied.Source != SourceSnapshotFallback {
+		t.Fatalf("LastApplied(app.timeout) = %+v, want 10s from snapshot", applied)
+	}
+	if applied, _ := m.LastApplied("app.retries"); applied.Value != "5" {
+		t.Fatalf("LastApplied(app.retries) = %+v, want value from source kept", applied)
+	}
+	if _, ok := m.LastApplied("db.pool"); ok {
+		t.Fatal("ApplyFallback(app) applied db.pool")
+	}
+}
+
+// waitEvent 等待指定类型的事件
+func waitEvent(t *testing.T, events <-chan Event, typ EventType) Event {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == typ {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("no %s event", typ)
+		}
+	}
+}
+
+// waitApplied 等待配置键被应用
+func waitApplied(t *testing.T, m *Manager, key string) AppliedValue {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if applied, ok := m.LastApplied(key); ok {
+			return applied
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s not applied", key)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSnapshotFallbackFollowsSourceStatus(t *testing.T) {
+	store := &memorySnapshotStore{values: map[string]string{"app.timeout": "10s"}}
+	m := NewManager(WithLogger(NopLogger()),
+		WithSnapshotStore(store),
+		WithSnapshotFallback(FallbackRule{Prefix: "app.", Sources: []string{"nacos", "etcd"}}))
+	defer m.Close(context.Background())
+	if err := m.RegisterHandler("app.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	events, cancel := m.SubscribeEvents(16)
+	defer cancel()
+
+	// 只有部分配置源断开时不回退
+	m.ReportSourceStatus("nacos", false)
+	if got := m.FallbackPrefixes(); len(got) != 0 {
+		t.Fatalf("FallbackPrefixes() = %v with etcd status unknown, want none", got)
+	}
+	m.ReportSourceStatus("etcd", false)
+	if event := waitEvent(t, events, EventFallbackActivated); event.Key != "app" {
+		t.Fatalf("EventFallbackActivated Key = %q, want app", event.Key)
+	}
+	if applied := waitApplied(t, m, "app.timeout"); applied.Source != SourceSnapshotFallback {
+		t.Fatalf("app.timeout Source = %q, want %q", applied.Source, SourceSnapshotFallback)
+	}
+	if got := m.FallbackPrefixes(); len(got) != 1 || got[0] != "app" {
+		t.Fatalf("FallbackPrefixes() = %v, want [app]", got)
+	}
+
+	m.ReportSourceStatus("etcd", true)
+	if event := waitEvent(t, events, EventFallbackDeactivated); event.Source != "etcd" {
+		t.Fatalf("EventFallbackDeactivated Source = %q, want etcd", event.Source)
+	}
+	if got := m.FallbackPrefixes(); len(got) != 0 {
+		t.Fatalf("FallbackPrefixes() = %v after reconnect, want none", got)
+	}
+}
+
+func TestSnapshotFallbackStartupTimeout(t *testing.T) {
+	store := &memorySnapshotStore{values: map[string]string{"app.timeout": "10s"}}
+	m := NewManager(WithLogger(NopLogger()),
+		WithSnapshotStore(store),
+		WithSnapshotFallback(FallbackRule{Prefix: "app", Sources: []string{"nacos"}, StartupTimeout: 20 * time.Millisecond}))
+	defer m.Close(context.Background())
+	if err := m.RegisterHandler("app.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	if applied := waitApplied(t, m, "app.timeout"); applied.Value != "10s" {
+		t.Fatalf("app.timeout = %q, want 10s", applied.Value)
+	}
+}
+
+func TestCloseSavesSnapshot(t *testing.T) {
+	store := &memorySnapshotStore{}
+	m := NewManager(WithLogger(NopLogger()), WithSnapshotStore(store))
+	if err := m.RegisterHandler("app.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	if err := m.Apply(context.Background(), Change{Key: "app.timeout", NewValue: "10s"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := m.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	values, _ := store.Load(context.Background())
+	if values["app.timeout"] != "10s" {
+		t.Fatalf("saved snapshot = %v, want app.timeout=10s", values)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import "context"
+
+// FaultInjector 故障注入接口，用于在测试或金丝雀环境中验证错误策略、重试与回滚在故障下的行为
+// 内置实现见 chaos 子包；生产环境不应配置
+type FaultInjector interface {
+	// BeforeHandler 在每个处理器执行前调用（处于处理器超时与 panic 恢复的保护范围内）
+	// 返回错误时该处理器视为失败且不会执行；可阻塞以模拟延迟，可 panic 以模拟处理器崩溃
+	BeforeHandler(ctx context.Context, change Change, pattern, reloader string) error
+
+	// Duplicate 返回是否重复投递该配置变更（模拟配置源的重复推送）
+	Duplicate(change Change) bool
+}
+
+// WithFaultInjector 设置故障注入器
+func WithFaultInjector(injector FaultInjector) Option {
+	return func(m *Manager) {
+		m.faults = injector
+	}
+}
+
+// MatchPattern 返回配置键是否匹配模式（与处理器注册的匹配语义相同）
+func MatchPattern(pattern, key string) bool {
+	return matchPattern(pattern, key)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"runtime/pprof"
+	"slices"
+	"strings"
+)
+
+// fieldTarget 字段设置器登记及其允许的配置前缀
+type fieldTarget struct {
+	reg             *registration
+	allowedPrefixes []string
+}
+
+// SetModuleFieldSetter 为模块设置独立的字段设置器
+// 配置键按首段路由：首段为 module 的配置键只交给该字段设置器处理（不再交给 SetFieldSetter 设置的全局字段设置器），
+// 便于大型应用按子系统拆分配置结构体；allowedPrefixes 为空时允许该模块下的所有配置键，否则每个前缀都必须位于 module 之下。
+// setter 为 nil 时移除该模块的字段设置器，此后该模块的配置键回到全局字段设置器
+func (m *Manager) SetModuleFieldSetter(module string, setter FieldSetter, allowedPrefixes []string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+
+	module = m.NormalizeKey(module)
+	if module == "" || strings.ContainsAny(module, ".*") {
+		return fmt.Errorf("invalid field setter module: %q", module)
+	}
+	prefixes := m.normalizePatterns(allowedPrefixes)
+	if len(prefixes) == 0 {
+		prefixes = []string{module}
+	}
+	for _, prefix := range prefixes {
+		if first, _, _ := strings.Cut(prefix, "."); first != module {
+			return fmt.Errorf("allowed prefix %s is not under module %s", prefix, module)
+		}
+	}
+
+	// 释放锁后通知配置层适配器更新订阅
+	defer m.resubscribeAdapters()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if setter == nil {
+		delete(m.moduleFieldSetters, module)
+		m.rebuildRoutesLocked()
+		return nil
+	}
+
+	if m.moduleFieldSetters == nil {
+		m.moduleFieldSetters = make(map[string]fieldTarget)
+	}
+	m.moduleFieldSetters[module] = fieldTarget{
+		reg:             m.newFieldSetterRegLocked(fieldSetterPattern+":"+module, setter, callerSite(1)),
+		allowedPrefixes: slices.Clone(prefixes),
+	}
+	m.rebuildRoutesLocked()
+	return nil
+}
+
+// FieldSetterModules 按字典序返回设置了独立字段设置器的模块
+func (m *Manager) FieldSetterModules() []string {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return slices.Sorted(maps.Keys(m.moduleFieldSetters))
+}
+
+// newFieldSetterRegLocked 创建字段设置器对应的处理器登记（调用方需持有写锁）
+func (m *Manager) newFieldSetterRegLocked(pattern string, setter FieldSetter, site string) *registration {
+	m.regSeq++
+	name := funcName(setter)
+	return &registration{
+		pattern: pattern,
+		name:    name,
+		kind:    RegistrationFieldSetter,
+		site:    site,
+		seq:     m.regSeq,
+		handler: func(ctx context.Context, change Change) error {
+			return m.handleSystemConfig(change.Key, change.OldValue, change.NewValue, setter)
+		},
+		labels: pprof.Labels(PprofLabelPattern, pattern, PprofLabelReloader, name),
+		names:  []string{name},
+	}
+}
+
+// fieldTargetsLocked 返回所有字段设置器及其允许的配置前缀（调用方需持有读锁）
+// 全局字段设置器在前，模块字段设置器按模块名排序
+func (m *Manager) fieldTargetsLocked() []fieldTarget {
+	targets := make([]fieldTarget, 0, len(m.moduleFieldSetters)+1)
+	if m.fieldSetterReg != nil {
+		targets = append(targets, fieldTarget{reg: m.fieldSetterReg, allowedPrefixes: m.allowedPrefixes})
+	}
+	modules := slices.Sorted(maps.Keys(m.moduleFieldSetters))
+	for _, module := range modules {
+		targets = append(targets, m.moduleFieldSetters[module])
+	}
+	return targets
+}
+
+// fieldSetterRegsLocked 返回所有字段设置器对应的处理器登记（调用方需持有读锁）
+func (m *Manager) fieldSetterRegsLocked() []*registration {
+	targets := m.fieldTargetsLocked()
+	regs := make([]*registration, 0, len(targets))
+	for _, target := range targets {
+		regs = append(regs, target.reg)
+	}
+	return regs
+}
+
+// matchFieldTarget 判断配置键是否位于字段设置器允许的前缀下
+func matchFieldTarget(target fieldTarget, key string) bool {
+	if target.reg == nil {
+		return false
+	}
+	for _, prefix := range target.allowedPrefixes {
+		if hasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChangeFilter 配置变更过滤器，在处理器执行之前调用
+// 可修改 change.NewValue 改写新值（如取值范围钳制、环境别名映射），返回错误时拒绝该变更；
+// 对配置键及其他字段的修改不会生效
+type ChangeFilter func(ctx context.Context, change *Change) error
+
+// filterRoute 过滤器路由规则
+type filterRoute struct {
+	// 过滤器标识（用于移除）
+	id uint64
+	// 过滤器名称（用于日志与错误信息）
+	name string
+	// 配置键模式列表（为空表示过滤所有配置变更）
+	patterns []string
+	// 过滤函数
+	filter ChangeFilter
+}
+
+// matches 检查配置键是否匹配过滤器，返回匹配的模式
+func (r *filterRoute) matches(key string) (string, bool) {
+	if len(r.patterns) == 0 {
+		return "*", true
+	}
+	for _, pattern := range r.patterns {
+		if pattern == "*" || matchPattern(pattern, key) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// AddFilter 注册配置变更过滤器，按注册顺序执行，前一个过滤器改写的值会传递给下一个
+// patterns 为配置键模式（支持通配符），为空时过滤所有配置变更；返回用于移除该过滤器的函数
+// 过滤器只对至少匹配一个处理器的配置变更执行，被拒绝的变更按验证失败处理
+func (m *Manager) AddFilter(name string, filter ChangeFilter, patterns ...string) (remove func()) {
+	if m == nil || filter == nil {
+		return func() {}
+	}
+
+	m.mu.Lock()
+	m.filterSeq++
+	route := &filterRoute{
+		id:       m.filterSeq,
+		name:     name,
+		patterns: append([]string(nil), m.normalizePatterns(patterns)...),
+		filter:   filter,
+	}
+	m.filters = append(m.filters, route)
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		// 写时复制，避免影响正在执行的过滤器遍历
+		filters := make([]*filterRoute, 0, len(m.filters))
+		for _, r := range m.filters {
+			if r.id != route.id {
+				filters = append(filters, r)
+			}
+		}
+		m.filters = filters
+	}
+}
+
+// runFilters 依次执行匹配的过滤器，拒绝时返回验证失败错误
+func (m *Manager) runFilters(ctx context.Context, change *Change) *ChangeError {
+	m.mu.RLock()
+	filters := m.filters
+	m.mu.RUnlock()
+
+	for _, route := range filters {
+		pattern, ok := route.matches(change.Key)
+		if !ok {
+			continue
+		}
+		if err := m.callFilter(ctx, route, change); err != nil {
+			return &ChangeError{
+				Kind:     ErrorKindValidation,
+				Key:      change.Key,
+				Pattern:  pattern,
+				Reloader: route.name,
+				Err:      err,
+			}
+		}
+	}
+	return nil
+}
+
+// callFilter 调用单个过滤器，只采纳其对新值的修改，panic 视为拒绝
+func (m *Manager) callFilter(ctx context.Context, route *filterRoute, change *Change) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("Config change filter panicked",
+				"filter", route.name,
+				"key", change.Key,
+				"panic", r)
+			err = fmt.Errorf("filter panicked: %v", r)
+		}
+	}()
+
+	filtered := *change
+	if err := route.filter(ctx, &filtered); err != nil {
+		return err
+	}
+	if filtered.NewValue != change.NewValue {
+		m.logger.Debug("Config change value rewritten by filter",
+			"filter", route.name,
+			"key", change.Key,
+			"new_value", filtered.NewValue)
+		change.NewValue = filtered.NewValue
+	}
+	return nil
+}
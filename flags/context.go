@@ -0,0 +1,44 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package flags
+
+import "context"
+
+// EvalContext 开关求值上下文
+type EvalContext struct {
+	// 定向键（如用户 ID），用于百分比分桶
+	Key string
+	// 用户属性（如 country、plan、app_version）
+	Attributes map[string]string
+}
+
+// evalContextKey 求值上下文在 context 中的键
+type evalContextKey struct{}
+
+// WithEvalContext 返回携带求值上下文的 context
+func WithEvalContext(ctx context.Context, ec EvalContext) context.Context {
+	return context.WithValue(ctx, evalContextKey{}, ec)
+}
+
+// EvalContextFrom 从 context 中读取求值上下文
+func EvalContextFrom(ctx context.Context) EvalContext {
+	if ctx == nil {
+		return EvalContext{}
+	}
+	ec, _ := ctx.Value(evalContextKey{}).(EvalContext)
+	return ec
+}
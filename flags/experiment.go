@@ -0,0 +1,218 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultExperimentPrefix 默认的实验配置键前缀
+const DefaultExperimentPrefix = "experiments"
+
+// Experiment A/B 实验定义
+//
+// 配置值为 JSON 对象，例如：
+//
+//	{
+//	  "enabled": true,
+//	  "salt": "checkout-2025q3",
+//	  "traffic": 50,
+//	  "audience": [{"attribute": "platform", "op": "in", "values": ["ios", "android"]}],
+//	  "variants": [
+//	    {"name": "control", "weight": 50},
+//	    {"name": "one_page", "weight": 50, "value": {"steps": 1}}
+//	  ]
+//	}
+type Experiment struct {
+	// 实验名称
+	Name string `json:"-"`
+	// 是否启用（默认启用）
+	Enabled bool `json:"enabled"`
+	// 分桶盐值（为空时使用实验名称），修改盐值会重新分配所有用户
+	Salt string `json:"salt,omitempty"`
+	// 进入实验的流量百分比（[0, 100]，默认 100）
+	Traffic float64 `json:"traffic"`
+	// 受众规则，须全部命中才会进入实验（规则的 value 字段被忽略）
+	Audience []Rule `json:"audience,omitempty"`
+	// 实验变体，按权重分配
+	Variants []Variant `json:"variants"`
+}
+
+// Variant 实验变体
+type Variant struct {
+	// 变体名称
+	Name string `json:"name"`
+	// 权重（非负，按各变体权重之和归一化）
+	Weight float64 `json:"weight"`
+	// 变体携带的参数
+	Value any `json:"value,omitempty"`
+}
+
+// Assignment 实验分组结果
+type Assignment struct {
+	// 实验名称
+	Experiment string `json:"experiment"`
+	// 分配的变体
+	Variant Variant `json:"variant"`
+	// 定向键（如用户 ID）
+	Key string `json:"key"`
+}
+
+// Exposure 实验曝光事件，在调用方读取分组结果时产生
+type Exposure struct {
+	Assignment
+	// 曝光时间
+	Time time.Time `json:"time"`
+}
+
+// ExposureHandler 实验曝光回调，用于上报曝光事件到分析系统
+// 回调在调用 Assign 的协程中同步执行，耗时操作应自行异步化
+type ExposureHandler func(ctx context.Context, exposure Exposure)
+
+// WithExperimentPrefix 设置实验配置键前缀（默认 "experiments"）
+func WithExperimentPrefix(prefix string) Option {
+	return func(c *Client) {
+		if prefix != "" {
+			c.experimentPrefix = prefix
+		}
+	}
+}
+
+// WithExposureHandler 设置实验曝光回调
+func WithExposureHandler(handler ExposureHandler) Option {
+	return func(c *Client) {
+		c.exposure = handler
+	}
+}
+
+// ParseExperiment 解析实验定义
+func ParseExperiment(name, value string) (*Experiment, error) {
+	exp := &Experiment{Name: name, Enabled: true, Traffic: 100}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(value)), exp); err != nil {
+		return nil, fmt.Errorf("invalid experiment %s: %w", name, err)
+	}
+	exp.Name = name
+
+	if exp.Traffic < 0 || exp.Traffic > 100 {
+		return nil, fmt.Errorf("traffic of experiment %s must be in [0, 100], got %g", name, exp.Traffic)
+	}
+	if len(exp.Variants) == 0 {
+		return nil, fmt.Errorf("experiment %s has no variants", name)
+	}
+	total := 0.0
+	seen := make(map[string]struct{}, len(exp.Variants))
+	for _, v := range exp.Variants {
+		if v.Name == "" {
+			return nil, fmt.Errorf("experiment %s has a variant without name", name)
+		}
+		if _, dup := seen[v.Name]; dup {
+			return nil, fmt.Errorf("experiment %s has duplicate variant %s", name, v.Name)
+		}
+		seen[v.Name] = struct{}{}
+		if v.Weight < 0 {
+			return nil, fmt.Errorf("weight of variant %s must be >= 0, got %g", v.Name, v.Weight)
+		}
+		total += v.Weight
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("experiment %s has zero total weight", name)
+	}
+	for i, rule := range exp.Audience {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid audience rule %d of experiment %s: %w", i, name, err)
+		}
+	}
+	return exp, nil
+}
+
+// Assign 为求值上下文分配变体，未启用、定向键为空、不在受众或流量范围内时 ok 为 false
+// 相同盐值与定向键始终分配到相同变体，与调用顺序和实例无关
+func (e *Experiment) Assign(ec EvalContext) (Variant, bool) {
+	if !e.Enabled || ec.Key == "" {
+		return Variant{}, false
+	}
+	salt := e.Salt
+	if salt == "" {
+		salt = e.Name
+	}
+	for i := range e.Audience {
+		if !e.Audience[i].matches(salt, ec) {
+			return Variant{}, false
+		}
+	}
+	if Bucket(salt+":traffic", ec.Key) >= e.Traffic {
+		return Variant{}, false
+	}
+
+	total := 0.0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+	point := Bucket(salt, ec.Key) / 100 * total
+	for _, v := range e.Variants {
+		if point < v.Weight {
+			return v, true
+		}
+		point -= v.Weight
+	}
+	return e.Variants[len(e.Variants)-1], true
+}
+
+// Experiment 返回实验定义
+func (c *Client) Experiment(name string) (*Experiment, bool) {
+	exp, ok := (*c.experiments.Load())[name]
+	return exp, ok
+}
+
+// Assign 按 ctx 中的求值上下文分配实验变体，分配成功时触发曝光回调
+func (c *Client) Assign(ctx context.Context, experiment string) (Assignment, bool) {
+	exp, ok := c.Experiment(experiment)
+	if !ok {
+		return Assignment{}, false
+	}
+	ec := EvalContextFrom(ctx)
+	variant, ok := exp.Assign(ec)
+	if !ok {
+		return Assignment{}, false
+	}
+
+	assignment := Assignment{Experiment: experiment, Variant: variant, Key: ec.Key}
+	if c.exposure != nil {
+		c.exposure(ctx, Exposure{Assignment: assignment, Time: time.Now()})
+	}
+	return assignment, true
+}
+
+// VariantName 返回分配的变体名称，未进入实验时返回 def
+func (c *Client) VariantName(ctx context.Context, experiment string, def string) string {
+	if assignment, ok := c.Assign(ctx, experiment); ok {
+		return assignment.Variant.Name
+	}
+	return def
+}
+
+// VariantName 使用默认客户端返回分配的变体名称，未设置默认客户端或未进入实验时返回 def
+func VariantName(ctx context.Context, experiment string, def string) string {
+	if c := defaultClient.Load(); c != nil {
+		return c.VariantName(ctx, experiment, def)
+	}
+	return def
+}
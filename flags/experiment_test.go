@@ -0,0 +1,114 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package flags
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestParseExperiment(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{`{"variants": [{"name": "control", "weight": 1}, {"name": "one_page", "weight": 1}]}`, false},
+		{`{"variants": []}`, true},
+		{`{"traffic": 150, "variants": [{"name": "control", "weight": 1}]}`, true},
+		{`{"variants": [{"name": "", "weight": 1}]}`, true},
+		{`{"variants": [{"name": "a", "weight": 1}, {"name": "a", "weight": 1}]}`, true},
+		{`{"variants": [{"name": "a", "weight": -1}, {"name": "b", "weight": 2}]}`, true},
+		{`{"variants": [{"name": "a", "weight": 0}]}`, true},
+		{`{"audience": [{"op": "in"}], "variants": [{"name": "a", "weight": 1}]}`, true},
+		{`not json`, true},
+	}
+	for _, tt := range tests {
+		if _, err := ParseExperiment("checkout_layout", tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("ParseExperiment(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestExperimentAssign(t *testing.T) {
+	exp, err := ParseExperiment("checkout_layout", `{
+		"traffic": 50,
+		"audience": [{"attribute": "platform", "op": "in", "values": ["ios", "android"]}],
+		"variants": [{"name": "control", "weight": 3}, {"name": "one_page", "weight": 1}]
+	}`)
+	if err != nil {
+		t.Fatalf("ParseExperiment() error = %v", err)
+	}
+	if _, ok := exp.Assign(EvalContext{Key: "alice", Attributes: map[string]string{"platform": "web"}}); ok {
+		t.Fatal("Assign() outside audience ok = true")
+	}
+	if _, ok := exp.Assign(EvalContext{Attributes: map[string]string{"platform": "ios"}}); ok {
+		t.Fatal("Assign() without key ok = true")
+	}
+
+	const users = 4000
+	counts := make(map[string]int)
+	for i := range users {
+		ec := EvalContext{Key: fmt.Sprintf("user-%d", i), Attributes: map[string]string{"platform": "ios"}}
+		v, ok := exp.Assign(ec)
+		if again, _ := exp.Assign(ec); again.Name != v.Name {
+			t.Fatalf("Assign(%q) is not stable", ec.Key)
+		}
+		if ok {
+			counts[v.Name]++
+		}
+	}
+	enrolled := counts["control"] + counts["one_page"]
+	if ratio := float64(enrolled) / users; ratio < 0.45 || ratio > 0.55 {
+		t.Fatalf("enrolled ratio = %.3f, want about 0.50", ratio)
+	}
+	if ratio := float64(counts["one_page"]) / float64(enrolled); ratio < 0.2 || ratio > 0.3 {
+		t.Fatalf("one_page ratio = %.3f, want about 0.25", ratio)
+	}
+}
+
+func TestClientAssignReportsExposure(t *testing.T) {
+	var exposures []Exposure
+	c := NewClient(WithExposureHandler(func(_ context.Context, e Exposure) {
+		exposures = append(exposures, e)
+	}))
+	if err := c.OnChange("experiments.checkout_layout", "", `{"variants": [{"name": "one_page", "weight": 1}]}`); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	// 实验与开关使用不同前缀，互不影响
+	if _, ok := c.Flag("checkout_layout"); ok {
+		t.Fatal("experiment definition registered as flag")
+	}
+
+	ctx := WithEvalContext(context.Background(), EvalContext{Key: "alice"})
+	if got := c.VariantName(ctx, "checkout_layout", "control"); got != "one_page" {
+		t.Fatalf("VariantName() = %q, want one_page", got)
+	}
+	if got := c.VariantName(context.Background(), "checkout_layout", "control"); got != "control" {
+		t.Fatalf("VariantName() without key = %q, want control", got)
+	}
+	if len(exposures) != 1 || exposures[0].Key != "alice" || exposures[0].Variant.Name != "one_page" {
+		t.Fatalf("exposures = %+v, want one exposure for alice", exposures)
+	}
+
+	if err := c.OnChange("experiments.checkout_layout", "", ""); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if _, ok := c.Experiment("checkout_layout"); ok {
+		t.Fatal("Experiment() still present after empty value")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package flags
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// 定向规则支持的运算符
+const (
+	OpEquals     = "eq"
+	OpNotEquals  = "neq"
+	OpIn         = "in"
+	OpNotIn      = "not_in"
+	OpContains   = "contains"
+	OpPrefix     = "prefix"
+	OpSuffix     = "suffix"
+	OpGreater    = "gt"
+	OpLess       = "lt"
+	OpPercentage = "percentage"
+)
+
+// Flag 开关定义
+//
+// 配置值为 JSON 对象，例如：
+//
+//	{
+//	  "enabled": true,
+//	  "value": false,
+//	  "rules": [
+//	    {"attribute": "country", "op": "in", "values": ["CN", "SG"], "value": true},
+//	    {"op": "percentage", "percentage": 20, "value": true}
+//	  ]
+//	}
+//
+// 也可以直接使用标量值（如 "true"、"42"、"blue"）定义固定取值的开关
+type Flag struct {
+	// 开关名称
+	Name string `json:"-"`
+	// 是否启用（默认启用），未启用时求值返回调用方提供的默认值
+	Enabled bool `json:"enabled"`
+	// 未命中任何规则时的取值（为空时返回调用方提供的默认值）
+	Value any `json:"value,omitempty"`
+	// 定向规则，按顺序匹配，命中第一条规则后返回其取值
+	Rules []Rule `json:"rules,omitempty"`
+	// 百分比分桶使用的盐值（为空时使用开关名称），修改盐值会重新分桶
+	Salt string `json:"salt,omitempty"`
+}
+
+// Rule 定向规则
+type Rule struct {
+	// 用户属性名（percentage 运算符不需要）
+	Attribute string `json:"attribute,omitempty"`
+	// 运算符（eq、neq、in、not_in、contains、prefix、suffix、gt、lt、percentage）
+	Op string `json:"op"`
+	// 比较值
+	Values []string `json:"values,omitempty"`
+	// 百分比（percentage 运算符有效，取值 [0, 100]），按 EvalContext.Key 稳定分桶
+	Percentage float64 `json:"percentage,omitempty"`
+	// 命中规则时的取值
+	Value any `json:"value"`
+}
+
+// ParseFlag 解析开关定义
+func ParseFlag(name, value string) (*Flag, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("flag %s is empty", name)
+	}
+
+	if !strings.HasPrefix(value, "{") {
+		return &Flag{Name: name, Enabled: true, Value: parseScalar(value)}, nil
+	}
+
+	// 未指定 enabled 时默认启用
+	flag := &Flag{Name: name, Enabled: true}
+	if err := json.Unmarshal([]byte(value), flag); err != nil {
+		return nil, fmt.Errorf("invalid flag %s: %w", name, err)
+	}
+	flag.Name = name
+	for i, rule := range flag.Rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid rule %d of flag %s: %w", i, name, err)
+		}
+	}
+	return flag, nil
+}
+
+// parseScalar 解析标量取值
+func parseScalar(value string) any {
+	if b, err := strconv.ParseBool(value); err == nil {
+		return b
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil {
+		return f
+	}
+	return value
+}
+
+// validate 验证定向规则
+func (r *Rule) validate() error {
+	switch r.Op {
+	case OpPercentage:
+		if r.Percentage < 0 || r.Percentage > 100 {
+			return fmt.Errorf("percentage must be in [0, 100], got %g", r.Percentage)
+		}
+		return nil
+	case OpEquals, OpNotEquals, OpIn, OpNotIn, OpContains, OpPrefix, OpSuffix:
+	case OpGreater, OpLess:
+		if len(r.Values) != 1 {
+			return fmt.Errorf("op %s requires exactly one value", r.Op)
+		}
+		if _, err := strconv.ParseFloat(r.Values[0], 64); err != nil {
+			return fmt.Errorf("op %s requires a numeric value, got %q", r.Op, r.Values[0])
+		}
+	default:
+		return fmt.Errorf("unsupported op: %s", r.Op)
+	}
+	if r.Attribute == "" {
+		return fmt.Errorf("attribute is empty")
+	}
+	if len(r.Values) == 0 {
+		return fmt.Errorf("values is empty")
+	}
+	return nil
+}
+
+// Evaluate 按求值上下文计算开关取值，返回取值与是否命中（未启用或无取值时 ok 为 false）
+func (f *Flag) Evaluate(ec EvalContext) (value any, ok bool) {
+	value, _, ok = f.Match(ec)
+	return value, ok
+}
+
+// Match 按求值上下文计算开关取值，并返回命中的规则下标（使用默认取值时为 -1）
+func (f *Flag) Match(ec EvalContext) (value any, rule int, ok bool) {
+	if !f.Enabled {
+		return nil, -1, false
+	}
+	for i := range f.Rules {
+		if f.Rules[i].matches(f.salt(), ec) {
+			return f.Rules[i].Value, i, f.Rules[i].Value != nil
+		}
+	}
+	return f.Value, -1, f.Value != nil
+}
+
+// salt 返回百分比分桶使用的盐值
+func (f *Flag) salt() string {
+	if f.Salt != "" {
+		return f.Salt
+	}
+	return f.Name
+}
+
+// matches 返回规则是否命中
+func (r *Rule) matches(salt string, ec EvalContext) bool {
+	if r.Op == OpPercentage {
+		if ec.Key == "" {
+			return false
+		}
+		return Bucket(salt, ec.Key) < r.Percentage
+	}
+
+	actual, ok := ec.Attributes[r.Attribute]
+	if !ok {
+		return r.Op == OpNotEquals || r.Op == OpNotIn
+	}
+	switch r.Op {
+	case OpEquals:
+		return actual == r.Values[0]
+	case OpNotEquals:
+		return actual != r.Values[0]
+	case OpIn:
+		return slices.Contains(r.Values, actual)
+	case OpNotIn:
+		return !slices.Contains(r.Values, actual)
+	case OpContains:
+		return slices.ContainsFunc(r.Values, func(v string) bool { return strings.Contains(actual, v) })
+	case OpPrefix:
+		return slices.ContainsFunc(r.Values, func(v string) bool { return strings.HasPrefix(actual, v) })
+	case OpSuffix:
+		return slices.ContainsFunc(r.Values, func(v string) bool { return strings.HasSuffix(actual, v) })
+	case OpGreater, OpLess:
+		a, err := strconv.ParseFloat(actual, 64)
+		if err != nil {
+			return false
+		}
+		b, _ := strconv.ParseFloat(r.Values[0], 64)
+		if r.Op == OpGreater {
+			return a > b
+		}
+		return a < b
+	}
+	return false
+}
+
+// Bucket 返回 key 在 [0, 100) 范围内的稳定分桶值，相同 salt 与 key 始终返回相同结果
+func Bucket(salt, key string) float64 {
+	sum := sha256.Sum256([]byte(salt + ":" + key))
+	return float64(binary.BigEndian.Uint64(sum[:8])%10000) / 100
+}
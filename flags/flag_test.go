@@ -0,0 +1,129 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package flags
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestParseFlag(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    any
+		wantErr bool
+	}{
+		{"true", true, false},
+		{"42", 42.0, false},
+		{"blue", "blue", false},
+		{`{"value": "green"}`, "green", false},
+		{"", nil, true},
+		{`{"value": `, nil, true},
+		{`{"rules": [{"op": "regex", "attribute": "country", "values": ["C.*"]}]}`, nil, true},
+		{`{"rules": [{"op": "in", "values": ["CN"]}]}`, nil, true},
+		{`{"rules": [{"op": "eq", "attribute": "country"}]}`, nil, true},
+		{`{"rules": [{"op": "gt", "attribute": "app_version", "values": ["v2"]}]}`, nil, true},
+		{`{"rules": [{"op": "percentage", "percentage": 120}]}`, nil, true},
+	}
+	for _, tt := range tests {
+		flag, err := ParseFlag("checkout", tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFlag(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !flag.Enabled || flag.Value != tt.want {
+			t.Errorf("ParseFlag(%q) = %+v, want enabled with value %v", tt.value, flag, tt.want)
+		}
+	}
+}
+
+func TestFlagMatch(t *testing.T) {
+	flag, err := ParseFlag("checkout", `{
+		"value": "control",
+		"rules": [
+			{"attribute": "country", "op": "in", "values": ["CN", "SG"], "value": "asia"},
+			{"attribute": "plan", "op": "neq", "values": ["free"], "value": "paid"},
+			{"attribute": "app_version", "op": "gt", "values": ["3"], "value": "modern"}
+		]
+	}`)
+	if err != nil {
+		t.Fatalf("ParseFlag() error = %v", err)
+	}
+	tests := []struct {
+		attrs map[string]string
+		want  any
+		rule  int
+	}{
+		{map[string]string{"country": "SG", "plan": "free"}, "asia", 0},
+		{map[string]string{"country": "US", "plan": "pro"}, "paid", 1},
+		// 缺少属性时 neq 规则视为命中
+		{map[string]string{"country": "US"}, "paid", 1},
+		{map[string]string{"plan": "free", "app_version": "4.5"}, "modern", 2},
+		{map[string]string{"plan": "free", "app_version": "beta"}, "control", -1},
+	}
+	for _, tt := range tests {
+		value, rule, ok := flag.Match(EvalContext{Attributes: tt.attrs})
+		if !ok || value != tt.want || rule != tt.rule {
+			t.Errorf("Match(%v) = %v, %d, %v, want %v, %d", tt.attrs, value, rule, ok, tt.want, tt.rule)
+		}
+	}
+
+	flag.Enabled = false
+	if _, ok := flag.Evaluate(EvalContext{}); ok {
+		t.Error("Evaluate() of disabled flag ok = true")
+	}
+}
+
+func TestFlagPercentageRollout(t *testing.T) {
+	flag, err := ParseFlag("checkout", `{"value": false, "rules": [{"op": "percentage", "percentage": 30, "value": true}]}`)
+	if err != nil {
+		t.Fatalf("ParseFlag() error = %v", err)
+	}
+	if v, _ := flag.Evaluate(EvalContext{}); v != false {
+		t.Fatalf("Evaluate() without key = %v, want false", v)
+	}
+
+	const users = 2000
+	enabled := 0
+	for i := range users {
+		key := fmt.Sprintf("user-%d", i)
+		ec := EvalContext{Key: key}
+		first, _ := flag.Evaluate(ec)
+		second, _ := flag.Evaluate(ec)
+		if first != second {
+			t.Fatalf("Evaluate(%q) is not stable", key)
+		}
+		if first == true {
+			enabled++
+		}
+	}
+	if ratio := float64(enabled) / users; ratio < 0.25 || ratio > 0.35 {
+		t.Fatalf("enabled ratio = %.3f, want about 0.30", ratio)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	if Bucket("checkout", "alice") != Bucket("checkout", "alice") {
+		t.Fatal("Bucket() is not stable")
+	}
+	if b := Bucket("checkout", "alice"); b < 0 || b >= 100 {
+		t.Fatalf("Bucket() = %g, want in [0, 100)", b)
+	}
+}
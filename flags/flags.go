@@ -0,0 +1,357 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package flags 提供基于热加载管理器的功能开关
+//
+// 开关定义在 "flags.<name>" 配置键下（前缀可通过 WithPrefix 修改），配置值为 JSON 对象或标量值（见 Flag），
+// 支持按用户属性与百分比定向，所有开关随配置变更热加载：
+//
+//	client, _ := flags.New(manager)
+//	flags.SetDefault(client)
+//
+//	ctx = flags.WithEvalContext(ctx, flags.EvalContext{Key: userID, Attributes: map[string]string{"country": "CN"}})
+//	if flags.Bool(ctx, "new_checkout", false) { ... }
+//
+// A/B 实验定义在 "experiments.<name>" 配置键下（见 Experiment），按定向键稳定分配变体：
+//
+//	switch flags.VariantName(ctx, "checkout_layout", "control") { ... }
+package flags
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "flags"
+
+// Option 开关客户端配置选项
+type Option func(*Client)
+
+// WithPrefix 设置配置键前缀（默认 "flags"）
+func WithPrefix(prefix string) Option {
+	return func(c *Client) {
+		if prefix != "" {
+			c.prefix = prefix
+		}
+	}
+}
+
+// Client 功能开关客户端
+type Client struct {
+	prefix           string
+	experimentPrefix string
+	exposure         ExposureHandler
+
+	// 串行化配置变更
+	mu          sync.Mutex
+	flags       atomic.Pointer[map[string]*Flag]
+	experiments atomic.Pointer[map[string]*Experiment]
+
+	// 开关变更监听器
+	listenerMu  sync.RWMutex
+	listeners   map[uint64]func(name string)
+	listenerSeq uint64
+}
+
+// New 创建功能开关客户端并注册到热加载管理器
+func New(manager *hotreload.Manager, opts ...Option) (*Client, error) {
+	if manager == nil {
+		return nil, fmt.Errorf("manager is nil")
+	}
+	c := NewClient(opts...)
+	if err := manager.RegisterReloader(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// NewClient 创建功能开关客户端（不注册到热加载管理器）
+// 可自行通过 RegisterReloader 注册，或在测试中通过 Set 直接设置开关
+func NewClient(opts ...Option) *Client {
+	c := &Client{prefix: DefaultPrefix, experimentPrefix: DefaultExperimentPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	flags := make(map[string]*Flag)
+	c.flags.Store(&flags)
+	experiments := make(map[string]*Experiment)
+	c.experiments.Store(&experiments)
+	return c
+}
+
+// Name 返回重载器名称
+func (c *Client) Name() string {
+	return "flags"
+}
+
+// Patterns 返回配置键模式列表
+func (c *Client) Patterns() []string {
+	return []string{c.prefix + ".*", c.experimentPrefix + ".*"}
+}
+
+// Validate 验证开关或实验定义
+func (c *Client) Validate(key, value string) error {
+	if name, ok := c.experimentName(key); ok {
+		if strings.TrimSpace(value) == "" {
+			return nil
+		}
+		_, err := ParseExperiment(name, value)
+		return err
+	}
+
+	name, err := c.flagName(key)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	_, err = ParseFlag(name, value)
+	return err
+}
+
+// OnChange 更新开关或实验定义，值为空表示删除
+func (c *Client) OnChange(key, oldValue, newValue string) error {
+	if name, ok := c.experimentName(key); ok {
+		if strings.TrimSpace(newValue) == "" {
+			c.updateExperiment(name, nil)
+			return nil
+		}
+		exp, err := ParseExperiment(name, newValue)
+		if err != nil {
+			return err
+		}
+		c.updateExperiment(name, exp)
+		return nil
+	}
+
+	name, err := c.flagName(key)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(newValue) == "" {
+		c.update(name, nil)
+		return nil
+	}
+	flag, err := ParseFlag(name, newValue)
+	if err != nil {
+		return err
+	}
+	c.update(name, flag)
+	return nil
+}
+
+// flagName 解析配置键中的开关名称
+func (c *Client) flagName(key string) (string, error) {
+	name, ok := strings.CutPrefix(key, c.prefix+".")
+	if !ok || name == "" {
+		return "", fmt.Errorf("unsupported flag key: %s", key)
+	}
+	return name, nil
+}
+
+// experimentName 解析配置键中的实验名称
+func (c *Client) experimentName(key string) (string, bool) {
+	name, ok := strings.CutPrefix(key, c.experimentPrefix+".")
+	return name, ok && name != ""
+}
+
+// Set 直接设置开关定义（flag 为 nil 表示删除），主要用于测试
+func (c *Client) Set(name string, flag *Flag) {
+	if flag != nil {
+		flag.Name = name
+	}
+	c.update(name, flag)
+}
+
+// update 以写时复制方式更新开关定义
+func (c *Client) update(name string, flag *Flag) {
+	c.mu.Lock()
+	flags := maps.Clone(*c.flags.Load())
+	if flag == nil {
+		delete(flags, name)
+	} else {
+		flags[name] = flag
+	}
+	c.flags.Store(&flags)
+	c.mu.Unlock()
+
+	c.notifyFlagChange(name)
+}
+
+// OnFlagChange 注册开关变更监听器（开关新增、修改或删除后调用），返回用于取消监听的函数
+func (c *Client) OnFlagChange(fn func(name string)) (cancel func()) {
+	if fn == nil {
+		return func() {}
+	}
+	c.listenerMu.Lock()
+	defer c.listenerMu.Unlock()
+	if c.listeners == nil {
+		c.listeners = make(map[uint64]func(name string))
+	}
+	c.listenerSeq++
+	id := c.listenerSeq
+	c.listeners[id] = fn
+	return func() {
+		c.listenerMu.Lock()
+		delete(c.listeners, id)
+		c.listenerMu.Unlock()
+	}
+}
+
+// notifyFlagChange 通知开关变更监听器
+func (c *Client) notifyFlagChange(name string) {
+	c.listenerMu.RLock()
+	defer c.listenerMu.RUnlock()
+	for _, fn := range c.listeners {
+		fn(name)
+	}
+}
+
+// updateExperiment 以写时复制方式更新实验定义
+func (c *Client) updateExperiment(name string, exp *Experiment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	experiments := maps.Clone(*c.experiments.Load())
+	if exp == nil {
+		delete(experiments, name)
+	} else {
+		experiments[name] = exp
+	}
+	c.experiments.Store(&experiments)
+}
+
+// Flag 返回开关定义
+func (c *Client) Flag(name string) (*Flag, bool) {
+	flag, ok := (*c.flags.Load())[name]
+	return flag, ok
+}
+
+// Names 返回所有开关名称，按名称排序
+func (c *Client) Names() []string {
+	flags := *c.flags.Load()
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Value 按 ctx 中的求值上下文计算开关取值，开关不存在、未启用或无取值时 ok 为 false
+func (c *Client) Value(ctx context.Context, name string) (value any, ok bool) {
+	flag, exists := c.Flag(name)
+	if !exists {
+		return nil, false
+	}
+	return flag.Evaluate(EvalContextFrom(ctx))
+}
+
+// Bool 返回布尔开关取值，开关不存在或取值类型不匹配时返回 def
+func (c *Client) Bool(ctx context.Context, name string, def bool) bool {
+	if v, ok := c.Value(ctx, name); ok {
+		if b, ok := v.(bool); ok {
+			return b
+		}
+	}
+	return def
+}
+
+// String 返回字符串开关取值（多变体开关），开关不存在或取值类型不匹配时返回 def
+func (c *Client) String(ctx context.Context, name string, def string) string {
+	if v, ok := c.Value(ctx, name); ok {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return def
+}
+
+// Float64 返回数值开关取值，开关不存在或取值类型不匹配时返回 def
+func (c *Client) Float64(ctx context.Context, name string, def float64) float64 {
+	if v, ok := c.Value(ctx, name); ok {
+		if f, ok := v.(float64); ok {
+			return f
+		}
+	}
+	return def
+}
+
+// Int 返回整数开关取值，开关不存在、取值类型不匹配或不是整数时返回 def
+func (c *Client) Int(ctx context.Context, name string, def int) int {
+	if v, ok := c.Value(ctx, name); ok {
+		if f, ok := v.(float64); ok && f == math.Trunc(f) {
+			return int(f)
+		}
+	}
+	return def
+}
+
+// defaultClient 包级函数使用的默认客户端
+var defaultClient atomic.Pointer[Client]
+
+// SetDefault 设置包级函数（Bool、String 等）使用的默认客户端
+func SetDefault(c *Client) {
+	defaultClient.Store(c)
+}
+
+// Default 返回默认客户端，未设置时返回 nil
+func Default() *Client {
+	return defaultClient.Load()
+}
+
+// Bool 使用默认客户端返回布尔开关取值，未设置默认客户端时返回 def
+func Bool(ctx context.Context, name string, def bool) bool {
+	if c := defaultClient.Load(); c != nil {
+		return c.Bool(ctx, name, def)
+	}
+	return def
+}
+
+// String 使用默认客户端返回字符串开关取值，未设置默认客户端时返回 def
+func String(ctx context.Context, name string, def string) string {
+	if c := defaultClient.Load(); c != nil {
+		return c.String(ctx, name, def)
+	}
+	return def
+}
+
+// Float64 使用默认客户端返回数值开关取值，未设置默认客户端时返回 def
+func Float64(ctx context.Context, name string, def float64) float64 {
+	if c := defaultClient.Load(); c != nil {
+		return c.Float64(ctx, name, def)
+	}
+	return def
+}
+
+// Int 使用默认客户端返回整数开关取值，未设置默认客户端时返回 def
+func Int(ctx context.Context, name string, def int) int {
+	if c := defaultClient.Load(); c != nil {
+		return c.Int(ctx, name, def)
+	}
+	return def
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package flags
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+func TestClientFollowsConfigChanges(t *testing.T) {
+	m := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	defer m.Close(context.Background())
+	c, err := New(m)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var changed []string
+	cancel := c.OnFlagChange(func(name string) { changed = append(changed, name) })
+	defer cancel()
+
+	ctx := WithEvalContext(context.Background(), EvalContext{Key: "alice", Attributes: map[string]string{"country": "CN"}})
+	apply := func(key, value string) error {
+		return m.Apply(context.Background(), hotreload.Change{Key: key, NewValue: value})
+	}
+	if err := apply("flags.new_checkout", `{"value": false, "rules": [{"attribute": "country", "op": "eq", "values": ["CN"], "value": true}]}`); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := apply("flags.page_size", "25"); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if !c.Bool(ctx, "new_checkout", false) {
+		t.Fatal("Bool(new_checkout) = false, want true for CN")
+	}
+	if c.Bool(context.Background(), "new_checkout", true) {
+		t.Fatal("Bool(new_checkout) = true without attributes, want flag default false")
+	}
+	if got := c.Int(ctx, "page_size", 10); got != 25 {
+		t.Fatalf("Int(page_size) = %d, want 25", got)
+	}
+	// 类型不匹配时返回调用方默认值
+	if got := c.String(ctx, "page_size", "small"); got != "small" {
+		t.Fatalf("String(page_size) = %q, want small", got)
+	}
+
+	if err := apply("flags.new_checkout", `{"rules": [{"op": "between"}]}`); err == nil {
+		t.Fatal("Apply() with invalid flag error = nil")
+	}
+	if !c.Bool(ctx, "new_checkout", false) {
+		t.Fatal("rejected change altered flag new_checkout")
+	}
+
+	if err := apply("flags.page_size", ""); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if _, ok := c.Flag("page_size"); ok {
+		t.Fatal("Flag(page_size) still present after empty value")
+	}
+	if got := c.Names(); len(got) != 1 || got[0] != "new_checkout" {
+		t.Fatalf("Names() = %v, want [new_checkout]", got)
+	}
+	if len(changed) != 3 {
+		t.Fatalf("flag change notifications = %v, want 3", changed)
+	}
+}
+
+func TestDefaultClient(t *testing.T) {
+	defer SetDefault(nil)
+	ctx := context.Background()
+	if !Bool(ctx, "dark_mode", true) {
+		t.Fatal("Bool() without default client = false, want def")
+	}
+	c := NewClient()
+	c.Set("dark_mode", &Flag{Enabled: true, Value: false})
+	SetDefault(c)
+	if Bool(ctx, "dark_mode", true) {
+		t.Fatal("Bool() = true, want value from default client")
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package ofprovider 提供基于 flags 子包的 OpenFeature Provider
+//
+// 使用 OpenFeature SDK 的应用可直接获得随配置热加载的功能开关：
+//
+//	client, _ := flags.New(manager)
+//	_ = openfeature.SetProviderAndWait(ofprovider.New(client))
+//	enabled, _ := openfeature.NewDefaultClient().BooleanValue(ctx, "new_checkout", false,
+//		openfeature.NewEvaluationContext(userID, map[string]any{"country": "CN"}))
+//
+// OpenFeature 求值上下文的 targetingKey 对应 flags.EvalContext.Key，其余属性转换为字符串后作为定向属性；
+// 求值上下文为空时使用 ctx 中的 flags.EvalContext。开关变更时发出 PROVIDER_CONFIGURATION_CHANGED 事件
+package ofprovider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/go-anyway/framework-hotreload/flags"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// Name Provider 名称
+const Name = "hotreload-flags"
+
+// eventBuffer 事件通道缓冲大小（缓冲写满时丢弃事件）
+const eventBuffer = 64
+
+// Provider OpenFeature Provider 实现
+type Provider struct {
+	client *flags.Client
+	events chan openfeature.Event
+
+	mu     sync.Mutex
+	cancel func()
+}
+
+var (
+	_ openfeature.FeatureProvider = (*Provider)(nil)
+	_ openfeature.StateHandler    = (*Provider)(nil)
+	_ openfeature.EventHandler    = (*Provider)(nil)
+)
+
+// New 创建 OpenFeature Provider
+func New(client *flags.Client) *Provider {
+	return &Provider{
+		client: client,
+		events: make(chan openfeature.Event, eventBuffer),
+	}
+}
+
+// Metadata 返回 Provider 元数据
+func (p *Provider) Metadata() openfeature.Metadata {
+	return openfeature.Metadata{Name: Name}
+}
+
+// Hooks 返回 Provider 钩子
+func (p *Provider) Hooks() []openfeature.Hook {
+	return nil
+}
+
+// Init 初始化 Provider，开始监听开关变更
+func (p *Provider) Init(openfeature.EvaluationContext) error {
+	if p.client == nil {
+		return fmt.Errorf("flags client is nil")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel == nil {
+		p.cancel = p.client.OnFlagChange(p.flagChanged)
+	}
+	return nil
+}
+
+// Shutdown 停止监听开关变更
+func (p *Provider) Shutdown() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+}
+
+// EventChannel 返回 Provider 事件通道
+func (p *Provider) EventChannel() <-chan openfeature.Event {
+	return p.events
+}
+
+// flagChanged 发出配置变更事件，缓冲写满时丢弃
+func (p *Provider) flagChanged(name string) {
+	select {
+	case p.events <- openfeature.Event{
+		ProviderName: Name,
+		EventType:    openfeature.ProviderConfigChange,
+		ProviderEventDetails: openfeature.ProviderEventDetails{
+			Message:     "flag changed: " + name,
+			FlagChanges: []string{name},
+		},
+	}:
+	default:
+	}
+}
+
+// BooleanEvaluation 计算布尔开关
+func (p *Provider) BooleanEvaluation(ctx context.Context, flag string, defaultValue bool, flatCtx openfeature.FlattenedContext) openfeature.BoolResolutionDetail {
+	value, detail := p.evaluate(ctx, flag, flatCtx)
+	if detail.Error() != nil || value == nil {
+		return openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	b, ok := value.(bool)
+	if !ok {
+		return openfeature.BoolResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, value, "bool")}
+	}
+	return openfeature.BoolResolutionDetail{Value: b, ProviderResolutionDetail: detail}
+}
+
+// StringEvaluation 计算字符串开关
+func (p *Provider) StringEvaluation(ctx context.Context, flag string, defaultValue string, flatCtx openfeature.FlattenedContext) openfeature.StringResolutionDetail {
+	value, detail := p.evaluate(ctx, flag, flatCtx)
+	if detail.Error() != nil || value == nil {
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	s, ok := value.(string)
+	if !ok {
+		return openfeature.StringResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, value, "string")}
+	}
+	return openfeature.StringResolutionDetail{Value: s, ProviderResolutionDetail: detail}
+}
+
+// FloatEvaluation 计算数值开关
+func (p *Provider) FloatEvaluation(ctx context.Context, flag string, defaultValue float64, flatCtx openfeature.FlattenedContext) openfeature.FloatResolutionDetail {
+	value, detail := p.evaluate(ctx, flag, flatCtx)
+	if detail.Error() != nil || value == nil {
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	f, ok := value.(float64)
+	if !ok {
+		return openfeature.FloatResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, value, "float")}
+	}
+	return openfeature.FloatResolutionDetail{Value: f, ProviderResolutionDetail: detail}
+}
+
+// IntEvaluation 计算整数开关
+func (p *Provider) IntEvaluation(ctx context.Context, flag string, defaultValue int64, flatCtx openfeature.FlattenedContext) openfeature.IntResolutionDetail {
+	value, detail := p.evaluate(ctx, flag, flatCtx)
+	if detail.Error() != nil || value == nil {
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	f, ok := value.(float64)
+	if !ok || f != math.Trunc(f) {
+		return openfeature.IntResolutionDetail{Value: defaultValue, ProviderResolutionDetail: typeMismatch(flag, value, "int")}
+	}
+	return openfeature.IntResolutionDetail{Value: int64(f), ProviderResolutionDetail: detail}
+}
+
+// ObjectEvaluation 计算任意类型开关
+func (p *Provider) ObjectEvaluation(ctx context.Context, flag string, defaultValue any, flatCtx openfeature.FlattenedContext) openfeature.InterfaceResolutionDetail {
+	value, detail := p.evaluate(ctx, flag, flatCtx)
+	if detail.Error() != nil || value == nil {
+		return openfeature.InterfaceResolutionDetail{Value: defaultValue, ProviderResolutionDetail: detail}
+	}
+	return openfeature.InterfaceResolutionDetail{Value: value, ProviderResolutionDetail: detail}
+}
+
+// evaluate 计算开关取值与求值原因，开关未启用或无取值时返回 nil（调用方使用默认值）
+func (p *Provider) evaluate(ctx context.Context, name string, flatCtx openfeature.FlattenedContext) (any, openfeature.ProviderResolutionDetail) {
+	if p.client == nil {
+		return nil, openfeature.ProviderResolutionDetail{
+			ResolutionError: openfeature.NewProviderNotReadyResolutionError("flags client is nil"),
+			Reason:          openfeature.ErrorReason,
+		}
+	}
+	flag, ok := p.client.Flag(name)
+	if !ok {
+		return nil, openfeature.ProviderResolutionDetail{
+			ResolutionError: openfeature.NewFlagNotFoundResolutionError("flag not found: " + name),
+			Reason:          openfeature.ErrorReason,
+		}
+	}
+
+	value, rule, ok := flag.Match(evalContext(ctx, flatCtx))
+	switch {
+	case !flag.Enabled:
+		return nil, openfeature.ProviderResolutionDetail{Reason: openfeature.DisabledReason}
+	case !ok:
+		return nil, openfeature.ProviderResolutionDetail{Reason: openfeature.DefaultReason}
+	case rule < 0:
+		return value, openfeature.ProviderResolutionDetail{Reason: openfeature.StaticReason, Variant: "default"}
+	case flag.Rules[rule].Op == flags.OpPercentage:
+		return value, openfeature.ProviderResolutionDetail{Reason: openfeature.SplitReason, Variant: "rule-" + strconv.Itoa(rule)}
+	default:
+		return value, openfeature.ProviderResolutionDetail{Reason: openfeature.TargetingMatchReason, Variant: "rule-" + strconv.Itoa(rule)}
+	}
+}
+
+// evalContext 将 OpenFeature 求值上下文转换为 flags.EvalContext
+func evalContext(ctx context.Context, flatCtx openfeature.FlattenedContext) flags.EvalContext {
+	if len(flatCtx) == 0 {
+		return flags.EvalContextFrom(ctx)
+	}
+	ec := flags.EvalContext{Attributes: make(map[string]string, len(flatCtx))}
+	for k, v := range flatCtx {
+		if k == openfeature.TargetingKey {
+			ec.Key = fmt.Sprint(v)
+			continue
+		}
+		ec.Attributes[k] = fmt.Sprint(v)
+	}
+	return ec
+}
+
+// typeMismatch 返回类型不匹配的求值结果
+func typeMismatch(flag string, value any, want string) openfeature.ProviderResolutionDetail {
+	return openfeature.ProviderResolutionDetail{
+		ResolutionError: openfeature.NewTypeMismatchResolutionError(fmt.Sprintf("flag %s has type %T, want %s", flag, value, want)),
+		Reason:          openfeature.ErrorReason,
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package ofprovider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload/flags"
+	"github.com/open-feature/go-sdk/openfeature"
+)
+
+// newProvider 创建包含 new_checkout（按国家定向）与 page_size 开关的 Provider
+func newProvider(t *testing.T) (*Provider, *flags.Client) {
+	t.Helper()
+	client := flags.NewClient()
+	for key, value := range map[string]string{
+		"flags.new_checkout": `{"value": false, "rules": [{"attribute": "country", "op": "eq", "values": ["CN"], "value": true}]}`,
+		"flags.page_size":    "25",
+	} {
+		if err := client.OnChange(key, "", value); err != nil {
+			t.Fatalf("OnChange(%q) error = %v", key, err)
+		}
+	}
+	return New(client), client
+}
+
+func TestBooleanEvaluation(t *testing.T) {
+	p, _ := newProvider(t)
+	ctx := context.Background()
+
+	cn := openfeature.FlattenedContext{openfeature.TargetingKey: "alice", "country": "CN"}
+	detail := p.BooleanEvaluation(ctx, "new_checkout", false, cn)
+	if !detail.Value || detail.Reason != openfeature.TargetingMatchReason || detail.Variant != "rule-0" {
+		t.Fatalf("BooleanEvaluation(CN) = %+v", detail)
+	}
+	detail = p.BooleanEvaluation(ctx, "new_checkout", true, openfeature.FlattenedContext{"country": "US"})
+	if detail.Value || detail.Reason != openfeature.StaticReason {
+		t.Fatalf("BooleanEvaluation(US) = %+v", detail)
+	}
+
+	// 求值上下文为空时使用 ctx 中的 flags.EvalContext
+	flagCtx := flags.WithEvalContext(ctx, flags.EvalContext{Attributes: map[string]string{"country": "CN"}})
+	if detail := p.BooleanEvaluation(flagCtx, "new_checkout", false, nil); !detail.Value {
+		t.Fatalf("BooleanEvaluation() with context attributes = %+v", detail)
+	}
+
+	detail = p.BooleanEvaluation(ctx, "missing", true, nil)
+	if !detail.Value || detail.Error() == nil {
+		t.Fatalf("BooleanEvaluation(missing) = %+v, want default with flag not found error", detail)
+	}
+	detail = p.BooleanEvaluation(ctx, "page_size", true, nil)
+	if !detail.Value || detail.Error() == nil {
+		t.Fatalf("BooleanEvaluation(page_size) = %+v, want default with type mismatch error", detail)
+	}
+}
+
+func TestNumericEvaluation(t *testing.T) {
+	p, _ := newProvider(t)
+	ctx := context.Background()
+	if detail := p.IntEvaluation(ctx, "page_size", 10, nil); detail.Value != 25 {
+		t.Fatalf("IntEvaluation() = %+v, want 25", detail)
+	}
+	if detail := p.FloatEvaluation(ctx, "page_size", 10, nil); detail.Value != 25 {
+		t.Fatalf("FloatEvaluation() = %+v, want 25", detail)
+	}
+	if detail := p.StringEvaluation(ctx, "page_size", "small", nil); detail.Value != "small" || detail.Error() == nil {
+		t.Fatalf("StringEvaluation() = %+v, want default with type mismatch error", detail)
+	}
+}
+
+func TestEventsFollowFlagChanges(t *testing.T) {
+	p, client := newProvider(t)
+	if err := p.Init(openfeature.EvaluationContext{}); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if err := client.OnChange("flags.page_size", "25", "50"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	select {
+	case event := <-p.EventChannel():
+		if event.EventType != openfeature.ProviderConfigChange || len(event.FlagChanges) != 1 || event.FlagChanges[0] != "page_size" {
+			t.Fatalf("event = %+v, want config change for page_size", event)
+		}
+	default:
+		t.Fatal("no event after flag change")
+	}
+
+	p.Shutdown()
+	if err := client.OnChange("flags.page_size", "50", "75"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	select {
+	case event := <-p.EventChannel():
+		t.Fatalf("event after Shutdown() = %+v", event)
+	default:
+	}
+	if err := New(nil).Init(openfeature.EvaluationContext{}); err == nil {
+		t.Fatal("Init() with nil client error = nil")
+	}
+}
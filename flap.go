@@ -0,0 +1,308 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrKeyFrozen 配置键因抖动被冻结
+var ErrKeyFrozen = errors.New("key is frozen")
+
+// FlapRule 配置抖动检测规则
+// 在 Window 窗口内，配置键的新值回到窗口内出现过的旧值（如 A→B→A）记为一次回退，
+// 回退次数达到 Reversions 时判定为抖动
+type FlapRule struct {
+	// 检测窗口
+	Window time.Duration
+	// 判定为抖动的回退次数（默认 1）
+	Reversions int
+	// 判定为抖动后冻结配置键：保持当前值，拒绝后续变更直至调用 Unfreeze
+	Freeze bool
+}
+
+// flapRoute 按模式配置的抖动检测规则
+type flapRoute struct {
+	pattern string
+	rule    FlapRule
+}
+
+// WithFlapDetection 为匹配 pattern 的配置键开启抖动检测
+// 判定为抖动时发布 EventKeyFlapping 事件并发送 AlertKeyFlapping 告警；
+// 规则开启 Freeze 时配置键冻结在最近一次应用的值（即回退到的稳定值），
+// 后续变更以 ErrorKindFrozen 拒绝，直至操作人员通过 Unfreeze（或管理接口）解除冻结。
+// 多条规则按添加顺序匹配第一条
+func WithFlapDetection(pattern string, rule FlapRule) Option {
+	return func(m *Manager) {
+		if pattern == "" || rule.Window <= 0 {
+			return
+		}
+		if rule.Reversions <= 0 {
+			rule.Reversions = 1
+		}
+		m.flaps.routes = append(m.flaps.routes, flapRoute{
+			pattern: pattern,
+			rule:    rule,
+		})
+	}
+}
+
+// FrozenKey 因抖动被冻结的配置键
+type FrozenKey struct {
+	// 配置键
+	Key string `json:"key"`
+	// 冻结时的值
+	Value string `json:"value"`
+	// 判定为抖动时窗口内的回退次数
+	Reversions int `json:"reversions"`
+	// 冻结时间
+	FrozenAt time.Time `json:"frozen_at"`
+}
+
+// flapSample 窗口内应用过的值
+type flapSample struct {
+	value string
+	at    time.Time
+}
+
+// flapState 单个配置键的抖动检测状态
+type flapState struct {
+	// 窗口内应用过的值（按时间先后，最后一个为当前值）
+	samples []flapSample
+	// 窗口内的回退时间
+	reversions []time.Time
+}
+
+// flapDetector 按配置键的抖动检测器
+type flapDetector struct {
+	routes []flapRoute
+	states map[string]*flapState
+	// 状态数达到该值时清理过期状态，清理后翻倍，均摊开销为常数
+	sweepAt int
+	frozen  map[string]FrozenKey
+
+	mu sync.Mutex
+}
+
+// rule 返回配置键匹配的抖动检测规则
+func (d *flapDetector) rule(key string) *FlapRule {
+	for i := range d.routes {
+		if d.routes[i].pattern == "*" || matchPattern(d.routes[i].pattern, key) {
+			return &d.routes[i].rule
+		}
+	}
+	return nil
+}
+
+// checkFrozen 配置键已冻结时拒绝变更
+func (m *Manager) checkFrozen(change Change) error {
+	d := &m.flaps
+	if len(d.routes) == 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	frozen, ok := d.frozen[change.Key]
+	d.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	m.counters.recordFailure(ErrorKindFrozen)
+	m.logger.Warn("Config change rejected: key is frozen",
+		"key", change.Key,
+		"frozen_value", frozen.Value,
+		"new_value", change.NewValue,
+		"source", change.Source,
+		"actor", change.Actor)
+	return &ChangeError{Kind: ErrorKindFrozen, Key: change.Key, Err: ErrKeyFrozen}
+}
+
+// detectFlap 记录成功应用的配置变更，判定为抖动时发布事件并按规则冻结配置键
+func (m *Manager) detectFlap(change Change) {
+	d := &m.flaps
+	if len(d.routes) == 0 {
+		return
+	}
+	rule := d.rule(change.Key)
+	if rule == nil {
+		return
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	if d.states == nil {
+		d.states = make(map[string]*flapState)
+	}
+	state, ok := d.states[change.Key]
+	if !ok {
+		if len(d.states) >= d.sweepAt {
+			d.sweepLocked(now)
+			d.sweepAt = max(2*len(d.states), minStateSweep)
+		}
+		// 首次观察到的变更以旧值作为窗口内的起点
+		state = &flapState{samples: []flapSample{{value: change.OldValue, at: now}}}
+		d.states[change.Key] = state
+	}
+	state.prune(now, rule.Window)
+
+	// 新值回到窗口内出现过的旧值（不含当前值）记为一次回退
+	for i := 0; i+1 < len(state.samples); i++ {
+		if state.samples[i].value == change.NewValue {
+			state.reversions = append(state.reversions, now)
+			break
+		}
+	}
+	state.samples = append(state.samples, flapSample{value: change.NewValue, at: now})
+
+	reversions := len(state.reversions)
+	if reversions < rule.Reversions {
+		d.mu.Unlock()
+		return
+	}
+	// 判定为抖动后重新开始计数，避免每次变更都重复告警
+	state.samples = state.samples[len(state.samples)-1:]
+	state.reversions = nil
+	if rule.Freeze {
+		if d.frozen == nil {
+			d.frozen = make(map[string]FrozenKey)
+		}
+		d.frozen[change.Key] = FrozenKey{
+			Key:        change.Key,
+			Value:      change.NewValue,
+			Reversions: reversions,
+			FrozenAt:   now,
+		}
+	}
+	d.mu.Unlock()
+
+	message := fmt.Sprintf("key reverted to a previous value %d times within %s", reversions, rule.Window)
+	if rule.Freeze {
+		message += "; key frozen at its current value"
+	}
+	m.logger.Warn("Config key is flapping",
+		"key", change.Key,
+		"value", change.NewValue,
+		"reversions", reversions,
+		"window", rule.Window,
+		"frozen", rule.Freeze)
+	m.publishEvent(Event{
+		Type:           EventKeyFlapping,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Error:          message,
+		Time:           now,
+	})
+	m.emitAlert(AlertEvent{
+		Type:           AlertKeyFlapping,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Error:          message,
+		Time:           now,
+	})
+}
+
+// prune 移除窗口外的记录（始终保留当前值）
+func (s *flapState) prune(now time.Time, window time.Duration) {
+	n := 0
+	for n+1 < len(s.samples) && now.Sub(s.samples[n].at) >= window {
+		n++
+	}
+	if n > 0 {
+		s.samples = append(s.samples[:0], s.samples[n:]...)
+	}
+
+	n = 0
+	for n < len(s.reversions) && now.Sub(s.reversions[n]) >= window {
+		n++
+	}
+	if n > 0 {
+		s.reversions = append(s.reversions[:0], s.reversions[n:]...)
+	}
+}
+
+// sweepLocked 移除最近一次变更已在窗口外且没有回退记录的状态，避免不再变更的配置键长期占用内存
+// 配置键再次变更时以变更前的值为起点重新开始检测
+func (d *flapDetector) sweepLocked(now time.Time) {
+	for key, state := range d.states {
+		if rule := d.rule(key); rule != nil {
+			state.prune(now, rule.Window)
+			if len(state.reversions) > 0 || now.Sub(state.samples[len(state.samples)-1].at) < rule.Window {
+				continue
+			}
+		}
+		delete(d.states, key)
+	}
+}
+
+// FrozenKeys 按配置键排序返回因抖动被冻结的配置键
+func (m *Manager) FrozenKeys() []FrozenKey {
+	if m == nil {
+		return nil
+	}
+
+	m.flaps.mu.Lock()
+	defer m.flaps.mu.Unlock()
+
+	result := make([]FrozenKey, 0, len(m.flaps.frozen))
+	for _, frozen := range m.flaps.frozen {
+		result = append(result, frozen)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// Unfreeze 解除配置键的冻结并重置其抖动检测状态
+// 配置键未被冻结时返回错误
+func (m *Manager) Unfreeze(key string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	key = m.NormalizeKey(key)
+
+	m.flaps.mu.Lock()
+	frozen, ok := m.flaps.frozen[key]
+	if ok {
+		delete(m.flaps.frozen, key)
+		delete(m.flaps.states, key)
+	}
+	m.flaps.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("key %s is not frozen", key)
+	}
+
+	m.logger.Info("Config key unfrozen", "key", key, "value", frozen.Value)
+	m.publishEvent(Event{
+		Type:     EventKeyUnfrozen,
+		Key:      key,
+		NewValue: frozen.Value,
+		Time:     time.Now(),
+	})
+	return nil
+}
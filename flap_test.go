@@ -0,0 +1,67 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFlapDetectorSweepsExpiredStates(t *testing.T) {
+	const window = 20 * time.Millisecond
+	m := NewManager(WithLogger(NopLogger()), WithFlapDetection("app.*", FlapRule{Window: window, Reversions: 2}))
+	defer m.Close(context.Background())
+	if err := m.RegisterHandler("app.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	apply := func(key, value string) {
+		t.Helper()
+		if err := m.Apply(context.Background(), Change{Key: key, NewValue: value}); err != nil {
+			t.Fatalf("Apply(%q) error = %v", key, err)
+		}
+	}
+	for i := range minStateSweep {
+		apply(fmt.Sprintf("app.old%d", i), "v")
+	}
+	time.Sleep(2 * window)
+	// 窗口内有回退记录的状态不能被清理
+	apply("app.flapping", "a")
+	apply("app.flapping", "b")
+	apply("app.flapping", "a")
+	for i := range 2 * minStateSweep {
+		apply(fmt.Sprintf("app.new%d", i), "v")
+	}
+
+	m.flaps.mu.Lock()
+	defer m.flaps.mu.Unlock()
+	for key := range m.flaps.states {
+		if strings.HasPrefix(key, "app.old") {
+			t.Fatalf("expired state for %q not swept", key)
+		}
+	}
+	state, ok := m.flaps.states["app.flapping"]
+	if !ok {
+		t.Fatal("state with reversions within the window was swept")
+	}
+	if len(state.reversions) != 1 {
+		t.Fatalf("len(reversions) = %d, want 1", len(state.reversions))
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package grpcadmin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// Client 管理服务客户端
+type Client struct {
+	conn grpc.ClientConnInterface
+}
+
+// NewClient 基于已建立的 gRPC 连接创建管理服务客户端
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{conn: conn}
+}
+
+// invoke 调用管理服务方法
+func (c *Client) invoke(ctx context.Context, method string, req, resp any, opts ...grpc.CallOption) error {
+	opts = append([]grpc.CallOption{callOption()}, opts...)
+	return c.conn.Invoke(ctx, fullMethod(method), req, resp, opts...)
+}
+
+// ListReloaders 查询已注册的重载器
+func (c *Client) ListReloaders(ctx context.Context, req *ListReloadersRequest, opts ...grpc.CallOption) (*ListReloadersResponse, error) {
+	resp := new(ListReloadersResponse)
+	if err := c.invoke(ctx, "ListReloaders", req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetHistory 查询变更历史
+func (c *Client) GetHistory(ctx context.Context, req *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	resp := new(GetHistoryResponse)
+	if err := c.invoke(ctx, "GetHistory", req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TriggerChange 手动触发配置变更
+func (c *Client) TriggerChange(ctx context.Context, req *TriggerChangeRequest, opts ...grpc.CallOption) (*TriggerChangeResponse, error) {
+	resp := new(TriggerChangeResponse)
+	if err := c.invoke(ctx, "TriggerChange", req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DryRun 预演配置变更
+func (c *Client) DryRun(ctx context.Context, req *DryRunRequest, opts ...grpc.CallOption) (*DryRunResponse, error) {
+	resp := new(DryRunResponse)
+	if err := c.invoke(ctx, "DryRun", req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Rollback 回滚指定修订号的变更
+func (c *Client) Rollback(ctx context.Context, req *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
+	resp := new(RollbackResponse)
+	if err := c.invoke(ctx, "Rollback", req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package grpcadmin
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/proto"
+	"google.golang.org/grpc/mem"
+)
+
+// CodecName 管理服务使用的 gRPC 编解码器名称
+// 管理服务的消息为普通 Go 结构体，使用 JSON 编码传输；编解码器不会注册到进程全局，
+// 服务端需通过 ServerOption 创建 gRPC 服务器，本包的 Client 在每次调用时指定编解码器
+const CodecName = "json"
+
+// messagePkgPath 管理服务消息所在的包路径
+var messagePkgPath = reflect.TypeFor[ListReloadersRequest]().PkgPath()
+
+// ServerOption 返回使 gRPC 服务器能够编解码管理服务消息的选项
+// 管理服务的消息使用 JSON 编码，其他服务的消息仍交给 protobuf 编解码器，便于与业务服务共用一个 gRPC 服务器
+func ServerOption() grpc.ServerOption {
+	return grpc.ForceServerCodecV2(serverCodec{fallback: encoding.GetCodecV2(proto.Name)})
+}
+
+// callOption 返回客户端调用管理服务方法时指定 JSON 编解码器的选项
+func callOption() grpc.CallOption {
+	return grpc.ForceCodecV2(jsonCodec{})
+}
+
+// jsonCodec JSON 编解码器
+type jsonCodec struct{}
+
+// Marshal 编码消息
+func (jsonCodec) Marshal(v any) (mem.BufferSlice, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return mem.BufferSlice{mem.SliceBuffer(data)}, nil
+}
+
+// Unmarshal 解码消息
+func (jsonCodec) Unmarshal(data mem.BufferSlice, v any) error {
+	return json.Unmarshal(data.Materialize(), v)
+}
+
+// Name 返回编解码器名称
+func (jsonCodec) Name() string {
+	return CodecName
+}
+
+// serverCodec 服务端编解码器：管理服务的消息使用 JSON，其他消息交给 fallback
+type serverCodec struct {
+	fallback encoding.CodecV2
+}
+
+// Marshal 编码消息
+func (c serverCodec) Marshal(v any) (mem.BufferSlice, error) {
+	if isAdminMessage(v) {
+		return jsonCodec{}.Marshal(v)
+	}
+	return c.fallback.Marshal(v)
+}
+
+// Unmarshal 解码消息
+func (c serverCodec) Unmarshal(data mem.BufferSlice, v any) error {
+	if isAdminMessage(v) {
+		return jsonCodec{}.Unmarshal(data, v)
+	}
+	return c.fallback.Unmarshal(data, v)
+}
+
+// Name 返回编解码器名称
+func (c serverCodec) Name() string {
+	return c.fallback.Name()
+}
+
+// isAdminMessage 判断消息是否为管理服务的消息
+func isAdminMessage(v any) bool {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t != nil && t.PkgPath() == messagePkgPath
+}
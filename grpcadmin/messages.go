@@ -0,0 +1,82 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package grpcadmin
+
+import (
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// ListReloadersRequest 查询重载器列表请求
+type ListReloadersRequest struct{}
+
+// ListReloadersResponse 查询重载器列表响应
+type ListReloadersResponse struct {
+	Reloaders []hotreload.ReloaderInfo `json:"reloaders"`
+}
+
+// GetHistoryRequest 查询变更历史请求
+type GetHistoryRequest struct {
+	// 返回的最大条数（<= 0 表示全部）
+	Limit int `json:"limit"`
+}
+
+// GetHistoryResponse 查询变更历史响应
+type GetHistoryResponse struct {
+	Entries []hotreload.HistoryEntry `json:"entries"`
+}
+
+// TriggerChangeRequest 手动触发配置变更请求
+type TriggerChangeRequest struct {
+	Key      string `json:"key"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	// 删除配置键（忽略 NewValue）
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// TriggerChangeResponse 手动触发配置变更响应
+type TriggerChangeResponse struct {
+	// 应用后的修订号（变更未被应用时为 0）
+	Revision uint64 `json:"revision"`
+	// 处理结果（applied、deferred、superseded 等）
+	Outcome hotreload.ChangeOutcome `json:"outcome,omitempty"`
+}
+
+// DryRunRequest 预演配置变更请求
+type DryRunRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// DryRunResponse 预演配置变更响应
+type DryRunResponse struct {
+	Result hotreload.DryRunResult `json:"result"`
+}
+
+// RollbackRequest 回滚请求
+type RollbackRequest struct {
+	// 要回滚的修订号
+	Revision uint64 `json:"revision"`
+}
+
+// RollbackResponse 回滚响应
+type RollbackResponse struct {
+	// 回滚后的修订号（变更未被应用时为 0）
+	Revision uint64 `json:"revision"`
+	// 处理结果（applied、deferred、superseded 等）
+	Outcome hotreload.ChangeOutcome `json:"outcome,omitempty"`
+}
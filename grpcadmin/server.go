@@ -0,0 +1,144 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package grpcadmin 提供热加载管理器的 gRPC 管理服务
+// 服务提供 ListReloaders、GetHistory、TriggerChange、DryRun、Rollback 五个方法，
+// 供管理大量服务实例的控制面使用。消息以 JSON 编码传输（见 CodecName、ServerOption）
+package grpcadmin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-anyway/framework-hotreload"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceName gRPC 服务全名
+const ServiceName = "hotreload.admin.v1.AdminService"
+
+// AuthFunc 鉴权函数
+// fullMethod 为 gRPC 方法全名（如 "/hotreload.admin.v1.AdminService/Rollback"）
+// 返回调用方身份（如证书主体、Token 主体），会随配置变更记录到审计事件与日志中；
+// 返回错误时拒绝请求，建议返回带有 codes.Unauthenticated 或 codes.PermissionDenied 的 status 错误
+type AuthFunc func(ctx context.Context, fullMethod string) (principal string, err error)
+
+// AdminServer 管理服务接口
+type AdminServer interface {
+	ListReloaders(ctx context.Context, req *ListReloadersRequest) (*ListReloadersResponse, error)
+	GetHistory(ctx context.Context, req *GetHistoryRequest) (*GetHistoryResponse, error)
+	TriggerChange(ctx context.Context, req *TriggerChangeRequest) (*TriggerChangeResponse, error)
+	DryRun(ctx context.Context, req *DryRunRequest) (*DryRunResponse, error)
+	Rollback(ctx context.Context, req *RollbackRequest) (*RollbackResponse, error)
+}
+
+// Server 基于热加载管理器的管理服务实现
+type Server struct {
+	manager *hotreload.Manager
+}
+
+var _ AdminServer = (*Server)(nil)
+
+// NewServer 创建管理服务
+func NewServer(manager *hotreload.Manager) *Server {
+	return &Server{manager: manager}
+}
+
+// Register 将管理服务注册到 gRPC 服务器
+func Register(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&ServiceDesc, srv)
+}
+
+// UnaryServerInterceptor 返回对管理服务方法进行鉴权的一元拦截器
+// 只对本服务的方法生效，其他服务的请求直接放行，便于与业务服务共用一个 gRPC 服务器
+func UnaryServerInterceptor(auth AuthFunc) grpc.UnaryServerInterceptor {
+	prefix := "/" + ServiceName + "/"
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if auth != nil && strings.HasPrefix(info.FullMethod, prefix) {
+			principal, err := auth(ctx, info.FullMethod)
+			if err != nil {
+				if _, ok := status.FromError(err); ok {
+					return nil, err
+				}
+				return nil, status.Error(codes.PermissionDenied, err.Error())
+			}
+			if principal != "" {
+				ctx = hotreload.ContextWithActor(ctx, principal)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// ListReloaders 查询已注册的重载器
+func (s *Server) ListReloaders(ctx context.Context, req *ListReloadersRequest) (*ListReloadersResponse, error) {
+	return &ListReloadersResponse{Reloaders: s.manager.ListReloaders()}, nil
+}
+
+// GetHistory 查询变更历史
+func (s *Server) GetHistory(ctx context.Context, req *GetHistoryRequest) (*GetHistoryResponse, error) {
+	return &GetHistoryResponse{Entries: s.manager.History(req.Limit)}, nil
+}
+
+// TriggerChange 手动触发配置变更
+func (s *Server) TriggerChange(ctx context.Context, req *TriggerChangeRequest) (*TriggerChangeResponse, error) {
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is empty")
+	}
+	result, err := s.manager.ApplyWithReport(ctx, hotreload.Change{
+		Key:      req.Key,
+		OldValue: req.OldValue,
+		NewValue: req.NewValue,
+		Deleted:  req.Deleted,
+		Source:   hotreload.SourceAdminGRPC,
+	})
+	if err != nil {
+		return nil, changeStatus(err)
+	}
+	return &TriggerChangeResponse{Revision: result.Revision, Outcome: result.Outcome}, nil
+}
+
+// DryRun 预演配置变更
+func (s *Server) DryRun(ctx context.Context, req *DryRunRequest) (*DryRunResponse, error) {
+	if req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "key is empty")
+	}
+	return &DryRunResponse{Result: s.manager.DryRun(req.Key, req.Value)}, nil
+}
+
+// Rollback 回滚指定修订号的变更
+func (s *Server) Rollback(ctx context.Context, req *RollbackRequest) (*RollbackResponse, error) {
+	if req.Revision == 0 {
+		return nil, status.Error(codes.InvalidArgument, "revision is empty")
+	}
+	result, err := s.manager.RollbackWithReport(ctx, req.Revision)
+	if err != nil {
+		return nil, changeStatus(err)
+	}
+	return &RollbackResponse{Revision: result.Revision, Outcome: result.Outcome}, nil
+}
+
+// changeStatus 将配置变更错误转换为 gRPC status 错误（被授权策略拒绝时为 PermissionDenied）
+func changeStatus(err error) error {
+	if errors.Is(err, hotreload.ErrUnauthorized) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return status.Error(codes.FailedPrecondition, err.Error())
+}
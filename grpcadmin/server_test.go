@@ -0,0 +1,77 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package grpcadmin
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServerSharesGRPCServerWithProtobufServices(t *testing.T) {
+	manager := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	defer manager.Close(context.Background())
+	if err := manager.RegisterHandler("server.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(ServerOption())
+	Register(server, NewServer(manager))
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+
+	ctx := context.Background()
+	client := NewClient(conn)
+	resp, err := client.TriggerChange(ctx, &TriggerChangeRequest{Key: "server.port", NewValue: "8080"})
+	if err != nil {
+		t.Fatalf("TriggerChange() error = %v", err)
+	}
+	if resp.Outcome != hotreload.OutcomeApplied || resp.Revision == 0 {
+		t.Fatalf("TriggerChange() = %+v, want applied with revision", resp)
+	}
+
+	manager.Pause()
+	resp, err = client.TriggerChange(ctx, &TriggerChangeRequest{Key: "server.port", NewValue: "9090"})
+	if err != nil {
+		t.Fatalf("TriggerChange() while paused error = %v", err)
+	}
+	if resp.Outcome != hotreload.OutcomeDeferred || resp.Revision != 0 {
+		t.Fatalf("TriggerChange() while paused = %+v, want deferred without revision", resp)
+	}
+
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("health Check() error = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package grpcadmin
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// ServiceDesc 管理服务描述
+var ServiceDesc = grpc.ServiceDesc{
+	ServiceName: ServiceName,
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ListReloaders", Handler: listReloadersHandler},
+		{MethodName: "GetHistory", Handler: getHistoryHandler},
+		{MethodName: "TriggerChange", Handler: triggerChangeHandler},
+		{MethodName: "DryRun", Handler: dryRunHandler},
+		{MethodName: "Rollback", Handler: rollbackHandler},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hotreload/admin/v1/admin",
+}
+
+// fullMethod 返回方法全名
+func fullMethod(method string) string {
+	return "/" + ServiceName + "/" + method
+}
+
+// unary 构造一元方法处理器
+func unary[Req any, Resp any](method string, call func(srv AdminServer, ctx context.Context, req *Req) (*Resp, error)) grpc.MethodHandler {
+	return func(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		if interceptor == nil {
+			return call(srv.(AdminServer), ctx, req)
+		}
+		info := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: fullMethod(method),
+		}
+		handler := func(ctx context.Context, req any) (any, error) {
+			return call(srv.(AdminServer), ctx, req.(*Req))
+		}
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+var (
+	listReloadersHandler = unary("ListReloaders", AdminServer.ListReloaders)
+	getHistoryHandler    = unary("GetHistory", AdminServer.GetHistory)
+	triggerChangeHandler = unary("TriggerChange", AdminServer.TriggerChange)
+	dryRunHandler        = unary("DryRun", AdminServer.DryRun)
+	rollbackHandler      = unary("Rollback", AdminServer.Rollback)
+)
@@ -0,0 +1,190 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultHealthFailureThreshold 默认的健康检查失败阈值
+const defaultHealthFailureThreshold = 3
+
+// HealthStatus 健康状态
+type HealthStatus string
+
+const (
+	// HealthStatusUp 健康
+	HealthStatusUp HealthStatus = "up"
+	// HealthStatusDegraded 降级（热加载仍可工作，但存在需要关注的问题）
+	HealthStatusDegraded HealthStatus = "degraded"
+)
+
+// Health 健康检查结果
+type Health struct {
+	// 健康状态
+	Status HealthStatus `json:"status"`
+	// 降级原因（健康时为空）
+	Reasons []string `json:"reasons,omitempty"`
+	// 已断开连接的配置源
+	DisconnectedSources []string `json:"disconnected_sources,omitempty"`
+	// 已被隔离的处理器模式
+	QuarantinedPatterns []string `json:"quarantined_patterns,omitempty"`
+	// 已回退到持久化快照的配置键前缀
+	FallbackPrefixes []string `json:"fallback_prefixes,omitempty"`
+	// 最近连续失败的配置变更次数
+	ConsecutiveFailures int `json:"consecutive_failures"`
+	// 检查时间
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// HealthChecker 健康检查接口
+// Check 的签名与常见健康检查框架（如 health-go、alexliesenfeld/health）的检查函数兼容，
+// 可直接作为检查项注册：返回 nil 表示健康，返回错误表示降级
+type HealthChecker interface {
+	// HealthCheck 返回详细的健康检查结果
+	HealthCheck() Health
+
+	// Check 执行健康检查，降级时返回错误
+	Check(ctx context.Context) error
+}
+
+var _ HealthChecker = (*Manager)(nil)
+
+// ErrDegraded 健康检查判定为降级时返回的错误
+var ErrDegraded = errors.New("hotreload degraded")
+
+// healthState 健康状态跟踪
+type healthState struct {
+	// 最近连续失败的配置变更次数
+	consecutiveFailures atomic.Int64
+
+	// 配置源连接状态（source name -> connected）
+	sources map[string]bool
+
+	mu sync.RWMutex
+}
+
+// newHealthState 创建健康状态跟踪
+func newHealthState() healthState {
+	return healthState{
+		sources: make(map[string]bool),
+	}
+}
+
+// recordSuccess 记录一次成功的配置变更
+func (h *healthState) recordSuccess() {
+	h.consecutiveFailures.Store(0)
+}
+
+// recordFailure 记录一次失败的配置变更
+func (h *healthState) recordFailure() {
+	h.consecutiveFailures.Add(1)
+}
+
+// ReportSourceStatus 上报配置源连接状态
+// 由配置中心客户端在连接建立或断开时调用，断开的配置源会使健康检查判定为降级
+func (m *Manager) ReportSourceStatus(source string, connected bool) {
+	if m == nil || source == "" {
+		return
+	}
+
+	m.health.mu.Lock()
+	previous, known := m.health.sources[source]
+	m.health.sources[source] = connected
+	m.health.mu.Unlock()
+
+	if known && previous == connected {
+		return
+	}
+
+	if connected {
+		m.logger.Info("Config source connected", "source", source)
+	} else {
+		m.logger.Warn("Config source disconnected", "source", source)
+	}
+	m.evaluateFallback(source, connected)
+}
+
+// HealthCheck 返回热加载的健康检查结果
+// 以下任一情况判定为降级：
+//   - 存在已断开连接的配置源
+//   - 存在已被隔离的处理器
+//   - 存在已回退到持久化快照的前缀（见 WithSnapshotFallback）
+//   - 最近连续失败的配置变更次数达到阈值（见 WithHealthFailureThreshold）
+func (m *Manager) HealthCheck() Health {
+	health := Health{
+		Status:    HealthStatusUp,
+		CheckedAt: time.Now(),
+	}
+	if m == nil {
+		health.Status = HealthStatusDegraded
+		health.Reasons = []string{"manager is nil"}
+		return health
+	}
+
+	m.health.mu.RLock()
+	for source, connected := range m.health.sources {
+		if !connected {
+			health.DisconnectedSources = append(health.DisconnectedSources, source)
+		}
+	}
+	m.health.mu.RUnlock()
+	sort.Strings(health.DisconnectedSources)
+
+	health.QuarantinedPatterns = m.quarantinedPatterns()
+	health.FallbackPrefixes = m.FallbackPrefixes()
+	health.ConsecutiveFailures = int(m.health.consecutiveFailures.Load())
+
+	if len(health.DisconnectedSources) > 0 {
+		health.Reasons = append(health.Reasons,
+			fmt.Sprintf("config sources disconnected: %s", strings.Join(health.DisconnectedSources, ", ")))
+	}
+	if len(health.QuarantinedPatterns) > 0 {
+		health.Reasons = append(health.Reasons,
+			fmt.Sprintf("config handlers quarantined: %s", strings.Join(health.QuarantinedPatterns, ", ")))
+	}
+	if len(health.FallbackPrefixes) > 0 {
+		health.Reasons = append(health.Reasons,
+			fmt.Sprintf("serving config from snapshot fallback: %s", strings.Join(health.FallbackPrefixes, ", ")))
+	}
+	if m.healthFailureThreshold > 0 && health.ConsecutiveFailures >= m.healthFailureThreshold {
+		health.Reasons = append(health.Reasons,
+			fmt.Sprintf("last %d config changes failed", health.ConsecutiveFailures))
+	}
+
+	if len(health.Reasons) > 0 {
+		health.Status = HealthStatusDegraded
+	}
+
+	return health
+}
+
+// Check 执行健康检查，降级时返回包装了 ErrDegraded 的错误
+func (m *Manager) Check(ctx context.Context) error {
+	health := m.HealthCheck()
+	if health.Status == HealthStatusUp {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrDegraded, strings.Join(health.Reasons, "; "))
+}
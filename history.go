@@ -0,0 +1,298 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultHistorySize 默认保留的变更历史条数
+const defaultHistorySize = 100
+
+// ChangeOutcome 配置变更处理结果
+type ChangeOutcome string
+
+const (
+	// OutcomeApplied 配置变更已成功应用
+	OutcomeApplied ChangeOutcome = "applied"
+	// OutcomeFailed 配置变更处理失败
+	OutcomeFailed ChangeOutcome = "failed"
+	// OutcomeRestartRequired 配置变更已被接受，但需重启服务才能生效（原因记录在 Error 中）
+	OutcomeRestartRequired ChangeOutcome = "restart_required"
+	// OutcomeUnmatched 没有任何处理器匹配该配置键（不记录到变更历史）
+	OutcomeUnmatched ChangeOutcome = "unmatched"
+	// OutcomeDeferred 配置变更被暂存（暂停分发或超出频率限制后合并），稍后应用（不记录到变更历史）
+	OutcomeDeferred ChangeOutcome = "deferred"
+	// OutcomeSuperseded 配置变更早于已应用的变更而被丢弃（见 WithSourceOrdering，不记录到变更历史）
+	OutcomeSuperseded ChangeOutcome = "superseded"
+	// OutcomeDenied 配置变更被授权策略拒绝（见 WithAuthorizer）
+	OutcomeDenied ChangeOutcome = "denied"
+)
+
+// HistoryEntry 变更历史记录
+type HistoryEntry struct {
+	// 应用后的修订号（处理失败时为 0）
+	Revision uint64 `json:"revision,omitempty"`
+	// 配置键
+	Key string `json:"key"`
+	// 旧值
+	OldValue string `json:"old_value"`
+	// 新值
+	NewValue string `json:"new_value"`
+	// 配置键已被删除
+	Deleted bool `json:"deleted,omitempty"`
+	// 配置键在该变更前没有应用的值（新建），回滚时删除配置键
+	Created bool `json:"created,omitempty"`
+	// 新值来自默认值注册表
+	Defaulted bool `json:"defaulted,omitempty"`
+	// 变更来源（如配置中心名称、admin-http）
+	Source string `json:"source,omitempty"`
+	// 操作者身份（如配置中心用户、管理接口调用方）
+	Actor string `json:"actor,omitempty"`
+	// 配置源的修订号
+	SourceRevision string `json:"source_revision,omitempty"`
+	// 处理结果
+	Outcome ChangeOutcome `json:"outcome"`
+	// 错误分类（处理失败时有效）
+	ErrorKind ErrorKind `json:"error_kind,omitempty"`
+	// 错误信息（处理失败时有效）
+	Error string `json:"error,omitempty"`
+	// 回滚的目标修订号（该变更为回滚操作时有效）
+	RollbackOf uint64 `json:"rollback_of,omitempty"`
+	// 处理时间
+	Time time.Time `json:"time"`
+}
+
+// history 固定容量的变更历史环形缓冲区
+type history struct {
+	entries []HistoryEntry
+	// 下一条记录的写入位置
+	next int
+	// 是否已写满一轮
+	full bool
+
+	mu sync.RWMutex
+}
+
+// newHistory 创建变更历史
+func newHistory(size int) *history {
+	if size <= 0 {
+		size = defaultHistorySize
+	}
+	return &history{entries: make([]HistoryEntry, size)}
+}
+
+// add 追加一条历史记录，超出容量时覆盖最旧的记录
+func (h *history) add(entry HistoryEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries[h.next] = entry
+	h.next++
+	if h.next == len(h.entries) {
+		h.next = 0
+		h.full = true
+	}
+}
+
+// list 按时间倒序返回最近的 limit 条记录（limit <= 0 表示全部）
+func (h *history) list(limit int) []HistoryEntry {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	count := h.next
+	if h.full {
+		count = len(h.entries)
+	}
+	if limit <= 0 || limit > count {
+		limit = count
+	}
+
+	result := make([]HistoryEntry, 0, limit)
+	for i := 0; i < limit; i++ {
+		idx := (h.next - 1 - i + len(h.entries)) % len(h.entries)
+		result = append(result, h.entries[idx])
+	}
+	return result
+}
+
+// find 查找指定修订号的历史记录
+func (h *history) find(revision uint64) (HistoryEntry, bool) {
+	for _, entry := range h.list(0) {
+		if entry.Revision == revision {
+			return entry, true
+		}
+	}
+	return HistoryEntry{}, false
+}
+
+// WithHistorySize 设置保留的变更历史条数（默认 100）
+func WithHistorySize(size int) Option {
+	return func(m *Manager) {
+		m.history = newHistory(size)
+	}
+}
+
+// History 按时间倒序返回最近的变更历史（limit <= 0 表示全部）
+func (m *Manager) History(limit int) []HistoryEntry {
+	if m == nil {
+		return nil
+	}
+	return m.history.list(limit)
+}
+
+// Rollback 将指定修订号的变更回滚到变更前的值
+// 回滚本身作为一次新的配置变更经过完整的验证与分发流程，并记录到变更历史
+// 操作者身份从 ctx 中读取（见 ContextWithActor）
+func (m *Manager) Rollback(ctx context.Context, revision uint64) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+
+	change, err := m.rollbackChange(revision)
+	if err != nil {
+		return err
+	}
+	return m.dispatch(ctx, change, revision)
+}
+
+// RollbackWithReport 回滚指定修订号的变更，并返回回滚变更的处理结果（修订号、处理结果与处理器执行明细）
+func (m *Manager) RollbackWithReport(ctx context.Context, revision uint64) (ChangeResult, error) {
+	if m == nil {
+		return ChangeResult{}, fmt.Errorf("manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	change, err := m.rollbackChange(revision)
+	if err != nil {
+		return ChangeResult{}, err
+	}
+	reports := make([]HandlerReport, 0)
+	result := m.dispatchResult(ctx, change, revision, &reports).ownedHandlers()
+	return result, result.Err
+}
+
+// rollbackChange 构造回滚指定修订号的配置变更
+func (m *Manager) rollbackChange(revision uint64) (Change, error) {
+	entry, ok := m.history.find(revision)
+	if !ok || (entry.Outcome != OutcomeApplied && entry.Outcome != OutcomeRestartRequired) {
+		return Change{}, fmt.Errorf("revision %d not found in history", revision)
+	}
+	// 回滚新建即删除配置键，回滚删除即恢复删除前的值
+	return Change{
+		Key:      entry.Key,
+		OldValue: entry.NewValue,
+		NewValue: entry.OldValue,
+		Deleted:  entry.Created,
+		Source:   SourceRollback,
+	}, nil
+}
+
+// DryRunResult 配置变更预演结果
+type DryRunResult struct {
+	// 配置键
+	Key string `json:"key"`
+	// 配置值
+	Value string `json:"value"`
+	// 匹配的处理器模式
+	MatchedPatterns []string `json:"matched_patterns"`
+	// 是否通过验证
+	Valid bool `json:"valid"`
+	// 验证失败的错误信息
+	Error string `json:"error,omitempty"`
+}
+
+// DryRun 预演配置变更
+// 依次解压、执行转换流水线并调用匹配重载器的 Validate，不调用 OnChange，也不记录历史，用于在推送前检查配置值是否有效
+func (m *Manager) DryRun(key, value string) DryRunResult {
+	result := DryRunResult{
+		Key:             key,
+		Value:           value,
+		MatchedPatterns: make([]string, 0),
+		Valid:           true,
+	}
+	if m == nil {
+		result.Valid = false
+		result.Error = "manager is nil"
+		return result
+	}
+
+	key = m.NormalizeKey(key)
+	if m.decompressor != nil {
+		decoded, err := m.decompressor.decode(value)
+		if err != nil {
+			result.Valid = false
+			result.Error = fmt.Sprintf("validation failed for key %s: %v", key, err)
+		}
+		value = decoded
+	}
+	if result.Valid {
+		transformed, err := m.transformValue(key, value)
+		if err != nil {
+			result.Valid = false
+			result.Error = err.Error()
+		}
+		value = transformed
+	}
+	for _, reg := range m.match(key, nil) {
+		result.MatchedPatterns = append(result.MatchedPatterns, reg.pattern)
+		if reg.validate == nil || !result.Valid {
+			continue
+		}
+		if err := reg.validate(key, value); err != nil {
+			result.Valid = false
+			result.Error = fmt.Sprintf("validation failed for key %s: %v", key, err)
+		}
+	}
+
+	return result
+}
+
+// ReloaderInfo 重载器信息
+type ReloaderInfo struct {
+	// 重载器名称
+	Name string `json:"name"`
+	// 重载器类型
+	Type string `json:"type"`
+	// 配置键模式列表
+	Patterns []string `json:"patterns"`
+}
+
+// ListReloaders 返回已注册的重载器列表
+func (m *Manager) ListReloaders() []ReloaderInfo {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]ReloaderInfo, 0, len(m.reloaders))
+	for _, entry := range m.reloaders {
+		result = append(result, ReloaderInfo{
+			Name:     entry.name,
+			Type:     fmt.Sprintf("%T", entry.reloader),
+			Patterns: entry.reloader.Patterns(),
+		})
+	}
+	return result
+}
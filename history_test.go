@@ -0,0 +1,100 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"testing"
+)
+
+// newRollbackManager 创建记录所收到配置变更的管理器
+func newRollbackManager(t *testing.T) (*Manager, *[]Change) {
+	t.Helper()
+	m := NewManager(WithLogger(NopLogger()))
+	t.Cleanup(func() { m.Close(context.Background()) })
+	var received []Change
+	if err := m.RegisterChangeHandler("app.*", func(ctx context.Context, change Change) error {
+		received = append(received, change)
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterChangeHandler() error = %v", err)
+	}
+	return m, &received
+}
+
+func TestRollbackCreationDeletesKey(t *testing.T) {
+	m, received := newRollbackManager(t)
+
+	result, err := m.ApplyWithReport(context.Background(), Change{Key: "app.key", NewValue: "v1"})
+	if err != nil {
+		t.Fatalf("ApplyWithReport() error = %v", err)
+	}
+	if err := m.Rollback(context.Background(), result.Revision); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	last := (*received)[len(*received)-1]
+	if !last.Deleted || last.NewValue != "" || last.OldValue != "v1" {
+		t.Fatalf("rollback change = %+v, want deletion of %q", last, "v1")
+	}
+	if _, ok := m.LastApplied("app.key"); ok {
+		t.Fatal("LastApplied() found the key after rolling back its creation")
+	}
+}
+
+func TestRollbackDeletionRestoresValue(t *testing.T) {
+	m, received := newRollbackManager(t)
+
+	if err := m.Apply(context.Background(), Change{Key: "app.key", NewValue: "v1"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	result, err := m.ApplyWithReport(context.Background(), Change{Key: "app.key", OldValue: "v1", Deleted: true})
+	if err != nil {
+		t.Fatalf("ApplyWithReport() error = %v", err)
+	}
+	if err := m.Rollback(context.Background(), result.Revision); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	last := (*received)[len(*received)-1]
+	if last.Deleted || last.NewValue != "v1" {
+		t.Fatalf("rollback change = %+v, want restore of %q", last, "v1")
+	}
+	if applied, ok := m.LastApplied("app.key"); !ok || applied.Value != "v1" {
+		t.Fatalf("LastApplied() = %+v, %v, want value %q", applied, ok, "v1")
+	}
+}
+
+func TestRollbackUpdateKeepsKey(t *testing.T) {
+	m, received := newRollbackManager(t)
+
+	if err := m.Apply(context.Background(), Change{Key: "app.key", NewValue: "v1"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	result, err := m.ApplyWithReport(context.Background(), Change{Key: "app.key", OldValue: "v1", NewValue: "v2"})
+	if err != nil {
+		t.Fatalf("ApplyWithReport() error = %v", err)
+	}
+	if err := m.Rollback(context.Background(), result.Revision); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+
+	last := (*received)[len(*received)-1]
+	if last.Deleted || last.NewValue != "v1" {
+		t.Fatalf("rollback change = %+v, want restore of %q", last, "v1")
+	}
+}
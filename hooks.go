@@ -0,0 +1,123 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// ChangeResult 一次配置变更分发的处理结果
+type ChangeResult struct {
+	// 处理结果（applied、failed、unmatched 等）
+	Outcome ChangeOutcome
+	// 应用后的修订号（成功应用时有效）
+	Revision uint64
+	// 已成功执行的重载器/处理器名称，按执行顺序排列
+	Handlers []string
+	// 处理失败时的错误（*ChangeError）
+	Err error
+	// 分发耗时
+	Duration time.Duration
+	// 每个匹配处理器的执行明细，按执行顺序排列（仅 ApplyWithReport 等返回的结果包含）
+	Reports []HandlerReport
+}
+
+// BeforeChangeHook 全局前置钩子，在校验与调用任何处理器之前执行
+// 可修改 change（如补充操作者身份、来源等审计信息），修改结果会传递给后续处理器
+type BeforeChangeHook func(ctx context.Context, change *Change)
+
+// AfterChangeHook 全局后置钩子，在所有匹配的处理器执行完成后执行（无论成功、失败或未匹配）
+// 适用于审计、缓存失效等不适合建模为模式处理器的横切逻辑
+type AfterChangeHook func(ctx context.Context, change Change, result ChangeResult)
+
+// OnBeforeChange 注册全局前置钩子，按注册顺序执行
+// 暂停期间暂存的变更在恢复分发时才会触发钩子
+func (m *Manager) OnBeforeChange(hook BeforeChangeHook) {
+	if m == nil || hook == nil {
+		return
+	}
+	m.mu.Lock()
+	m.beforeHooks = append(m.beforeHooks, hook)
+	m.mu.Unlock()
+}
+
+// OnAfterChange 注册全局后置钩子，按注册顺序执行
+func (m *Manager) OnAfterChange(hook AfterChangeHook) {
+	if m == nil || hook == nil {
+		return
+	}
+	m.mu.Lock()
+	m.afterHooks = append(m.afterHooks, hook)
+	m.mu.Unlock()
+}
+
+// runBeforeChangeHooks 执行全局前置钩子，返回钩子修改后的配置变更
+func (m *Manager) runBeforeChangeHooks(ctx context.Context, change Change) Change {
+	m.mu.RLock()
+	hooks := m.beforeHooks
+	m.mu.RUnlock()
+
+	if len(hooks) == 0 {
+		return change
+	}
+	return m.callBeforeChangeHooks(ctx, hooks, change)
+}
+
+// callBeforeChangeHooks 依次调用前置钩子
+// 与 runBeforeChangeHooks 分开，使未注册钩子时配置变更不会逃逸到堆上
+func (m *Manager) callBeforeChangeHooks(ctx context.Context, hooks []BeforeChangeHook, change Change) Change {
+	for _, hook := range hooks {
+		func() {
+			defer m.recoverHook("before", change.Key)
+			hook(ctx, &change)
+		}()
+	}
+	return change
+}
+
+// runAfterChangeHooks 执行全局后置钩子
+func (m *Manager) runAfterChangeHooks(ctx context.Context, change Change, result ChangeResult) {
+	m.mu.RLock()
+	hooks := m.afterHooks
+	m.mu.RUnlock()
+
+	for _, hook := range hooks {
+		func() {
+			defer m.recoverHook("after", change.Key)
+			hook(ctx, change, result.ownedHandlers())
+		}()
+	}
+}
+
+// recoverHook 恢复钩子中的 panic，避免影响配置变更分发
+func (m *Manager) recoverHook(phase, key string) {
+	if r := recover(); r != nil {
+		m.logger.Error("Config change hook panicked",
+			"phase", phase,
+			"key", key,
+			"panic", r)
+	}
+}
+
+// ownedHandlers 返回 Handlers 为独立副本的处理结果
+// 分发时 Handlers 可能直接引用处理器登记的名称列表（见 appendHandlerName），交给调用方之前需要复制
+func (r ChangeResult) ownedHandlers() ChangeResult {
+	r.Handlers = slices.Clone(r.Handlers)
+	return r
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package hotreloadfx 提供热加载管理器的 fx 模块
+//
+// Module 构造 *hotreload.Manager，收集依赖图中以 AsReloader 提供的所有重载器并注册，
+// 并将以 AsSource 提供的配置源的启停绑定到 fx 应用生命周期：
+//
+//	fx.New(
+//		hotreloadfx.Module,
+//		fx.Provide(
+//			hotreloadfx.AsOption(func() hotreload.Option { return hotreload.WithLogger(logger) }),
+//			hotreloadfx.AsReloader(ratelimit.NewReloader),
+//			hotreloadfx.AsSource(nacos.NewSource),
+//		),
+//	)
+package hotreloadfx
+
+import (
+	"context"
+
+	"github.com/go-anyway/framework-hotreload"
+	"go.uber.org/fx"
+)
+
+const (
+	// OptionsGroup 管理器选项的 fx 值组
+	OptionsGroup = "hotreload_options"
+	// ReloadersGroup 重载器的 fx 值组
+	ReloadersGroup = "hotreload_reloaders"
+	// SourcesGroup 配置源的 fx 值组
+	SourcesGroup = "hotreload_sources"
+)
+
+// Module 热加载管理器 fx 模块
+var Module = fx.Module("hotreload",
+	fx.Provide(NewManager),
+	fx.Invoke(registerReloaders),
+	fx.Invoke(bindSources),
+)
+
+// ManagerParams 构造管理器的依赖
+type ManagerParams struct {
+	fx.In
+
+	Options []hotreload.Option `group:"hotreload_options"`
+}
+
+// NewManager 以依赖图中收集的选项构造管理器
+func NewManager(p ManagerParams) *hotreload.Manager {
+	return hotreload.NewManager(p.Options...)
+}
+
+// AsOption 将返回 hotreload.Option 的构造函数加入管理器选项值组
+func AsOption(constructor any) any {
+	return fx.Annotate(constructor, fx.ResultTags(`group:"`+OptionsGroup+`"`))
+}
+
+// AsReloader 将返回重载器的构造函数加入重载器值组
+func AsReloader(constructor any) any {
+	return fx.Annotate(constructor,
+		fx.As(new(hotreload.Reloader)),
+		fx.ResultTags(`group:"`+ReloadersGroup+`"`))
+}
+
+// AsSource 将返回配置源的构造函数加入配置源值组
+func AsSource(constructor any) any {
+	return fx.Annotate(constructor,
+		fx.As(new(hotreload.Source)),
+		fx.ResultTags(`group:"`+SourcesGroup+`"`))
+}
+
+// reloadersParams 注册重载器的依赖
+type reloadersParams struct {
+	fx.In
+
+	Manager   *hotreload.Manager
+	Reloaders []hotreload.Reloader `group:"hotreload_reloaders"`
+}
+
+// registerReloaders 注册依赖图中收集的所有重载器
+func registerReloaders(p reloadersParams) error {
+	return p.Manager.RegisterReloaders(p.Reloaders...)
+}
+
+// sourcesParams 绑定配置源生命周期的依赖
+type sourcesParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Manager   *hotreload.Manager
+	Sources   []hotreload.Source `group:"hotreload_sources"`
+}
+
+// bindSources 在应用启动时启动配置源，停止时关闭管理器（按相反顺序停止配置源并等待在途变更完成）
+// 重载器在 Invoke 阶段注册，先于配置源启动，因此首次下发的配置不会遗漏
+func bindSources(p sourcesParams) {
+	p.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			return p.Manager.StartSources(ctx, p.Sources...)
+		},
+		OnStop: func(ctx context.Context) error {
+			return p.Manager.Close(ctx)
+		},
+	})
+}
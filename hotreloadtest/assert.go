@@ -0,0 +1,99 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreloadtest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// AssertOutcome 断言分发结果
+func AssertOutcome(tb testing.TB, result hotreload.ChangeResult, want hotreload.ChangeOutcome) {
+	tb.Helper()
+	if result.Outcome != want {
+		tb.Errorf("outcome = %s, want %s (error: %v)", result.Outcome, want, result.Err)
+	}
+}
+
+// AssertHandlers 断言成功执行的处理器及其顺序
+func AssertHandlers(tb testing.TB, result hotreload.ChangeResult, want ...string) {
+	tb.Helper()
+	if !slices.Equal(result.Handlers, want) {
+		tb.Errorf("handlers = %v, want %v", result.Handlers, want)
+	}
+}
+
+// AssertErrorKind 断言错误分类（见 hotreload.ErrorKindOf）
+func AssertErrorKind(tb testing.TB, err error, want hotreload.ErrorKind) {
+	tb.Helper()
+	if got := hotreload.ErrorKindOf(err); got != want {
+		tb.Errorf("error kind = %q, want %q (error: %v)", got, want, err)
+	}
+}
+
+// AssertErrorIs 断言错误链中包含 target
+func AssertErrorIs(tb testing.TB, err, target error) {
+	tb.Helper()
+	if !errors.Is(err, target) {
+		tb.Errorf("error = %v, want %v", err, target)
+	}
+}
+
+// AssertDispatchOrder 断言记录器中配置键的分发顺序
+func AssertDispatchOrder(tb testing.TB, r *Recorder, want ...string) {
+	tb.Helper()
+	if got := r.Keys(); !slices.Equal(got, want) {
+		tb.Errorf("dispatch order = %v, want %v", got, want)
+	}
+}
+
+// AssertCalls 断言重载器成功处理的配置键及其顺序
+func AssertCalls(tb testing.TB, r *RecordingReloader, want ...string) {
+	tb.Helper()
+	calls := r.Calls()
+	got := make([]string, 0, len(calls))
+	for _, call := range calls {
+		got = append(got, call.Key)
+	}
+	if !slices.Equal(got, want) {
+		tb.Errorf("reloader calls = %v, want %v", got, want)
+	}
+}
+
+// AssertApplied 断言配置键最近一次成功应用的值
+func AssertApplied(tb testing.TB, m *hotreload.Manager, key, want string) {
+	tb.Helper()
+	applied, ok := m.LastApplied(key)
+	if !ok {
+		tb.Errorf("key %s not applied, want %q", key, want)
+		return
+	}
+	if applied.Value != want {
+		tb.Errorf("key %s applied value = %q, want %q", key, applied.Value, want)
+	}
+}
+
+// AssertNotApplied 断言配置键从未成功应用
+func AssertNotApplied(tb testing.TB, m *hotreload.Manager, key string) {
+	tb.Helper()
+	if applied, ok := m.LastApplied(key); ok {
+		tb.Errorf("key %s applied value = %q, want not applied", key, applied.Value)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreloadtest
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// SourceTest 测试工具投递的配置变更来源
+const SourceTest = "test"
+
+// FakeSource 内存配置源，实现 hotreload.Adapter、hotreload.Source 与 hotreload.StateFetcher
+// 通过 Set/Delete 模拟配置中心推送，变更同步投递给绑定的管理器；通过 Drift 模拟遗漏的监听事件
+type FakeSource struct {
+	name string
+
+	mu       sync.Mutex
+	sink     hotreload.ChangeSink
+	values   map[string]string
+	patterns []string
+	started  bool
+	revision int
+}
+
+var (
+	_ hotreload.Adapter = (*FakeSource)(nil)
+	_ hotreload.Source  = (*FakeSource)(nil)
+
+	_ hotreload.StateFetcher = (*FakeSource)(nil)
+
+	_ hotreload.DeleteAwareReloader = (*RecordingReloader)(nil)
+)
+
+// NewFakeSource 创建内存配置源，name 为空时使用 "test"
+func NewFakeSource(name string) *FakeSource {
+	if name == "" {
+		name = SourceTest
+	}
+	return &FakeSource{name: name, values: make(map[string]string)}
+}
+
+// Name 返回配置源名称
+func (s *FakeSource) Name() string {
+	return s.name
+}
+
+// Attach 绑定变更接收端
+func (s *FakeSource) Attach(sink hotreload.ChangeSink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sink = sink
+	return nil
+}
+
+// Subscribe 记录订阅的配置键模式
+func (s *FakeSource) Subscribe(patterns []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.patterns = slices.Clone(patterns)
+	return nil
+}
+
+// Patterns 返回最近一次订阅的配置键模式
+func (s *FakeSource) Patterns() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.patterns)
+}
+
+// Start 标记配置源已启动，并上报已连接
+func (s *FakeSource) Start(ctx context.Context) error {
+	s.mu.Lock()
+	s.started = true
+	sink := s.sink
+	s.mu.Unlock()
+	if sink != nil {
+		sink.ReportSourceStatus(s.name, true)
+	}
+	return nil
+}
+
+// Stop 标记配置源已停止，并上报已断开
+func (s *FakeSource) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.started = false
+	sink := s.sink
+	s.mu.Unlock()
+	if sink != nil {
+		sink.ReportSourceStatus(s.name, false)
+	}
+	return nil
+}
+
+// Started 返回配置源是否已启动
+func (s *FakeSource) Started() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
+
+// Set 设置配置值并投递变更（旧值为该配置源中的上一个值），返回分发错误
+func (s *FakeSource) Set(ctx context.Context, key, value string) error {
+	s.mu.Lock()
+	old := s.values[key]
+	s.values[key] = value
+	s.revision++
+	change := hotreload.Change{
+		Key:            key,
+		OldValue:       old,
+		NewValue:       value,
+		Source:         s.name,
+		SourceRevision: strconv.Itoa(s.revision),
+		Sequence:       uint64(s.revision),
+	}
+	sink := s.sink
+	s.mu.Unlock()
+
+	if sink == nil {
+		return nil
+	}
+	return sink.Apply(ctx, change)
+}
+
+// Delete 删除配置值并投递删除事件（Change.Deleted），返回分发错误
+func (s *FakeSource) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	old, ok := s.values[key]
+	delete(s.values, key)
+	sink := s.sink
+	s.mu.Unlock()
+
+	if !ok || sink == nil {
+		return nil
+	}
+	return sink.Apply(ctx, hotreload.Change{Key: key, OldValue: old, Deleted: true, Source: s.name})
+}
+
+// Drift 修改配置值但不投递变更，模拟遗漏的监听事件（value 为空时删除配置键）
+// 可据此验证 Reconcile 能否修正漂移
+func (s *FakeSource) Drift(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if value == "" {
+		delete(s.values, key)
+		return
+	}
+	s.values[key] = value
+}
+
+// Fetch 返回配置源当前的全部配置
+func (s *FakeSource) Fetch(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	values := make(map[string]string, len(s.values))
+	for key, value := range s.values {
+		values[key] = value
+	}
+	return values, nil
+}
+
+// Call 重载器收到的一次调用
+type Call struct {
+	Key      string
+	OldValue string
+	NewValue string
+	// 删除事件（OnDelete）
+	Deleted bool
+}
+
+// RecordingReloader 记录所有调用的重载器，可注入验证与处理错误
+type RecordingReloader struct {
+	patterns []string
+
+	mu          sync.Mutex
+	calls       []Call
+	validations []Call
+	validateErr func(key, value string) error
+	changeErr   func(key, oldValue, newValue string) error
+}
+
+// NewRecordingReloader 创建记录调用的重载器
+func NewRecordingReloader(patterns ...string) *RecordingReloader {
+	return &RecordingReloader{patterns: patterns}
+}
+
+// FailValidation 设置验证函数，返回非 nil 错误时验证失败
+func (r *RecordingReloader) FailValidation(fn func(key, value string) error) *RecordingReloader {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.validateErr = fn
+	return r
+}
+
+// FailChange 设置处理函数，返回非 nil 错误时处理失败
+func (r *RecordingReloader) FailChange(fn func(key, oldValue, newValue string) error) *RecordingReloader {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changeErr = fn
+	return r
+}
+
+// Patterns 返回配置键模式列表
+func (r *RecordingReloader) Patterns() []string {
+	return r.patterns
+}
+
+// Validate 记录验证调用
+func (r *RecordingReloader) Validate(key, value string) error {
+	r.mu.Lock()
+	r.validations = append(r.validations, Call{Key: key, NewValue: value})
+	fn := r.validateErr
+	r.mu.Unlock()
+	if fn != nil {
+		return fn(key, value)
+	}
+	return nil
+}
+
+// OnChange 记录变更调用
+func (r *RecordingReloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	fn := r.changeErr
+	r.mu.Unlock()
+	if fn != nil {
+		if err := fn(key, oldValue, newValue); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	r.calls = append(r.calls, Call{Key: key, OldValue: oldValue, NewValue: newValue})
+	r.mu.Unlock()
+	return nil
+}
+
+// OnDelete 记录删除调用（Call.Deleted 为 true），FailChange 设置的处理函数以空新值调用
+func (r *RecordingReloader) OnDelete(key, oldValue string) error {
+	r.mu.Lock()
+	fn := r.changeErr
+	r.mu.Unlock()
+	if fn != nil {
+		if err := fn(key, oldValue, ""); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	r.calls = append(r.calls, Call{Key: key, OldValue: oldValue, Deleted: true})
+	r.mu.Unlock()
+	return nil
+}
+
+// Calls 返回成功处理的变更调用（按调用顺序）
+func (r *RecordingReloader) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.calls)
+}
+
+// Validations 返回验证调用（按调用顺序）
+func (r *RecordingReloader) Validations() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.validations)
+}
+
+// Reset 清空调用记录
+func (r *RecordingReloader) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+	r.validations = nil
+}
+
+// RecordingFieldSetter 记录所有调用的字段设置器
+type RecordingFieldSetter struct {
+	mu    sync.Mutex
+	calls []FieldCall
+}
+
+// FieldCall 字段设置器收到的一次调用
+type FieldCall struct {
+	Module    string
+	FieldPath string
+	Value     string
+}
+
+// Set 实现 hotreload.FieldSetter
+func (s *RecordingFieldSetter) Set(module, fieldPath, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls = append(s.calls, FieldCall{Module: module, FieldPath: fieldPath, Value: value})
+	return nil
+}
+
+// Calls 返回字段设置调用（按调用顺序）
+func (s *RecordingFieldSetter) Calls() []FieldCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.calls)
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package hotreloadtest 提供测试重载器与字段设置器的辅助工具，无需启动配置中心
+//
+//	m := hotreload.NewManager()
+//	r := hotreloadtest.NewRecordingReloader("server.*")
+//	_ = m.RegisterReloader(r)
+//
+//	result := hotreloadtest.TriggerAndWait(t, m, "server.port", "8080")
+//	hotreloadtest.AssertOutcome(t, result, hotreload.OutcomeApplied)
+//	hotreloadtest.AssertCalls(t, r, "server.port")
+package hotreloadtest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// DefaultWaitTimeout TriggerAndWait 等待分发完成的默认超时时间
+const DefaultWaitTimeout = 5 * time.Second
+
+// Dispatch 一次配置变更分发的记录
+type Dispatch struct {
+	Change hotreload.Change
+	Result hotreload.ChangeResult
+}
+
+// Recorder 通过全局后置钩子记录管理器的所有配置变更分发（按完成顺序）
+type Recorder struct {
+	mu         sync.Mutex
+	dispatches []Dispatch
+	// 等待指定配置键分发完成的通道
+	waiters map[string][]chan Dispatch
+}
+
+// NewRecorder 创建分发记录器并注册到管理器
+func NewRecorder(m *hotreload.Manager) *Recorder {
+	r := &Recorder{waiters: make(map[string][]chan Dispatch)}
+	m.OnAfterChange(func(ctx context.Context, change hotreload.Change, result hotreload.ChangeResult) {
+		r.record(Dispatch{Change: change, Result: result})
+	})
+	return r
+}
+
+// record 记录一次分发并唤醒等待者
+func (r *Recorder) record(d Dispatch) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dispatches = append(r.dispatches, d)
+	for _, ch := range r.waiters[d.Change.Key] {
+		ch <- d
+	}
+	delete(r.waiters, d.Change.Key)
+}
+
+// Dispatches 返回已记录的分发（按完成顺序）
+func (r *Recorder) Dispatches() []Dispatch {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.dispatches)
+}
+
+// Keys 返回已记录分发的配置键（按完成顺序）
+func (r *Recorder) Keys() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	keys := make([]string, 0, len(r.dispatches))
+	for _, d := range r.dispatches {
+		keys = append(keys, d.Change.Key)
+	}
+	return keys
+}
+
+// Reset 清空已记录的分发
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dispatches = nil
+}
+
+// wait 注册等待指定配置键的下一次分发完成
+func (r *Recorder) wait(key string) <-chan Dispatch {
+	ch := make(chan Dispatch, 1)
+	r.mu.Lock()
+	r.waiters[key] = append(r.waiters[key], ch)
+	r.mu.Unlock()
+	return ch
+}
+
+// TriggerAndWait 投递配置变更并等待其分发完成，返回分发结果
+// 暂停期间被暂存的变更会一直等待到超时，超时视为测试失败
+func (r *Recorder) TriggerAndWait(tb testing.TB, m *hotreload.Manager, change hotreload.Change) hotreload.ChangeResult {
+	tb.Helper()
+
+	done := r.wait(change.Key)
+	// 同步分发的结果由钩子写入 done，此处的错误已包含在结果中
+	_ = m.Apply(context.Background(), change)
+
+	select {
+	case d := <-done:
+		return d.Result
+	case <-time.After(DefaultWaitTimeout):
+		tb.Fatalf("timed out waiting for dispatch of key %s", change.Key)
+		return hotreload.ChangeResult{}
+	}
+}
+
+// recorders 按管理器缓存 TriggerAndWait 使用的记录器
+var recorders sync.Map
+
+// recorderFor 返回管理器对应的记录器，首次使用时创建
+func recorderFor(m *hotreload.Manager) *Recorder {
+	if r, ok := recorders.Load(m); ok {
+		return r.(*Recorder)
+	}
+	r, _ := recorders.LoadOrStore(m, NewRecorder(m))
+	return r.(*Recorder)
+}
+
+// TriggerAndWait 以 "test" 为来源投递配置变更并等待其分发完成，返回分发结果
+// 旧值取该配置键最近一次成功应用的值
+func TriggerAndWait(tb testing.TB, m *hotreload.Manager, key, newValue string) hotreload.ChangeResult {
+	tb.Helper()
+
+	applied, _ := m.LastApplied(key)
+	return recorderFor(m).TriggerAndWait(tb, m, hotreload.Change{
+		Key:      key,
+		OldValue: applied.Value,
+		NewValue: newValue,
+		Source:   SourceTest,
+	})
+}
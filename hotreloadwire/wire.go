@@ -0,0 +1,63 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package hotreloadwire 提供热加载管理器的 wire 提供者集合
+//
+// wire 不支持值组，应用需自行提供 Options、Reloaders 与 Sources 三个切片类型：
+//
+//	func provideReloaders(rl *ratelimit.Reloader, cb *breaker.Reloader) hotreloadwire.Reloaders {
+//		return hotreloadwire.Reloaders{rl, cb}
+//	}
+//
+//	wire.Build(hotreloadwire.ProviderSet, provideOptions, provideReloaders, provideSources)
+//
+// ProvideManager 注册所有重载器后启动配置源，wire 生成的 cleanup 函数会停止配置源
+package hotreloadwire
+
+import (
+	"context"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/google/wire"
+)
+
+// Options 管理器选项
+type Options []hotreload.Option
+
+// Reloaders 需要注册的重载器
+type Reloaders []hotreload.Reloader
+
+// Sources 需要启动的配置源
+type Sources []hotreload.Source
+
+// ProviderSet 热加载管理器提供者集合
+var ProviderSet = wire.NewSet(ProvideManager)
+
+// ProvideManager 构造管理器、注册重载器并启动配置源，返回的 cleanup 函数关闭管理器（按相反顺序停止配置源）
+func ProvideManager(opts Options, reloaders Reloaders, sources Sources) (*hotreload.Manager, func(), error) {
+	m := hotreload.NewManager(opts...)
+	if err := m.RegisterReloaders(reloaders...); err != nil {
+		return nil, nil, err
+	}
+	if err := m.StartSources(context.Background(), sources...); err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		// wire 的 cleanup 无法返回错误，停止失败由配置源自行记录
+		_ = m.Close(context.Background())
+	}
+	return m, cleanup, nil
+}
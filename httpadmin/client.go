@@ -0,0 +1,336 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package httpadmin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// Client 管理接口客户端
+type Client struct {
+	baseURL string
+	client  *http.Client
+
+	// 请求前的钩子（如设置鉴权请求头）
+	beforeRequest func(req *http.Request)
+}
+
+// ClientOption 客户端配置选项
+type ClientOption func(*Client)
+
+// WithHTTPClient 设置 HTTP 客户端
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// WithRequestHook 设置请求前的钩子（如设置鉴权请求头）
+func WithRequestHook(hook func(req *http.Request)) ClientOption {
+	return func(c *Client) {
+		c.beforeRequest = hook
+	}
+}
+
+// NewClient 创建管理接口客户端
+// baseURL 为管理接口的挂载地址，如 "http://127.0.0.1:8080/admin/hotreload"
+func NewClient(baseURL string, opts ...ClientOption) *Client {
+	c := &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// State 查询运行状态
+func (c *Client) State(ctx context.Context) (*State, error) {
+	var state State
+	if err := c.do(ctx, http.MethodGet, "/state", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Reloaders 查询已注册的重载器
+func (c *Client) Reloaders(ctx context.Context) ([]hotreload.ReloaderInfo, error) {
+	var reloaders []hotreload.ReloaderInfo
+	if err := c.do(ctx, http.MethodGet, "/reloaders", nil, &reloaders); err != nil {
+		return nil, err
+	}
+	return reloaders, nil
+}
+
+// Registrations 查询所有已注册的配置键模式及其处理器
+func (c *Client) Registrations(ctx context.Context) ([]hotreload.Registration, error) {
+	var regs []hotreload.Registration
+	if err := c.do(ctx, http.MethodGet, "/registrations", nil, &regs); err != nil {
+		return nil, err
+	}
+	return regs, nil
+}
+
+// History 查询变更历史
+func (c *Client) History(ctx context.Context, limit int) ([]hotreload.HistoryEntry, error) {
+	var entries []hotreload.HistoryEntry
+	path := "/history?limit=" + strconv.Itoa(limit)
+	if err := c.do(ctx, http.MethodGet, path, nil, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Stats 查询按模式与重载器统计的调用情况
+func (c *Client) Stats(ctx context.Context) ([]hotreload.PatternStats, error) {
+	var stats []hotreload.PatternStats
+	if err := c.do(ctx, http.MethodGet, "/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// ReloaderStats 查询按重载器统计的调用情况
+func (c *Client) ReloaderStats(ctx context.Context) ([]hotreload.ReloaderStats, error) {
+	var stats []hotreload.ReloaderStats
+	if err := c.do(ctx, http.MethodGet, "/reloaders/stats", nil, &stats); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// LastApplied 查询配置键最近一次成功应用的信息
+func (c *Client) LastApplied(ctx context.Context, key string) (*hotreload.AppliedValue, error) {
+	var applied hotreload.AppliedValue
+	if err := c.do(ctx, http.MethodGet, "/applied/"+url.PathEscape(key), nil, &applied); err != nil {
+		return nil, err
+	}
+	return &applied, nil
+}
+
+// EffectiveAll 查询所有配置键的生效值及其来源层
+func (c *Client) EffectiveAll(ctx context.Context) ([]hotreload.EffectiveValue, error) {
+	var values []hotreload.EffectiveValue
+	if err := c.do(ctx, http.MethodGet, "/effective", nil, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Effective 查询配置键的生效值及其来源层
+func (c *Client) Effective(ctx context.Context, key string) (*hotreload.EffectiveValue, error) {
+	var value hotreload.EffectiveValue
+	if err := c.do(ctx, http.MethodGet, "/effective/"+url.PathEscape(key), nil, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// TriggerChange 手动触发配置变更
+func (c *Client) TriggerChange(ctx context.Context, req ChangeRequest) (*ChangeResponse, error) {
+	var resp ChangeResponse
+	if err := c.do(ctx, http.MethodPost, "/changes", req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DryRun 预演配置变更
+func (c *Client) DryRun(ctx context.Context, req DryRunRequest) (*hotreload.DryRunResult, error) {
+	var result hotreload.DryRunResult
+	if err := c.do(ctx, http.MethodPost, "/dry-run", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Rollback 回滚指定修订号的变更
+func (c *Client) Rollback(ctx context.Context, revision uint64) (*ChangeResponse, error) {
+	var resp ChangeResponse
+	if err := c.do(ctx, http.MethodPost, "/rollback", RollbackRequest{Revision: revision}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Pause 暂停配置变更分发
+func (c *Client) Pause(ctx context.Context) (*State, error) {
+	var state State
+	if err := c.do(ctx, http.MethodPost, "/pause", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Resume 恢复配置变更分发
+func (c *Client) Resume(ctx context.Context) (*State, error) {
+	var state State
+	if err := c.do(ctx, http.MethodPost, "/resume", nil, &state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// Frozen 查询因抖动被冻结的配置键
+func (c *Client) Frozen(ctx context.Context) ([]hotreload.FrozenKey, error) {
+	var frozen []hotreload.FrozenKey
+	if err := c.do(ctx, http.MethodGet, "/frozen", nil, &frozen); err != nil {
+		return nil, err
+	}
+	return frozen, nil
+}
+
+// Unfreeze 解除配置键的冻结，返回仍被冻结的配置键
+func (c *Client) Unfreeze(ctx context.Context, key string) ([]hotreload.FrozenKey, error) {
+	var frozen []hotreload.FrozenKey
+	if err := c.do(ctx, http.MethodPost, "/unfreeze", UnfreezeRequest{Key: key}, &frozen); err != nil {
+		return nil, err
+	}
+	return frozen, nil
+}
+
+// Reload 以当前值强制重新应用前缀下的配置键（prefix 为空时为所有配置键）
+func (c *Client) Reload(ctx context.Context, prefix string) (*ReloadResponse, error) {
+	var resp ReloadResponse
+	if err := c.do(ctx, http.MethodPost, "/reload", ReloadRequest{Prefix: prefix}, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// TailEvents 订阅热加载事件流，每收到一个事件调用一次 fn
+// 阻塞直到 ctx 取消、连接断开或 fn 返回错误
+func (c *Client) TailEvents(ctx context.Context, pattern string, fn func(hotreload.Event) error) error {
+	path := "/events"
+	if pattern != "" {
+		path += "?pattern=" + url.QueryEscape(pattern)
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect event stream: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxBodySize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+
+		var event hotreload.Event
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return fmt.Errorf("failed to decode event: %w", err)
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return scanner.Err()
+}
+
+// newRequest 创建请求
+func (c *Client) newRequest(ctx context.Context, method, path string, body any) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.beforeRequest != nil {
+		c.beforeRequest(req)
+	}
+	return req, nil
+}
+
+// do 发送请求并解析 JSON 响应
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	req, err := c.newRequest(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return readError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// readError 从错误响应中读取错误信息
+func readError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+
+	var e errorResponse
+	if err := json.Unmarshal(data, &e); err == nil && e.Error != "" {
+		return fmt.Errorf("admin api returned status %d: %s", resp.StatusCode, e.Error)
+	}
+	return fmt.Errorf("admin api returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+}
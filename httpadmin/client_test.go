@@ -0,0 +1,104 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package httpadmin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// newTestServer 启动要求 "Bearer ops" 令牌的管理接口服务
+func newTestServer(t *testing.T, manager *hotreload.Manager) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(Handler(manager, WithAuth(func(r *http.Request) (string, error) {
+		if r.Header.Get("Authorization") != "Bearer ops" {
+			return "", errors.New("invalid token")
+		}
+		return "ops", nil
+	})))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClientRoundTrip(t *testing.T) {
+	manager := newTestManager(t)
+	server := newTestServer(t, manager)
+	client := NewClient(server.URL+"/", WithRequestHook(func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer ops")
+	}))
+
+	ctx := context.Background()
+	resp, err := client.TriggerChange(ctx, ChangeRequest{Key: "server.port", NewValue: "8080"})
+	if err != nil {
+		t.Fatalf("TriggerChange() error = %v", err)
+	}
+	if resp.Outcome != hotreload.OutcomeApplied || resp.Revision == 0 || len(resp.Handlers) != 1 {
+		t.Fatalf("TriggerChange() = %+v, want applied by one handler", resp)
+	}
+
+	applied, err := client.LastApplied(ctx, "server.port")
+	if err != nil {
+		t.Fatalf("LastApplied() error = %v", err)
+	}
+	if applied.Value != "8080" || applied.Actor != "ops" {
+		t.Fatalf("LastApplied() = %+v, want 8080 by ops", applied)
+	}
+
+	state, err := client.Pause(ctx)
+	if err != nil {
+		t.Fatalf("Pause() error = %v", err)
+	}
+	if !state.Paused {
+		t.Fatalf("Pause() state = %+v, want paused", state)
+	}
+	if state, err = client.Resume(ctx); err != nil || state.Paused {
+		t.Fatalf("Resume() = %+v, %v, want resumed", state, err)
+	}
+
+	rollback, err := client.Rollback(ctx, resp.Revision)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if rollback.Outcome != hotreload.OutcomeApplied {
+		t.Fatalf("Rollback() = %+v, want applied", rollback)
+	}
+}
+
+func TestClientReturnsServerError(t *testing.T) {
+	manager := newTestManager(t)
+	server := newTestServer(t, manager)
+	ctx := context.Background()
+
+	_, err := NewClient(server.URL).State(ctx)
+	if err == nil || !strings.Contains(err.Error(), "status 401: invalid token") {
+		t.Fatalf("State() without token error = %v, want status 401: invalid token", err)
+	}
+
+	client := NewClient(server.URL, WithRequestHook(func(req *http.Request) {
+		req.Header.Set("Authorization", "Bearer ops")
+	}))
+	_, err = client.TriggerChange(ctx, ChangeRequest{Key: "server.port", NewValue: "invalid"})
+	if err == nil || !strings.Contains(err.Error(), "status 422") || !strings.Contains(err.Error(), "invalid value") {
+		t.Fatalf("TriggerChange() error = %v, want status 422 with handler error", err)
+	}
+}
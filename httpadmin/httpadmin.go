@@ -0,0 +1,464 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package httpadmin 提供热加载管理器的 HTTP 管理接口
+// 接口以可挂载的 http.Handler 形式提供，鉴权通过中间件钩子接入（如 SSO、Token 校验）
+//
+// 路由（相对于挂载点）：
+//
+//	GET  /state            查询运行状态（修订号、暂停状态、健康状态等）
+//	GET  /reloaders        查询已注册的重载器
+//	GET  /registrations    查询所有已注册的配置键模式及其处理器、注册位置
+//	GET  /history          查询变更历史（?limit=N）
+//	GET  /stats            查询按模式与重载器统计的调用耗时与结果
+//	GET  /reloaders/stats  查询按重载器统计的调用次数、最近错误与隔离状态
+//	GET  /applied/{key}    查询配置键最近一次成功应用的值、修订号与执行的处理器
+//	GET  /effective        查询所有配置键的生效值及其来源层
+//	GET  /effective/{key}  查询配置键的生效值及其来源层（默认值、配置源、人工覆盖或快照回退）
+//	GET  /health           健康检查（降级时返回 503）
+//	GET  /events           以 Server-Sent Events 推送热加载事件（?pattern=...）
+//	POST /changes          手动触发配置变更 {"key","old_value","new_value","deleted"}
+//	POST /dry-run          预演配置变更 {"key","value"}
+//	POST /rollback         回滚指定修订号 {"revision"}
+//	POST /pause            暂停配置变更分发
+//	POST /resume           恢复配置变更分发
+//	GET  /frozen           查询因抖动被冻结的配置键
+//	POST /unfreeze         解除配置键的冻结 {"key"}
+//	POST /reload           以当前值强制重新应用配置键 {"prefix"}
+package httpadmin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// Middleware HTTP 中间件，用于接入鉴权等横切逻辑
+type Middleware func(http.Handler) http.Handler
+
+// Option 管理接口配置选项
+type Option func(*options)
+
+// options 管理接口配置
+type options struct {
+	middlewares []Middleware
+}
+
+// WithMiddleware 添加中间件（如鉴权中间件），按添加顺序由外向内执行
+func WithMiddleware(mw Middleware) Option {
+	return func(o *options) {
+		if mw != nil {
+			o.middlewares = append(o.middlewares, mw)
+		}
+	}
+}
+
+// WithAuth 添加鉴权钩子
+// authorize 返回调用方身份（如 SSO 用户名、Token 主体），返回错误时以 401 拒绝请求；
+// 调用方身份会通过 hotreload.ContextWithActor 写入请求 context，随配置变更记录到审计事件与日志中
+func WithAuth(authorize func(r *http.Request) (principal string, err error)) Option {
+	return WithMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authorize(r)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+			if principal != "" {
+				r = r.WithContext(hotreload.ContextWithActor(r.Context(), principal))
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+}
+
+// Handler 创建管理接口处理器
+// 可通过 http.StripPrefix 挂载到任意路径下，如：
+//
+//	mux.Handle("/admin/hotreload/", http.StripPrefix("/admin/hotreload", httpadmin.Handler(m)))
+func Handler(manager *hotreload.Manager, opts ...Option) http.Handler {
+	o := &options{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	a := &admin{manager: manager}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /state", a.state)
+	mux.HandleFunc("GET /reloaders", a.reloaders)
+	mux.HandleFunc("GET /registrations", a.registrations)
+	mux.HandleFunc("GET /history", a.history)
+	mux.HandleFunc("GET /stats", a.stats)
+	mux.HandleFunc("GET /reloaders/stats", a.reloaderStats)
+	mux.HandleFunc("GET /applied/{key}", a.lastApplied)
+	mux.HandleFunc("GET /effective", a.effectiveAll)
+	mux.HandleFunc("GET /effective/{key}", a.effective)
+	mux.HandleFunc("GET /health", a.health)
+	mux.Handle("GET /events", manager.EventStreamHandler())
+	mux.HandleFunc("POST /changes", a.triggerChange)
+	mux.HandleFunc("POST /dry-run", a.dryRun)
+	mux.HandleFunc("POST /rollback", a.rollback)
+	mux.HandleFunc("POST /pause", a.pause)
+	mux.HandleFunc("POST /resume", a.resume)
+	mux.HandleFunc("GET /frozen", a.frozen)
+	mux.HandleFunc("POST /unfreeze", a.unfreeze)
+	mux.HandleFunc("POST /reload", a.reload)
+
+	var handler http.Handler = mux
+	for i := len(o.middlewares) - 1; i >= 0; i-- {
+		handler = o.middlewares[i](handler)
+	}
+	return handler
+}
+
+// admin 管理接口实现
+type admin struct {
+	manager *hotreload.Manager
+}
+
+// State 运行状态
+type State struct {
+	Revision       uint64           `json:"revision"`
+	LastAppliedAt  *time.Time       `json:"last_applied_at,omitempty"`
+	Paused         bool             `json:"paused"`
+	PendingChanges int              `json:"pending_changes"`
+	Health         hotreload.Health `json:"health"`
+}
+
+// ChangeRequest 手动触发配置变更请求
+type ChangeRequest struct {
+	Key      string `json:"key"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+	// 删除配置键（忽略 NewValue）
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// ChangeResponse 手动触发配置变更响应
+type ChangeResponse struct {
+	Revision uint64 `json:"revision"`
+	// 暂停期间变更会被暂存，此时 Deferred 为 true
+	Deferred bool `json:"deferred,omitempty"`
+	// 处理结果（applied、unmatched、deferred 等）
+	Outcome hotreload.ChangeOutcome `json:"outcome,omitempty"`
+	// 每个匹配处理器的执行明细
+	Handlers []HandlerResult `json:"handlers,omitempty"`
+}
+
+// HandlerResult 单个匹配处理器的执行明细
+type HandlerResult struct {
+	Pattern  string                  `json:"pattern"`
+	Name     string                  `json:"name"`
+	Mode     hotreload.ExecutionMode `json:"mode"`
+	Status   hotreload.HandlerStatus `json:"status"`
+	Duration time.Duration           `json:"duration"`
+	Error    string                  `json:"error,omitempty"`
+}
+
+// DryRunRequest 预演配置变更请求
+type DryRunRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RollbackRequest 回滚请求
+type RollbackRequest struct {
+	Revision uint64 `json:"revision"`
+}
+
+// UnfreezeRequest 解除冻结请求
+type UnfreezeRequest struct {
+	Key string `json:"key"`
+}
+
+// ReloadRequest 强制重新应用请求
+type ReloadRequest struct {
+	// 配置键前缀（为空表示所有配置键）
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// ReloadResponse 强制重新应用响应
+type ReloadResponse struct {
+	// 重新分发的配置键数
+	Reloaded int    `json:"reloaded"`
+	Revision uint64 `json:"revision"`
+}
+
+// state 查询运行状态
+func (a *admin) state(w http.ResponseWriter, r *http.Request) {
+	state := State{
+		Revision:       a.manager.Revision(),
+		Paused:         a.manager.Paused(),
+		PendingChanges: a.manager.PendingChanges(),
+		Health:         a.manager.HealthCheck(),
+	}
+	if t := a.manager.LastAppliedAt(); !t.IsZero() {
+		state.LastAppliedAt = &t
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+// reloaders 查询已注册的重载器
+func (a *admin) reloaders(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.manager.ListReloaders())
+}
+
+// registrations 查询所有已注册的配置键模式
+func (a *admin) registrations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.manager.Registrations())
+}
+
+// history 查询变更历史
+func (a *admin) history(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid limit: "+v)
+			return
+		}
+		limit = n
+	}
+	writeJSON(w, http.StatusOK, a.manager.History(limit))
+}
+
+// stats 查询按模式与重载器统计的调用情况
+func (a *admin) stats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.manager.PatternStats())
+}
+
+// reloaderStats 查询按重载器统计的调用情况
+func (a *admin) reloaderStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.manager.Stats())
+}
+
+// lastApplied 查询配置键最近一次成功应用的信息
+func (a *admin) lastApplied(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	applied, ok := a.manager.LastApplied(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, "key not applied: "+key)
+		return
+	}
+	writeJSON(w, http.StatusOK, applied)
+}
+
+// effectiveAll 查询所有配置键的生效值
+func (a *admin) effectiveAll(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.manager.EffectiveAll())
+}
+
+// effective 查询配置键的生效值
+func (a *admin) effective(w http.ResponseWriter, r *http.Request) {
+	key := r.PathValue("key")
+	effective, ok := a.manager.Effective(key)
+	if !ok {
+		writeError(w, http.StatusNotFound, "key has no effective value: "+key)
+		return
+	}
+	writeJSON(w, http.StatusOK, effective)
+}
+
+// health 健康检查
+func (a *admin) health(w http.ResponseWriter, r *http.Request) {
+	health := a.manager.HealthCheck()
+	status := http.StatusOK
+	if health.Status != hotreload.HealthStatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, health)
+}
+
+// triggerChange 手动触发配置变更
+func (a *admin) triggerChange(w http.ResponseWriter, r *http.Request) {
+	var req ChangeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Key == "" {
+		writeError(w, http.StatusBadRequest, "key is empty")
+		return
+	}
+
+	result, err := a.manager.ApplyWithReport(r.Context(), hotreload.Change{
+		Key:      req.Key,
+		OldValue: req.OldValue,
+		NewValue: req.NewValue,
+		Deleted:  req.Deleted,
+		Source:   hotreload.SourceAdminHTTP,
+	})
+	if err != nil {
+		writeError(w, changeErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, changeResponse(result))
+}
+
+// changeResponse 将配置变更的处理结果转换为响应（修订号为该变更的修订号，未应用时为 0）
+func changeResponse(result hotreload.ChangeResult) ChangeResponse {
+	handlers := make([]HandlerResult, 0, len(result.Reports))
+	for _, report := range result.Reports {
+		handler := HandlerResult{
+			Pattern:  report.Pattern,
+			Name:     report.Name,
+			Mode:     report.Mode,
+			Status:   report.Status,
+			Duration: report.Duration,
+		}
+		if report.Err != nil {
+			handler.Error = report.Err.Error()
+		}
+		handlers = append(handlers, handler)
+	}
+	return ChangeResponse{
+		Revision: result.Revision,
+		Deferred: result.Outcome == hotreload.OutcomeDeferred,
+		Outcome:  result.Outcome,
+		Handlers: handlers,
+	}
+}
+
+// dryRun 预演配置变更
+func (a *admin) dryRun(w http.ResponseWriter, r *http.Request) {
+	var req DryRunRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Key == "" {
+		writeError(w, http.StatusBadRequest, "key is empty")
+		return
+	}
+	writeJSON(w, http.StatusOK, a.manager.DryRun(req.Key, req.Value))
+}
+
+// rollback 回滚指定修订号的变更
+func (a *admin) rollback(w http.ResponseWriter, r *http.Request) {
+	var req RollbackRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Revision == 0 {
+		writeError(w, http.StatusBadRequest, "revision is empty")
+		return
+	}
+
+	result, err := a.manager.RollbackWithReport(r.Context(), req.Revision)
+	if err != nil {
+		writeError(w, changeErrorStatus(err), err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, changeResponse(result))
+}
+
+// pause 暂停配置变更分发
+func (a *admin) pause(w http.ResponseWriter, r *http.Request) {
+	a.manager.Pause()
+	a.state(w, r)
+}
+
+// resume 恢复配置变更分发
+func (a *admin) resume(w http.ResponseWriter, r *http.Request) {
+	if err := a.manager.Resume(); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	a.state(w, r)
+}
+
+// frozen 查询因抖动被冻结的配置键
+func (a *admin) frozen(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, a.manager.FrozenKeys())
+}
+
+// unfreeze 解除配置键的冻结，返回仍被冻结的配置键
+func (a *admin) unfreeze(w http.ResponseWriter, r *http.Request) {
+	var req UnfreezeRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.Key == "" {
+		writeError(w, http.StatusBadRequest, "key is empty")
+		return
+	}
+
+	if err := a.manager.Unfreeze(req.Key); err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	a.frozen(w, r)
+}
+
+// reload 以当前值强制重新应用配置键
+func (a *admin) reload(w http.ResponseWriter, r *http.Request) {
+	var req ReloadRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	reloaded, err := a.manager.ReloadPrefix(r.Context(), req.Prefix)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, ReloadResponse{
+		Reloaded: reloaded,
+		Revision: a.manager.Revision(),
+	})
+}
+
+// changeErrorStatus 返回配置变更错误对应的 HTTP 状态码（被授权策略拒绝时为 403）
+func changeErrorStatus(err error) int {
+	if errors.Is(err, hotreload.ErrUnauthorized) {
+		return http.StatusForbidden
+	}
+	return http.StatusUnprocessableEntity
+}
+
+// errorResponse 错误响应
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// maxBodySize 请求体大小上限
+const maxBodySize = 1 << 20
+
+// decodeJSON 解析 JSON 请求体，失败时写出 400 响应并返回 false
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeJSON 写出 JSON 响应
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError 写出错误响应
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package httpadmin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// newTestManager 创建为 server.* 注册了处理器的管理器，新值为 "invalid" 时处理器返回错误
+func newTestManager(t *testing.T, opts ...hotreload.Option) *hotreload.Manager {
+	t.Helper()
+	manager := hotreload.NewManager(append([]hotreload.Option{hotreload.WithLogger(hotreload.NopLogger())}, opts...)...)
+	t.Cleanup(func() { manager.Close(context.Background()) })
+	if err := manager.RegisterHandler("server.*", func(key, oldValue, newValue string) error {
+		if newValue == "invalid" {
+			return errors.New("invalid value")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	return manager
+}
+
+// serve 向管理接口发送请求并返回响应
+func serve(handler http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer ops")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandlerAuthRejectsAndRecordsActor(t *testing.T) {
+	manager := newTestManager(t)
+	handler := Handler(manager, WithAuth(func(r *http.Request) (string, error) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			return "", errors.New("missing token")
+		}
+		return token, nil
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/state", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), "missing token") {
+		t.Fatalf("GET /state without token = %d %s, want 401 missing token", rec.Code, rec.Body.String())
+	}
+
+	rec = serve(handler, http.MethodPost, "/changes", `{"key":"server.port","new_value":"8080"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /changes = %d %s, want 200", rec.Code, rec.Body.String())
+	}
+	applied, ok := manager.LastApplied("server.port")
+	if !ok || applied.Actor != "ops" || applied.Source != hotreload.SourceAdminHTTP {
+		t.Fatalf("LastApplied() = %+v, want actor ops from %s", applied, hotreload.SourceAdminHTTP)
+	}
+}
+
+func TestHandlerChangeStatus(t *testing.T) {
+	deny := hotreload.AuthorizerFunc(func(ctx context.Context, request hotreload.AuthorizationRequest) error {
+		return errors.New("read only")
+	})
+	manager := newTestManager(t, hotreload.WithAuthorizer(deny, "server.secret"))
+	handler := Handler(manager)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"applied", http.MethodPost, "/changes", `{"key":"server.port","new_value":"8080"}`, http.StatusOK},
+		{"invalid body", http.MethodPost, "/changes", `{`, http.StatusBadRequest},
+		{"empty key", http.MethodPost, "/changes", `{"new_value":"8080"}`, http.StatusBadRequest},
+		{"handler failure", http.MethodPost, "/changes", `{"key":"server.port","new_value":"invalid"}`, http.StatusUnprocessableEntity},
+		{"denied", http.MethodPost, "/changes", `{"key":"server.secret","new_value":"x"}`, http.StatusForbidden},
+		{"empty revision", http.MethodPost, "/rollback", `{}`, http.StatusBadRequest},
+		{"unknown revision", http.MethodPost, "/rollback", `{"revision":999}`, http.StatusUnprocessableEntity},
+		{"unfreeze unknown key", http.MethodPost, "/unfreeze", `{"key":"server.port"}`, http.StatusNotFound},
+		{"method not allowed", http.MethodGet, "/changes", ``, http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(handler, tt.method, tt.path, tt.body)
+			if rec.Code != tt.want {
+				t.Fatalf("%s %s = %d %s, want %d", tt.method, tt.path, rec.Code, rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlerReportsDeferredChange(t *testing.T) {
+	manager := newTestManager(t)
+	manager.Pause()
+
+	rec := serve(Handler(manager), http.MethodPost, "/changes", `{"key":"server.port","new_value":"8080"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /changes = %d %s, want 200", rec.Code, rec.Body.String())
+	}
+	if body := rec.Body.String(); !strings.Contains(body, `"deferred":true`) || !strings.Contains(body, `"outcome":"deferred"`) {
+		t.Fatalf("POST /changes body = %s, want deferred outcome", body)
+	}
+}
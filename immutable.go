@@ -0,0 +1,70 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"fmt"
+	"slices"
+)
+
+// WithImmutableKeys 设置运行期间不允许变更的配置键模式（支持通配符）
+// 匹配的配置键首次成功应用后，后续改变其值（包括删除与回滚）的变更均以 ErrorKindImmutable 拒绝，
+// 错误可通过 errors.Is(err, ErrKeyImmutable) 判断；值未变化的变更（如 ReloadAll）不受影响
+func WithImmutableKeys(patterns ...string) Option {
+	return func(m *Manager) {
+		m.immutable = append(m.immutable, patterns...)
+	}
+}
+
+// WithRejectUnmatched 设置没有任何处理器匹配配置键时返回 ErrNoHandlerMatched 错误（默认忽略该变更）
+// 用于管理接口、发布工具等需要确认变更确实被某个处理器接收的场景
+func WithRejectUnmatched() Option {
+	return func(m *Manager) {
+		m.rejectUnmatched = true
+	}
+}
+
+// checkImmutable 拒绝改变已应用的不可变配置键的变更
+func (m *Manager) checkImmutable(change Change) error {
+	immutable := slices.ContainsFunc(m.immutable, func(pattern string) bool {
+		return pattern == "*" || matchPattern(pattern, change.Key)
+	})
+	if !immutable {
+		return nil
+	}
+	applied, ok := m.store.get(change.Key)
+	if !ok || (!change.Deleted && change.NewValue == applied.Value) {
+		return nil
+	}
+
+	m.counters.recordFailure(ErrorKindImmutable)
+	m.logger.Warn("Config change rejected: key is immutable",
+		"key", change.Key,
+		"applied_value", applied.Value,
+		"new_value", change.NewValue,
+		"source", change.Source,
+		"actor", change.Actor)
+	return &ChangeError{Kind: ErrorKindImmutable, Key: change.Key, Err: ErrKeyImmutable}
+}
+
+// unmatchedError 没有处理器匹配配置键时返回的错误（未开启 WithRejectUnmatched 时为 nil）
+func (m *Manager) unmatchedError(key string) error {
+	if !m.rejectUnmatched {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrNoHandlerMatched, key)
+}
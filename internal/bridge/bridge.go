@@ -0,0 +1,85 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package bridge 提供配置库桥接共用的差异同步逻辑
+package bridge
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// Syncer 记录配置库的扁平化快照，并将差异交给管理器分发
+type Syncer struct {
+	manager *hotreload.Manager
+	source  string
+
+	// 串行化同步
+	mu sync.Mutex
+	// 最近一次同步成功的扁平化配置
+	snapshot map[string]string
+}
+
+// NewSyncer 创建差异同步器，source 为配置变更来源
+func NewSyncer(manager *hotreload.Manager, source string) *Syncer {
+	return &Syncer{
+		manager:  manager,
+		source:   source,
+		snapshot: make(map[string]string),
+	}
+}
+
+// Sync 比较 current 与上次同步的快照，将新增、修改与删除（Change.Deleted）的配置键交给管理器分发
+// 分发失败的配置键保留旧快照，下次同步时重试；返回所有失败的合并错误
+func (s *Syncer) Sync(ctx context.Context, current map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	apply := func(key, oldValue, newValue string, deleted bool) {
+		err := s.manager.Apply(ctx, hotreload.Change{
+			Key:      key,
+			OldValue: oldValue,
+			NewValue: newValue,
+			Deleted:  deleted,
+			Source:   s.source,
+		})
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if deleted {
+			delete(s.snapshot, key)
+		} else {
+			s.snapshot[key] = newValue
+		}
+	}
+
+	for key, value := range current {
+		if old, ok := s.snapshot[key]; !ok || old != value {
+			apply(key, old, value, false)
+		}
+	}
+	for key, old := range s.snapshot {
+		if _, ok := current[key]; !ok {
+			apply(key, old, "", true)
+		}
+	}
+	return errors.Join(errs...)
+}
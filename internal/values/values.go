@@ -0,0 +1,75 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package values 提供内置重载器与配置库桥接共用的配置值解析与格式化函数
+package values
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseList 解析列表类型的配置值
+// 支持 JSON 数组（如 ["a","b"]）与逗号/换行分隔的文本（如 "a, b"），忽略空白项
+func ParseList(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(value, "[") {
+		var items []string
+		if err := json.Unmarshal([]byte(value), &items); err != nil {
+			return nil, fmt.Errorf("invalid list value: %w", err)
+		}
+		return compact(items), nil
+	}
+
+	return compact(strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})), nil
+}
+
+// compact 去除列表项两端空白并忽略空白项
+func compact(items []string) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// Format 将配置库中的配置值转换为字符串配置值
+// 标量值按默认格式转换，列表与对象编码为 JSON
+func Format(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []any, map[string]any, []string, []int:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
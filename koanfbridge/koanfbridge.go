@@ -0,0 +1,228 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package koanfbridge 提供 koanf 与热加载管理器之间的双向桥接
+//
+// 方向一：以管理器作为 koanf 的变更接收端。Bridge 计算 koanf 扁平化配置与上次同步的差异，
+// 并逐个交给管理器分发；Watch 在 Provider 通知变化时重新加载配置并同步：
+//
+//	f := file.Provider("config.yaml")
+//	b, _ := koanfbridge.New(koanf.New("."), hotReloadManager)
+//	_ = b.Load(ctx, f, yaml.Parser()) // 首次加载并同步全部配置键
+//	_ = b.Watch(ctx, f, yaml.Parser())
+//
+// 方向二：Provider 将管理器已成功应用的配置值作为 koanf.Provider 提供，并支持 Watch，
+// 便于以 koanf 为标准的项目读取热加载后的配置：
+//
+//	p := koanfbridge.NewProvider(hotReloadManager)
+//	_ = k.Load(p, nil)
+package koanfbridge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/go-anyway/framework-hotreload/internal/bridge"
+	"github.com/go-anyway/framework-hotreload/internal/values"
+	"github.com/knadh/koanf/maps"
+	"github.com/knadh/koanf/v2"
+)
+
+// DefaultSource 默认的配置变更来源
+const DefaultSource = "koanf"
+
+// keyDelim 热加载管理器配置键的分隔符
+const keyDelim = "."
+
+// WatchProvider 支持监听变化的 koanf Provider（如 file.Provider）
+type WatchProvider interface {
+	koanf.Provider
+	Watch(cb func(event any, err error)) error
+}
+
+// Option 桥接配置选项
+type Option func(*Bridge)
+
+// WithSource 设置配置变更来源（默认 "koanf"）
+func WithSource(source string) Option {
+	return func(b *Bridge) {
+		if source != "" {
+			b.source = source
+		}
+	}
+}
+
+// WithErrorHandler 设置自动重新加载或同步失败时的回调（默认忽略，分发失败详情已记录在管理器日志中）
+func WithErrorHandler(fn func(err error)) Option {
+	return func(b *Bridge) {
+		b.onError = fn
+	}
+}
+
+// Bridge koanf 到热加载管理器的桥接
+type Bridge struct {
+	koanf   atomic.Pointer[koanf.Koanf]
+	source  string
+	onError func(err error)
+	syncer  *bridge.Syncer
+}
+
+// New 创建 koanf 桥接
+func New(k *koanf.Koanf, manager *hotreload.Manager, opts ...Option) (*Bridge, error) {
+	if k == nil {
+		return nil, fmt.Errorf("koanf is nil")
+	}
+	if manager == nil {
+		return nil, fmt.Errorf("manager is nil")
+	}
+	b := &Bridge{source: DefaultSource}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	b.koanf.Store(k)
+	b.syncer = bridge.NewSyncer(manager, b.source)
+	return b, nil
+}
+
+// Koanf 返回当前的 koanf 实例（Load 与 Watch 重新加载后会替换为新实例）
+func (b *Bridge) Koanf() *koanf.Koanf {
+	return b.koanf.Load()
+}
+
+// Sync 比较 koanf 当前配置与上次同步的快照，将新增、修改与删除的配置键交给管理器分发
+// 删除的配置键以 Change.Deleted 投递（已登记默认值时回退到默认值，见 hotreload.Manager.SetDefault）
+// 分发失败的配置键保留旧快照，下次同步时重试；返回所有失败的合并错误
+func (b *Bridge) Sync(ctx context.Context) error {
+	current, err := flatten(b.koanf.Load())
+	if err != nil {
+		return err
+	}
+	return b.syncer.Sync(ctx, current)
+}
+
+// Load 从 provider 重新加载配置到新的 koanf 实例（不保留已删除的配置键），替换当前实例后同步
+func (b *Bridge) Load(ctx context.Context, provider koanf.Provider, parser koanf.Parser, opts ...koanf.Option) error {
+	k := koanf.New(b.koanf.Load().Delim())
+	if err := k.Load(provider, parser, opts...); err != nil {
+		return fmt.Errorf("failed to load koanf provider: %w", err)
+	}
+	b.koanf.Store(k)
+	return b.Sync(ctx)
+}
+
+// Watch 监听 provider 的变化并重新加载、同步，ctx 结束后的变化被忽略
+func (b *Bridge) Watch(ctx context.Context, provider WatchProvider, parser koanf.Parser, opts ...koanf.Option) error {
+	return provider.Watch(func(event any, err error) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			err = b.Load(ctx, provider, parser, opts...)
+		}
+		if err != nil && b.onError != nil {
+			b.onError(err)
+		}
+	})
+}
+
+// flatten 将 koanf 配置扁平化为热加载配置键到字符串值的映射
+func flatten(k *koanf.Koanf) (map[string]string, error) {
+	all := k.All()
+	delim := k.Delim()
+	result := make(map[string]string, len(all))
+	for key, value := range all {
+		formatted, err := values.Format(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to format key %s: %w", key, err)
+		}
+		if delim != keyDelim {
+			key = strings.ReplaceAll(key, delim, keyDelim)
+		}
+		result[key] = formatted
+	}
+	return result, nil
+}
+
+// Provider 以管理器已成功应用的配置值作为 koanf.Provider
+type Provider struct {
+	manager *hotreload.Manager
+
+	mu     sync.Mutex
+	cancel func()
+}
+
+// NewProvider 创建基于管理器的 koanf Provider
+func NewProvider(manager *hotreload.Manager) *Provider {
+	return &Provider{manager: manager}
+}
+
+// ReadBytes 不支持，Provider 直接返回配置映射（加载时 parser 传 nil）
+func (p *Provider) ReadBytes() ([]byte, error) {
+	return nil, fmt.Errorf("koanfbridge provider does not support ReadBytes")
+}
+
+// Read 返回已成功应用的配置值（按 "." 展开为嵌套映射）
+func (p *Provider) Read() (map[string]any, error) {
+	if p.manager == nil {
+		return nil, fmt.Errorf("manager is nil")
+	}
+	applied := p.manager.AppliedValues()
+	flat := make(map[string]any, len(applied))
+	for _, value := range applied {
+		flat[value.Key] = value.Value
+	}
+	return maps.Unflatten(flat, keyDelim), nil
+}
+
+// Watch 在配置变更成功应用后调用 cb（event 为 hotreload.Event），调用方通常在回调中重新 Load
+func (p *Provider) Watch(cb func(event any, err error)) error {
+	if p.manager == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		return fmt.Errorf("koanfbridge provider is already watching")
+	}
+
+	events, cancel := p.manager.SubscribeEvents(0)
+	p.cancel = cancel
+	go func() {
+		for event := range events {
+			if event.Type == hotreload.EventChangeApplied {
+				cb(event, nil)
+			}
+		}
+	}()
+	return nil
+}
+
+// Unwatch 停止监听配置变更
+func (p *Provider) Unwatch() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package koanfbridge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/knadh/koanf/v2"
+)
+
+// mapProvider 返回固定嵌套映射的 koanf Provider，watch 保存 Watch 注册的回调
+type mapProvider struct {
+	data  map[string]any
+	watch func(event any, err error)
+}
+
+func (p *mapProvider) ReadBytes() ([]byte, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (p *mapProvider) Read() (map[string]any, error) {
+	return p.data, nil
+}
+
+func (p *mapProvider) Watch(cb func(event any, err error)) error {
+	p.watch = cb
+	return nil
+}
+
+// newManager 创建管理器，并注册记录 app.* 最新值与删除事件的处理器
+func newManager(t *testing.T) (*hotreload.Manager, map[string]string) {
+	t.Helper()
+	m := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	t.Cleanup(func() { m.Close(context.Background()) })
+	values := make(map[string]string)
+	if err := m.RegisterChangeHandler("app.*", func(_ context.Context, change hotreload.Change) error {
+		if change.Deleted {
+			delete(values, change.Key)
+		} else {
+			values[change.Key] = change.NewValue
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterChangeHandler() error = %v", err)
+	}
+	return m, values
+}
+
+func TestLoadAndWatchSyncChanges(t *testing.T) {
+	m, values := newManager(t)
+	var watchErr error
+	b, err := New(koanf.New("/"), m, WithErrorHandler(func(err error) { watchErr = err }))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	provider := &mapProvider{data: map[string]any{
+		"app": map[string]any{"name": "demo", "limits": map[string]any{"rps": 10}},
+	}}
+	if err := b.Load(ctx, provider, nil); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	// 非 "." 分隔符的配置键转换为管理器使用的 "."
+	if values["app.name"] != "demo" || values["app.limits.rps"] != "10" {
+		t.Fatalf("values after Load() = %v", values)
+	}
+
+	if err := b.Watch(ctx, provider, nil); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	provider.data = map[string]any{"app": map[string]any{"name": "v2"}}
+	provider.watch(nil, nil)
+	if watchErr != nil {
+		t.Fatalf("Watch() reload error = %v", watchErr)
+	}
+	if len(values) != 1 || values["app.name"] != "v2" {
+		t.Fatalf("values after reload = %v, want only app.name=v2", values)
+	}
+	if b.Koanf().Exists("app/limits/rps") {
+		t.Fatal("Koanf() still contains removed key after reload")
+	}
+
+	provider.watch(nil, fmt.Errorf("watch failed"))
+	if watchErr == nil {
+		t.Fatal("watch error not passed to error handler")
+	}
+}
+
+func TestProviderReadsAppliedValues(t *testing.T) {
+	m, _ := newManager(t)
+	ctx := context.Background()
+	if err := m.Apply(ctx, hotreload.Change{Key: "app.limits.rps", NewValue: "10"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	p := NewProvider(m)
+	k := koanf.New(".")
+	if err := k.Load(p, nil); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := k.String("app.limits.rps"); got != "10" {
+		t.Fatalf("String(app.limits.rps) = %q, want 10", got)
+	}
+
+	notified := make(chan struct{}, 1)
+	if err := p.Watch(func(any, error) {
+		select {
+		case notified <- struct{}{}:
+		default:
+		}
+	}); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer p.Unwatch()
+	if err := p.Watch(func(any, error) {}); err == nil {
+		t.Fatal("second Watch() error = nil")
+	}
+	if err := m.Apply(ctx, hotreload.Change{Key: "app.limits.rps", OldValue: "10", NewValue: "20"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	select {
+	case <-notified:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch() callback not called after change applied")
+	}
+}
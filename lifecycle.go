@@ -0,0 +1,249 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrManagerClosed 管理器已关闭，不再接收配置变更
+var ErrManagerClosed = errors.New("manager closed")
+
+// lifecycle 管理器生命周期状态
+type lifecycle struct {
+	// 是否已关闭
+	closed atomic.Bool
+	// 正在分发的配置变更数
+	inflight atomic.Int64
+	// 关闭后在途变更全部完成时通知
+	drained chan struct{}
+	// 关闭时关闭，通知后台循环退出
+	done chan struct{}
+
+	// 交由管理器管理、关闭时需要停止的配置源（按启动顺序）
+	sources []managedSource
+	// 保护 sources，同时串行化配置源的增删替换
+	sourcesMu sync.Mutex
+
+	// 异步通知、告警等后台任务
+	background sync.WaitGroup
+	// 是否已停止接收后台任务，置位后 background 不再 Add，保证 Add 先于 Wait
+	backgroundStopped bool
+	// 保护 backgroundStopped 与 background.Add
+	backgroundMu sync.Mutex
+}
+
+// enter 登记一次配置变更分发，管理器已关闭时返回 false
+func (m *Manager) enter() bool {
+	m.lifecycle.inflight.Add(1)
+	if m.lifecycle.closed.Load() {
+		m.exit()
+		return false
+	}
+	return true
+}
+
+// exit 结束一次配置变更分发
+func (m *Manager) exit() {
+	if m.lifecycle.inflight.Add(-1) == 0 && m.lifecycle.closed.Load() {
+		select {
+		case m.lifecycle.drained <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// goBackground 在后台 goroutine 中执行 fn，关闭时等待其完成
+// 关闭过程已开始等待后台任务时丢弃 fn
+func (m *Manager) goBackground(fn func()) {
+	m.lifecycle.backgroundMu.Lock()
+	if m.lifecycle.backgroundStopped {
+		m.lifecycle.backgroundMu.Unlock()
+		m.logger.Warn("Dropping background task after manager closed")
+		return
+	}
+	m.lifecycle.background.Add(1)
+	m.lifecycle.backgroundMu.Unlock()
+	go func() {
+		defer m.lifecycle.background.Done()
+		fn()
+	}()
+}
+
+// StartSources 依次启动配置源并交由管理器管理，Close 时按相反顺序停止
+// 配置源以 Name() 返回值（未实现时为类型名）命名，重名时追加序号，可据此通过 RemoveSource、ReplaceSource 管理；
+// 任一启动失败时停止本次已启动的配置源并返回错误
+func (m *Manager) StartSources(ctx context.Context, sources ...Source) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+
+	m.lifecycle.sourcesMu.Lock()
+	defer m.lifecycle.sourcesMu.Unlock()
+
+	if m.Closed() {
+		return ErrManagerClosed
+	}
+	if err := StartSources(ctx, sources...); err != nil {
+		return err
+	}
+	for _, source := range sources {
+		if source == nil {
+			continue
+		}
+		m.lifecycle.sources = append(m.lifecycle.sources, managedSource{
+			name:   m.uniqueSourceNameLocked(sourceName(source)),
+			source: source,
+		})
+	}
+	return nil
+}
+
+// Closed 返回管理器是否已关闭
+func (m *Manager) Closed() bool {
+	return m != nil && m.lifecycle.closed.Load()
+}
+
+// Close 关闭管理器
+// 依次：拒绝新的配置变更（返回 ErrManagerClosed）、停止通过 StartSources 启动的配置源、
+// 丢弃暂停期间暂存与限流合并的配置变更、等待在途的配置变更与异步通知完成（受 ctx 截止时间约束）、
+// 保存持久化快照、关闭所有事件订阅并释放解压器等资源。重复调用直接返回 nil
+// ctx 到期时仍有在途变更或后台任务的，返回错误，事件订阅与解压器等资源推迟到它们完成后再释放
+func (m *Manager) Close(ctx context.Context) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if !m.lifecycle.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	m.logger.Info("Closing hot reload manager")
+	close(m.lifecycle.done)
+
+	var errs []error
+
+	// 1. 停止配置源
+	m.lifecycle.sourcesMu.Lock()
+	sources := make([]Source, 0, len(m.lifecycle.sources))
+	for _, entry := range m.lifecycle.sources {
+		sources = append(sources, entry.source)
+	}
+	m.lifecycle.sources = nil
+	m.lifecycle.sourcesMu.Unlock()
+	if err := StopSources(ctx, sources...); err != nil {
+		errs = append(errs, err)
+	}
+
+	// 2. 丢弃暂停期间暂存与限流合并的配置变更
+	m.pauseMu.Lock()
+	dropped := len(m.pending)
+	m.pending = nil
+	m.pendingIndex = nil
+	m.pauseMu.Unlock()
+	if dropped > 0 {
+		m.logger.Warn("Discarding config changes deferred while paused", "pending_count", dropped)
+	}
+	if dropped := m.rateLimiter.close(); dropped > 0 {
+		m.logger.Warn("Discarding config changes coalesced by rate limit", "pending_count", dropped)
+	}
+
+	// 3. 等待在途的配置变更
+	drained := true
+	if err := m.drain(ctx); err != nil {
+		errs = append(errs, err)
+		drained = false
+	}
+
+	// 4. 等待异步通知、告警与后台循环（在途变更仍可能发起通知，须在其完成后停止接收后台任务）
+	if drained {
+		m.stopBackground()
+		done := make(chan struct{})
+		go func() {
+			m.lifecycle.background.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("failed to wait for pending notifications: %w", ctx.Err()))
+			drained = false
+		}
+	}
+
+	// 5. 保存最终快照并释放资源
+	if err := m.saveSnapshot(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to persist config snapshot: %w", err))
+	}
+	if drained {
+		m.releaseResources()
+	} else {
+		// 仍在使用中的资源不能释放，等在途变更与后台任务结束后再释放
+		m.logger.Warn("Deferring resource release until in-flight config changes complete")
+		go func() {
+			_ = m.drain(context.Background())
+			m.stopBackground()
+			m.lifecycle.background.Wait()
+			m.releaseResources()
+		}()
+	}
+
+	m.logger.Info("Hot reload manager closed")
+	return errors.Join(errs...)
+}
+
+// stopBackground 停止接收新的后台任务
+func (m *Manager) stopBackground() {
+	m.lifecycle.backgroundMu.Lock()
+	m.lifecycle.backgroundStopped = true
+	m.lifecycle.backgroundMu.Unlock()
+}
+
+// releaseResources 关闭所有事件订阅并释放解压器等资源
+// 须在在途变更与后台任务全部完成后调用
+func (m *Manager) releaseResources() {
+	if m.shadow != nil {
+		if dropped := m.shadow.discardQueue(); dropped > 0 {
+			m.logger.Warn("Discarding config changes queued for shadow apply", "pending_count", dropped)
+		}
+	}
+	m.events.close()
+	m.watches.close()
+	if m.decompressor != nil {
+		m.decompressor.close()
+	}
+	m.mu.Lock()
+	m.adapters = nil
+	m.mu.Unlock()
+}
+
+// drain 等待在途的配置变更全部完成
+func (m *Manager) drain(ctx context.Context) error {
+	for m.lifecycle.inflight.Load() > 0 {
+		select {
+		case <-m.lifecycle.drained:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to drain %d in-flight config changes: %w", m.lifecycle.inflight.Load(), ctx.Err())
+		}
+	}
+	return nil
+}
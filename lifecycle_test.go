@@ -0,0 +1,76 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCloseExpiredContextDefersRelease(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	entered := make(chan struct{})
+	unblock := make(chan struct{})
+	if err := m.RegisterHandler("app.key", func(key, oldValue, newValue string) error {
+		close(entered)
+		<-unblock
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	events, cancel := m.SubscribeEvents(4)
+	defer cancel()
+
+	applied := make(chan error, 1)
+	go func() {
+		applied <- m.Apply(context.Background(), Change{Key: "app.key", NewValue: "v1"})
+	}()
+	<-entered
+
+	ctx, cancelCtx := context.WithCancel(context.Background())
+	cancelCtx()
+	if err := m.Close(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Close() error = %v, want context.Canceled", err)
+	}
+	select {
+	case _, ok := <-events:
+		t.Fatalf("event channel received (ok = %v) while a change is still in flight", ok)
+	default:
+	}
+
+	close(unblock)
+	if err := <-applied; err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Key != "app.key" {
+				t.Fatalf("event.Key = %q, want %q", event.Key, "app.key")
+			}
+		case <-timeout:
+			t.Fatal("event channel not closed after the in-flight change completed")
+		}
+	}
+}
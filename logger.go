@@ -0,0 +1,117 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"go.uber.org/zap"
+)
+
+// Logger 日志接口
+// keysAndValues 为交替出现的键值对（如 "key", key, "error", err），
+// 与 zap.SugaredLogger 的 *w 系列方法及 slog 的参数约定一致
+type Logger interface {
+	Debug(msg string, keysAndValues ...any)
+	Info(msg string, keysAndValues ...any)
+	Warn(msg string, keysAndValues ...any)
+	Error(msg string, keysAndValues ...any)
+}
+
+// InfoEnabler 可选接口，日志实现可据此声明当前是否输出 info 级别日志
+// 不输出时管理器跳过配置变更成功日志的字段构造，使成功应用的配置变更不分配内存
+type InfoEnabler interface {
+	InfoEnabled() bool
+}
+
+// WithLogger 设置日志接口
+// 未设置时使用输出到 stderr 的默认 zap 日志；在 go-anyway 框架中可传入
+// hotreload.NewZapLogger(log.GetLogger()) 复用框架日志，测试中可传入 NopLogger() 静默日志
+func WithLogger(logger Logger) Option {
+	return func(m *Manager) {
+		if logger != nil {
+			m.logger = logger
+		}
+	}
+}
+
+// zapLogger 基于 zap 的日志适配器
+type zapLogger struct {
+	sugar *zap.SugaredLogger
+}
+
+// NewZapLogger 基于 zap.Logger 创建日志适配器
+func NewZapLogger(logger *zap.Logger) Logger {
+	if logger == nil {
+		return NopLogger()
+	}
+	return &zapLogger{sugar: logger.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+// Debug 记录 debug 级别日志
+func (l *zapLogger) Debug(msg string, keysAndValues ...any) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
+
+// Info 记录 info 级别日志
+func (l *zapLogger) Info(msg string, keysAndValues ...any) {
+	l.sugar.Infow(msg, keysAndValues...)
+}
+
+// InfoEnabled 返回 zap 是否输出 info 级别日志
+func (l *zapLogger) InfoEnabled() bool {
+	return l.sugar.Level().Enabled(zap.InfoLevel)
+}
+
+// Warn 记录 warn 级别日志
+func (l *zapLogger) Warn(msg string, keysAndValues ...any) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+// Error 记录 error 级别日志
+func (l *zapLogger) Error(msg string, keysAndValues ...any) {
+	l.sugar.Errorw(msg, keysAndValues...)
+}
+
+// nopLogger 丢弃所有日志的日志实现
+type nopLogger struct{}
+
+// NopLogger 返回丢弃所有日志的日志实现
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+func (nopLogger) InfoEnabled() bool    { return false }
+
+// infoEnabled 判断日志实现是否输出 info 级别日志（未实现 InfoEnabler 时视为输出）
+func (m *Manager) infoEnabled() bool {
+	enabler, ok := m.logger.(InfoEnabler)
+	return !ok || enabler.InfoEnabled()
+}
+
+// defaultLogger 创建默认日志（info 级别，JSON 格式输出到 stderr）
+func defaultLogger() Logger {
+	cfg := zap.NewProductionConfig()
+	cfg.Sampling = nil
+	logger, err := cfg.Build()
+	if err != nil {
+		return NopLogger()
+	}
+	return NewZapLogger(logger)
+}
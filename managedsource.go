@@ -0,0 +1,301 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrSourceNotFound 指定名称的配置源不存在
+	ErrSourceNotFound = errors.New("config source not found")
+	// ErrSourceExists 同名配置源已存在
+	ErrSourceExists = errors.New("config source already exists")
+	// ErrSourceNotReconfigurable 配置源不支持运行时重新配置
+	ErrSourceNotReconfigurable = errors.New("config source is not reconfigurable")
+)
+
+// ReconfigurableSource 支持运行时重新配置的配置源
+// 实现后 ReconfigureSource 与 BindSource 会原地更新配置（如地址、凭据、轮询间隔），而不是重建配置源
+type ReconfigurableSource interface {
+	Source
+
+	// Reconfigure 以新的配置更新配置源，失败时应保持原有配置继续运行
+	Reconfigure(ctx context.Context, settings map[string]string) error
+}
+
+// SourceFactory 按配置构造（尚未启动的）配置源，settings 的键为去掉绑定前缀后的配置键
+type SourceFactory func(settings map[string]string) (Source, error)
+
+// managedSource 交由管理器管理的配置源
+type managedSource struct {
+	name   string
+	source Source
+}
+
+// sourceName 返回配置源名称：实现 Name() 时使用其返回值，否则使用类型名
+func sourceName(source Source) string {
+	if named, ok := source.(interface{ Name() string }); ok {
+		if name := named.Name(); name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("%T", source)
+}
+
+// uniqueSourceNameLocked 返回不与已管理配置源重名的名称（调用方需持有 sourcesMu）
+func (m *Manager) uniqueSourceNameLocked(name string) string {
+	if m.sourceIndexLocked(name) < 0 {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := name + "#" + strconv.Itoa(i)
+		if m.sourceIndexLocked(candidate) < 0 {
+			return candidate
+		}
+	}
+}
+
+// sourceIndexLocked 返回指定名称的配置源下标，不存在时返回 -1（调用方需持有 sourcesMu）
+func (m *Manager) sourceIndexLocked(name string) int {
+	for i, entry := range m.lifecycle.sources {
+		if entry.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// SourceNames 按启动顺序返回交由管理器管理的配置源名称
+func (m *Manager) SourceNames() []string {
+	if m == nil {
+		return nil
+	}
+
+	m.lifecycle.sourcesMu.Lock()
+	defer m.lifecycle.sourcesMu.Unlock()
+
+	names := make([]string, 0, len(m.lifecycle.sources))
+	for _, entry := range m.lifecycle.sources {
+		names = append(names, entry.name)
+	}
+	return names
+}
+
+// AddSource 在运行期间启动配置源并以 name 交由管理器管理
+func (m *Manager) AddSource(ctx context.Context, name string, source Source) error {
+	if m == nil || source == nil {
+		return fmt.Errorf("manager or source is nil")
+	}
+	if name == "" {
+		return fmt.Errorf("config source name is empty")
+	}
+
+	m.lifecycle.sourcesMu.Lock()
+	defer m.lifecycle.sourcesMu.Unlock()
+
+	if m.Closed() {
+		return ErrManagerClosed
+	}
+	if m.sourceIndexLocked(name) >= 0 {
+		return fmt.Errorf("%w: %s", ErrSourceExists, name)
+	}
+	if err := source.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start config source %s: %w", name, err)
+	}
+	m.lifecycle.sources = append(m.lifecycle.sources, managedSource{name: name, source: source})
+
+	m.logger.Info("Config source added", "source", name)
+	return nil
+}
+
+// RemoveSource 在运行期间停止并移除指定名称的配置源
+// 该配置源已应用的配置保持不变，需要恢复默认值时可配合 RevertSource 使用
+func (m *Manager) RemoveSource(ctx context.Context, name string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+
+	m.lifecycle.sourcesMu.Lock()
+	defer m.lifecycle.sourcesMu.Unlock()
+
+	i := m.sourceIndexLocked(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+	}
+	source := m.lifecycle.sources[i].source
+	m.lifecycle.sources = append(m.lifecycle.sources[:i:i], m.lifecycle.sources[i+1:]...)
+	if err := source.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop config source %s: %w", name, err)
+	}
+
+	m.logger.Info("Config source removed", "source", name)
+	return nil
+}
+
+// ReplaceSource 在运行期间以新的配置源替换指定名称的配置源（不存在时直接添加）
+// 先启动新的配置源，成功后再停止旧的配置源，切换期间不会出现无人监听的空窗；
+// 新配置源启动失败时旧配置源继续运行
+func (m *Manager) ReplaceSource(ctx context.Context, name string, source Source) error {
+	if m == nil || source == nil {
+		return fmt.Errorf("manager or source is nil")
+	}
+	if name == "" {
+		return fmt.Errorf("config source name is empty")
+	}
+
+	m.lifecycle.sourcesMu.Lock()
+	defer m.lifecycle.sourcesMu.Unlock()
+
+	if m.Closed() {
+		return ErrManagerClosed
+	}
+	if err := source.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start config source %s: %w", name, err)
+	}
+
+	i := m.sourceIndexLocked(name)
+	if i < 0 {
+		m.lifecycle.sources = append(m.lifecycle.sources, managedSource{name: name, source: source})
+		m.logger.Info("Config source added", "source", name)
+		return nil
+	}
+	old := m.lifecycle.sources[i].source
+	m.lifecycle.sources[i].source = source
+	m.logger.Info("Config source replaced", "source", name)
+
+	if err := old.Stop(ctx); err != nil {
+		return fmt.Errorf("failed to stop replaced config source %s: %w", name, err)
+	}
+	return nil
+}
+
+// ReconfigureSource 在运行期间重新配置指定名称的配置源
+// 配置源需实现 ReconfigurableSource，否则返回 ErrSourceNotReconfigurable
+func (m *Manager) ReconfigureSource(ctx context.Context, name string, settings map[string]string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+
+	m.lifecycle.sourcesMu.Lock()
+	defer m.lifecycle.sourcesMu.Unlock()
+
+	return m.reconfigureSourceLocked(ctx, name, settings)
+}
+
+// reconfigureSourceLocked 重新配置指定名称的配置源（调用方需持有 sourcesMu）
+func (m *Manager) reconfigureSourceLocked(ctx context.Context, name string, settings map[string]string) error {
+	i := m.sourceIndexLocked(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+	}
+	source, ok := m.lifecycle.sources[i].source.(ReconfigurableSource)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrSourceNotReconfigurable, name)
+	}
+	if err := source.Reconfigure(ctx, settings); err != nil {
+		return fmt.Errorf("failed to reconfigure config source %s: %w", name, err)
+	}
+
+	m.logger.Info("Config source reconfigured", "source", name)
+	return nil
+}
+
+// BindSource 将配置源的配置绑定到以 prefix 开头的配置键，由热加载的配置驱动配置源的增删与切换
+// 每次 "<prefix>.*" 下的配置键变更时，以该前缀下的全部配置（键去掉 "<prefix>." 前缀）：
+//   - 配置源尚未运行时通过 factory 构造并启动（AddSource）
+//   - 配置源实现 ReconfigurableSource 时原地重新配置，否则通过 factory 重建并替换（ReplaceSource）
+//   - 前缀下的配置全部删除时停止并移除配置源（RemoveSource）
+//
+// 构造、启动或重新配置失败时变更以失败处理，原有配置源继续运行。
+// 绑定的配置键应由其他配置源（如本地引导文件）下发：配置源在处理自身下发的变更时被停止可能导致死锁
+func (m *Manager) BindSource(name, prefix string, factory SourceFactory) error {
+	if m == nil || factory == nil {
+		return fmt.Errorf("manager or source factory is nil")
+	}
+	if name == "" || prefix == "" {
+		return fmt.Errorf("config source name or prefix is empty")
+	}
+
+	prefix = strings.TrimSuffix(m.NormalizeKey(prefix), ".") + "."
+	return m.RegisterChangeHandler(prefix+"*", func(ctx context.Context, change Change) error {
+		// 通配符不要求 "*" 前的分隔符，名称前缀相同的其他配置源（如 nacos2）的配置键也会匹配
+		if !strings.HasPrefix(change.Key, prefix) {
+			return nil
+		}
+		return m.syncBoundSource(ctx, name, prefix, factory, change)
+	}, WithName("source:"+name))
+}
+
+// syncBoundSource 按绑定前缀下的最新配置添加、重新配置、替换或移除配置源
+func (m *Manager) syncBoundSource(ctx context.Context, name, prefix string, factory SourceFactory, change Change) error {
+	settings := m.boundSourceSettings(prefix, change)
+	// 配置源的生命周期不应受单次变更处理的超时或取消影响
+	ctx = context.WithoutCancel(ctx)
+
+	m.lifecycle.sourcesMu.Lock()
+	i := m.sourceIndexLocked(name)
+	var running Source
+	if i >= 0 {
+		running = m.lifecycle.sources[i].source
+	}
+	if _, ok := running.(ReconfigurableSource); ok && len(settings) > 0 {
+		err := m.reconfigureSourceLocked(ctx, name, settings)
+		m.lifecycle.sourcesMu.Unlock()
+		return err
+	}
+	m.lifecycle.sourcesMu.Unlock()
+
+	if len(settings) == 0 {
+		if running == nil {
+			return nil
+		}
+		return m.RemoveSource(ctx, name)
+	}
+
+	source, err := factory(settings)
+	if err != nil {
+		return &ChangeError{Kind: ErrorKindValidation, Key: change.Key, Err: fmt.Errorf("failed to build config source %s: %w", name, err)}
+	}
+	if source == nil {
+		return fmt.Errorf("config source factory for %s returned nil", name)
+	}
+	return m.ReplaceSource(ctx, name, source)
+}
+
+// boundSourceSettings 汇总绑定前缀下已应用的配置与当前变更（键去掉前缀）
+// 当前变更尚未写入已应用记录，因此单独合并
+func (m *Manager) boundSourceSettings(prefix string, change Change) map[string]string {
+	settings := make(map[string]string)
+	for _, applied := range m.store.list() {
+		if strings.HasPrefix(applied.Key, prefix) {
+			settings[strings.TrimPrefix(applied.Key, prefix)] = applied.Value
+		}
+	}
+
+	field := strings.TrimPrefix(change.Key, prefix)
+	if change.Deleted {
+		delete(settings, field)
+	} else {
+		settings[field] = change.NewValue
+	}
+	return settings
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// lifecycleSource 记录启动与停止状态的测试配置源
+type lifecycleSource struct {
+	name     string
+	startErr error
+
+	mu      sync.Mutex
+	running bool
+	stopped bool
+}
+
+func (s *lifecycleSource) Name() string { return s.name }
+
+func (s *lifecycleSource) Start(ctx context.Context) error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = true
+	return nil
+}
+
+func (s *lifecycleSource) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running, s.stopped = false, true
+	return nil
+}
+
+func (s *lifecycleSource) state() (running, stopped bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running, s.stopped
+}
+
+// settingsSource 记录构造与重新配置时的配置的测试配置源
+type settingsSource struct {
+	mu       sync.Mutex
+	settings map[string]string
+}
+
+func (s *settingsSource) Start(ctx context.Context) error { return nil }
+func (s *settingsSource) Stop(ctx context.Context) error  { return nil }
+
+func (s *settingsSource) Reconfigure(ctx context.Context, settings map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings = maps.Clone(settings)
+	return nil
+}
+
+func (s *settingsSource) current() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.settings)
+}
+
+func TestBindSourceIgnoresSiblingPrefix(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+
+	sources := make(map[string]*settingsSource)
+	bind := func(name string) {
+		t.Helper()
+		err := m.BindSource(name, "sources."+name, func(settings map[string]string) (Source, error) {
+			source := &settingsSource{settings: maps.Clone(settings)}
+			sources[name] = source
+			return source, nil
+		})
+		if err != nil {
+			t.Fatalf("BindSource(%s) error = %v", name, err)
+		}
+	}
+	bind("nacos")
+	bind("nacos2")
+
+	ctx := context.Background()
+	for key, value := range map[string]string{
+		"sources.nacos.addr":  "10.0.0.1:8848",
+		"sources.nacos2.addr": "10.0.0.2:8848",
+	} {
+		if err := m.Apply(ctx, Change{Key: key, NewValue: value, Source: "bootstrap"}); err != nil {
+			t.Fatalf("Apply(%s) error = %v", key, err)
+		}
+	}
+
+	for name, want := range map[string]string{"nacos": "10.0.0.1:8848", "nacos2": "10.0.0.2:8848"} {
+		source, ok := sources[name]
+		if !ok {
+			t.Fatalf("config source %s not built", name)
+		}
+		settings := source.current()
+		if len(settings) != 1 || settings["addr"] != want {
+			t.Fatalf("config source %s settings = %v, want addr=%s", name, settings, want)
+		}
+	}
+}
+
+func TestStartSourcesNamesDuplicates(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+
+	err := m.StartSources(context.Background(),
+		&lifecycleSource{name: "nacos"}, &lifecycleSource{name: "nacos"}, &settingsSource{})
+	if err != nil {
+		t.Fatalf("StartSources() error = %v", err)
+	}
+	want := []string{"nacos", "nacos#2", "*hotreload.settingsSource"}
+	if got := m.SourceNames(); !slices.Equal(got, want) {
+		t.Fatalf("SourceNames() = %v, want %v", got, want)
+	}
+}
+
+func TestAddAndRemoveSource(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+	ctx := context.Background()
+
+	source := &lifecycleSource{}
+	if err := m.AddSource(ctx, "nacos", source); err != nil {
+		t.Fatalf("AddSource() error = %v", err)
+	}
+	if running, _ := source.state(); !running {
+		t.Fatal("AddSource() did not start source")
+	}
+	if err := m.AddSource(ctx, "nacos", &lifecycleSource{}); !errors.Is(err, ErrSourceExists) {
+		t.Fatalf("AddSource() duplicate error = %v, want ErrSourceExists", err)
+	}
+	if err := m.AddSource(ctx, "etcd", &lifecycleSource{startErr: errors.New("dial failed")}); err == nil {
+		t.Fatal("AddSource() with failing start error = nil")
+	}
+	if got := m.SourceNames(); !slices.Equal(got, []string{"nacos"}) {
+		t.Fatalf("SourceNames() = %v, want [nacos]", got)
+	}
+
+	if err := m.RemoveSource(ctx, "nacos"); err != nil {
+		t.Fatalf("RemoveSource() error = %v", err)
+	}
+	if _, stopped := source.state(); !stopped {
+		t.Fatal("RemoveSource() did not stop source")
+	}
+	if err := m.RemoveSource(ctx, "nacos"); !errors.Is(err, ErrSourceNotFound) {
+		t.Fatalf("RemoveSource() missing error = %v, want ErrSourceNotFound", err)
+	}
+	if got := m.SourceNames(); len(got) != 0 {
+		t.Fatalf("SourceNames() = %v, want none", got)
+	}
+}
+
+func TestReplaceSource(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+	ctx := context.Background()
+
+	first := &lifecycleSource{}
+	// 不存在时直接添加
+	if err := m.ReplaceSource(ctx, "nacos", first); err != nil {
+		t.Fatalf("ReplaceSource() error = %v", err)
+	}
+
+	// 新配置源启动失败时旧配置源继续运行
+	if err := m.ReplaceSource(ctx, "nacos", &lifecycleSource{startErr: errors.New("dial failed")}); err == nil {
+		t.Fatal("ReplaceSource() with failing start error = nil")
+	}
+	if running, _ := first.state(); !running {
+		t.Fatal("ReplaceSource() stopped old source after failed start")
+	}
+
+	second := &lifecycleSource{}
+	if err := m.ReplaceSource(ctx, "nacos", second); err != nil {
+		t.Fatalf("ReplaceSource() error = %v", err)
+	}
+	if _, stopped := first.state(); !stopped {
+		t.Fatal("ReplaceSource() did not stop old source")
+	}
+	if running, _ := second.state(); !running {
+		t.Fatal("ReplaceSource() did not start new source")
+	}
+	if got := m.SourceNames(); !slices.Equal(got, []string{"nacos"}) {
+		t.Fatalf("SourceNames() = %v, want [nacos]", got)
+	}
+}
+
+func TestReconfigureSource(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+	ctx := context.Background()
+
+	reconfigurable := &settingsSource{}
+	if err := m.AddSource(ctx, "nacos", reconfigurable); err != nil {
+		t.Fatalf("AddSource() error = %v", err)
+	}
+	if err := m.AddSource(ctx, "etcd", &lifecycleSource{}); err != nil {
+		t.Fatalf("AddSource() error = %v", err)
+	}
+
+	if err := m.ReconfigureSource(ctx, "nacos", map[string]string{"addr": "10.0.0.1:8848"}); err != nil {
+		t.Fatalf("ReconfigureSource() error = %v", err)
+	}
+	if settings := reconfigurable.current(); settings["addr"] != "10.0.0.1:8848" {
+		t.Fatalf("settings = %v, want addr=10.0.0.1:8848", settings)
+	}
+	if err := m.ReconfigureSource(ctx, "etcd", nil); !errors.Is(err, ErrSourceNotReconfigurable) {
+		t.Fatalf("ReconfigureSource(etcd) error = %v, want ErrSourceNotReconfigurable", err)
+	}
+	if err := m.ReconfigureSource(ctx, "consul", nil); !errors.Is(err, ErrSourceNotFound) {
+		t.Fatalf("ReconfigureSource(consul) error = %v, want ErrSourceNotFound", err)
+	}
+}
+
+func TestAddSourceAfterClose(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	source := &lifecycleSource{}
+	if err := m.AddSource(context.Background(), "nacos", source); err != nil {
+		t.Fatalf("AddSource() error = %v", err)
+	}
+	if err := m.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, stopped := source.state(); !stopped {
+		t.Fatal("Close() did not stop managed source")
+	}
+	if err := m.AddSource(context.Background(), "etcd", &lifecycleSource{}); !errors.Is(err, ErrManagerClosed) {
+		t.Fatalf("AddSource() after Close error = %v, want ErrManagerClosed", err)
+	}
+}
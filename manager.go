@@ -17,107 +17,423 @@
 package hotreload
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"runtime/pprof"
+	"sort"
 	"strings"
 	"sync"
-
-	"github.com/go-anyway/framework-log"
-
-	"go.uber.org/zap"
+	"sync/atomic"
+	"time"
 )
 
+// fieldSetterPattern 字段设置器在隔离、统计等场景中使用的模式名称（模块字段设置器为 "@field_setter:<module>"）
+const fieldSetterPattern = "@field_setter"
+
 // Manager 热加载管理器
 // 负责管理配置变更监听和组件热更新
 type Manager struct {
 	// 重载器列表
-	reloaders []Reloader
+	reloaders []reloaderEntry
 
 	// 配置变更处理器（按模式索引）
-	handlers map[string][]ConfigChangeHandler
+	handlers map[string][]*registration
+	// 含通配符的模式（按首次注册顺序，分发时只需遍历这些模式）
+	wildcards []string
+	// 分片数量及分片函数
+	shardCount int
+	sharder    sharder
+	// 精确模式处理器登记的分片（分发时只锁定配置键所在的分片）
+	registry []registryShard
+	// 通配符模式与字段设置器的只读路由表（分发时无锁读取）
+	routes atomic.Pointer[routeTable]
+	// 全局变更钩子
+	beforeHooks []BeforeChangeHook
+	afterHooks  []AfterChangeHook
+	// 配置变更过滤器
+	filters   []*filterRoute
+	filterSeq uint64
+
+	// 按前缀注册的配置值转换函数（按注册顺序执行）
+	transforms   []*transformRoute
+	transformSeq uint64
+
+	// 已绑定的配置层适配器
+	adapters []Adapter
+
+	// 配置键规范化函数（为空表示不规范化）
+	normalizer KeyNormalizer
+
+	// 压缩配置值解压器（为空表示不解压）
+	decompressor *decompressor
+
+	// 配置键默认值注册表
+	defaults defaultsRegistry
+
+	// 生命周期状态（关闭、在途变更与受管理的配置源）
+	lifecycle lifecycle
 
 	// 字段设置器（用于系统配置热加载）
 	fieldSetter FieldSetter
 
+	// 字段设置器对应的处理器登记（用于统一的隔离与统计）
+	fieldSetterReg *registration
+
+	// 处理器登记序号
+	regSeq uint64
+
 	// 允许的配置前缀（用于系统配置热加载）
 	allowedPrefixes []string
 
+	// 按模块（配置键首段）设置的字段设置器（见 SetModuleFieldSetter）
+	moduleFieldSetters map[string]fieldTarget
+
+	// 核心计数器（用于 expvar 等监控出口）
+	counters counters
+
+	// 健康状态跟踪
+	health healthState
+
+	// 处理器连续失败多少次后被隔离（0 表示不隔离）
+	quarantineThreshold int
+
+	// 最近连续失败多少次配置变更后判定为降级
+	healthFailureThreshold int
+
+	// 慢处理器阈值（0 表示不检测）
+	slowHandlerThreshold time.Duration
+
+	// 是否在处理器执行期间附加 pprof 标签
+	pprofLabels bool
+
+	// 单个处理器的执行超时时间（0 表示不限制）
+	handlerTimeout time.Duration
+
+	// 故障注入器（仅用于测试与金丝雀环境）
+	faults FaultInjector
+
+	// 告警器（配置变更最终失败或处理器被隔离时通知）
+	alerter Alerter
+
+	// 变更通知路由（配置变更成功应用后通知）
+	notifiers []notifierRoute
+
+	// 热加载事件总线
+	events eventBus
+
+	// Watch 订阅者（见 Watch）
+	watches watchHub
+
+	// 变更历史
+	history *history
+
+	// 按配置键记录的最近一次成功应用信息
+	store *store
+
+	// 已应用配置值的不可变快照
+	snapshot   atomic.Pointer[Snapshot]
+	snapshotMu sync.Mutex
+
+	// 指标接口
+	metrics Metrics
+
+	// 日志接口
+	logger Logger
+
+	// 按配置键的日志采样器
+	logSampler logSampler
+
+	// 按配置键的变更频率限制器
+	rateLimiter rateLimiter
+
+	// 按配置键的抖动检测器
+	flaps flapDetector
+
+	// 周期性对账间隔（0 表示不开启）
+	reconcileInterval time.Duration
+
+	// 快照持久化与回退状态
+	fallback snapshotFallback
+
+	// 视为人工覆盖层的额外变更来源（见 WithOverrideSources）
+	overrideSources map[string]struct{}
+
+	// 运行期间不允许变更的配置键模式（见 WithImmutableKeys）
+	immutable []string
+
+	// 没有处理器匹配时是否返回 ErrNoHandlerMatched（见 WithRejectUnmatched）
+	rejectUnmatched bool
+
+	// 全局配置变更并发限制（见 WithMaxConcurrentChanges）
+	concurrency concurrencyLimiter
+
+	// 命名配置档（见 DefineProfile）
+	profiles profileState
+
+	// 授权策略路由（见 WithAuthorizer）
+	authorizers []authorizerRoute
+
+	// 影子应用（见 WithShadow）
+	shadow *shadowRunner
+
+	// 跨配置源的变更排序状态
+	ordering sourceOrdering
+
+	// 暂停状态及暂停期间暂存的配置变更
+	paused       atomic.Bool
+	pending      []pendingChange
+	pendingIndex map[string]int
+	pauseMu      sync.Mutex
+
 	mu sync.RWMutex
 }
 
+// reloaderEntry 已注册的重载器
+type reloaderEntry struct {
+	reloader Reloader
+	name     string
+}
+
+// registration 处理器登记信息
+type registration struct {
+	// 注册时使用的配置键模式
+	pattern string
+
+	// 重载器/处理器名称（用于日志、指标）
+	name string
+
+	// 登记类型
+	kind RegistrationKind
+
+	// 注册位置（file:line）
+	site string
+
+	// 注册顺序
+	seq uint64
+
+	// 处理函数
+	handler ChangeHandler
+
+	// 注册时绑定的 pprof 标签
+	labels pprof.LabelSet
+
+	// 仅包含 name 的处理器名称列表（容量为 1），只有该处理器执行时直接作为处理结果的 Handlers，避免分配
+	names []string
+
+	// 验证函数（仅重载器登记有效，用于预演）
+	validate func(key, value string) error
+
+	// 是否可与其他处理器并行执行（见 WithExecutionMode）
+	parallel bool
+
+	// 连续失败次数
+	consecutiveFailures atomic.Int64
+
+	// 是否已被隔离（被隔离的处理器不再参与分发）
+	quarantined atomic.Bool
+
+	// 调用统计
+	stats handlerStats
+}
+
 // NewManager 创建新的热加载管理器
-func NewManager() *Manager {
-	return &Manager{
-		reloaders:       make([]Reloader, 0),
-		handlers:        make(map[string][]ConfigChangeHandler),
-		allowedPrefixes: make([]string, 0),
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		reloaders:              make([]reloaderEntry, 0),
+		handlers:               make(map[string][]*registration),
+		allowedPrefixes:        make([]string, 0),
+		healthFailureThreshold: defaultHealthFailureThreshold,
+		health:                 newHealthState(),
+		pprofLabels:            true,
+		history:                newHistory(defaultHistorySize),
+		shardCount:             defaultShardCount,
+		lifecycle:              lifecycle{drained: make(chan struct{}, 1), done: make(chan struct{})},
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(m)
+		}
 	}
+	m.normalizeRoutes()
+	m.sharder = newSharder(m.shardCount)
+	m.registry = newRegistry(m.shardCount)
+	m.store = newStore(m.sharder)
+	m.snapshot.Store(newSnapshot(m.sharder, m.normalizer))
+	if m.logger == nil {
+		m.logger = defaultLogger()
+	}
+	m.startReconciler()
+	m.startSnapshotFallback()
+	m.startShadow()
+	return m
 }
 
 // RegisterReloader 注册配置重载器
 // 重载器用于实现自定义配置热加载逻辑（如限流器、熔断器等）
-func (m *Manager) RegisterReloader(reloader Reloader) error {
+func (m *Manager) RegisterReloader(reloader Reloader, opts ...RegisterOption) error {
 	if m == nil || reloader == nil {
 		return fmt.Errorf("manager or reloader is nil")
 	}
 
+	// 释放锁后通知配置层适配器更新订阅
+	defer m.resubscribeAdapters()
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.reloaders = append(m.reloaders, reloader)
-
 	// 为每个模式注册处理器
-	patterns := reloader.Patterns()
-	for _, pattern := range patterns {
-		if m.handlers[pattern] == nil {
-			m.handlers[pattern] = make([]ConfigChangeHandler, 0)
+	options := newRegisterOptions(opts)
+	name := options.name
+	if name == "" {
+		name = reloaderName(reloader)
+	}
+	m.reloaders = append(m.reloaders, reloaderEntry{reloader: reloader, name: name})
+	if provider, ok := reloader.(DefaultsProvider); ok {
+		m.SetDefaults(provider.Defaults())
+	}
+	site := callerSite(1)
+
+	// 处理函数在注册时绑定一次，分发时不再创建闭包或做类型断言
+	changeReloader, _ := reloader.(ChangeReloader)
+	deleteAware, _ := reloader.(DeleteAwareReloader)
+	handler := func(ctx context.Context, change Change) error {
+		// 删除事件交给 DeleteAwareReloader，不验证空值
+		if change.Deleted && deleteAware != nil {
+			return deleteAware.OnDelete(change.Key, change.OldValue)
 		}
-		m.handlers[pattern] = append(m.handlers[pattern], func(key, oldValue, newValue string) error {
-			// 验证配置值
-			if err := reloader.Validate(key, newValue); err != nil {
-				return fmt.Errorf("validation failed for key %s: %w", key, err)
-			}
-			// 调用重载器
-			return reloader.OnChange(key, oldValue, newValue)
+		// 验证配置值
+		if err := reloader.Validate(change.Key, change.NewValue); err != nil {
+			return &ChangeError{Kind: ErrorKindValidation, Key: change.Key, Err: err}
+		}
+		// 调用重载器（支持携带来源信息的 ChangeReloader）
+		if changeReloader != nil {
+			return changeReloader.OnChangeContext(ctx, change)
+		}
+		return reloader.OnChange(change.Key, change.OldValue, change.NewValue)
+	}
+	validate := reloader.Validate
+
+	patterns := m.normalizePatterns(reloader.Patterns())
+	for _, pattern := range patterns {
+		m.addRegistrationLocked(&registration{
+			pattern:  pattern,
+			name:     name,
+			kind:     RegistrationReloader,
+			site:     site,
+			handler:  handler,
+			validate: validate,
+			parallel: options.mode == ExecutionParallel,
 		})
 	}
 
-	log.Info("Config reloader registered",
-		zap.Int("pattern_count", len(patterns)),
-		zap.Strings("patterns", patterns))
+	m.logger.Info("Config reloader registered",
+		"reloader", name,
+		"pattern_count", len(patterns),
+		"patterns", patterns)
 
 	return nil
 }
 
 // RegisterHandler 注册配置变更处理器
 // 用于直接处理配置变更，不经过重载器
-func (m *Manager) RegisterHandler(pattern string, handler ConfigChangeHandler) error {
+func (m *Manager) RegisterHandler(pattern string, handler ConfigChangeHandler, opts ...RegisterOption) error {
+	if m == nil || handler == nil {
+		return fmt.Errorf("manager or handler is nil")
+	}
+
+	// 释放锁后通知配置层适配器更新订阅
+	defer m.resubscribeAdapters()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pattern = m.NormalizeKey(pattern)
+	options := newRegisterOptions(opts)
+	name := options.name
+	if name == "" {
+		name = funcName(handler)
+	}
+
+	m.addRegistrationLocked(&registration{
+		pattern: pattern,
+		name:    name,
+		kind:    RegistrationHandler,
+		site:    callerSite(1),
+		handler: func(ctx context.Context, change Change) error {
+			return handler(change.Key, change.OldValue, change.NewValue)
+		},
+		parallel: options.mode == ExecutionParallel,
+	})
+
+	return nil
+}
+
+// RegisterChangeHandler 注册携带来源信息的配置变更处理器
+// 与 RegisterHandler 相同，但处理器会收到完整的 Change（来源、修订号、时间、操作者），
+// 可据此记录日志或拒绝不符合要求的变更（如只接受来自指定配置中心的变更）
+func (m *Manager) RegisterChangeHandler(pattern string, handler ChangeHandler, opts ...RegisterOption) error {
 	if m == nil || handler == nil {
 		return fmt.Errorf("manager or handler is nil")
 	}
 
+	// 释放锁后通知配置层适配器更新订阅
+	defer m.resubscribeAdapters()
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.handlers[pattern] == nil {
-		m.handlers[pattern] = make([]ConfigChangeHandler, 0)
+	pattern = m.NormalizeKey(pattern)
+	options := newRegisterOptions(opts)
+	name := options.name
+	if name == "" {
+		name = funcName(handler)
 	}
-	m.handlers[pattern] = append(m.handlers[pattern], handler)
+
+	m.addRegistrationLocked(&registration{
+		pattern:  pattern,
+		name:     name,
+		kind:     RegistrationHandler,
+		site:     callerSite(1),
+		handler:  handler,
+		parallel: options.mode == ExecutionParallel,
+	})
 
 	return nil
 }
 
 // SetFieldSetter 设置字段设置器
 // 用于系统配置热加载（将配置中心的配置加载到目标结构体）
+// 已通过 SetModuleFieldSetter 设置了独立字段设置器的模块不经过该全局字段设置器
 func (m *Manager) SetFieldSetter(setter FieldSetter, allowedPrefixes []string) {
 	if m == nil {
 		return
 	}
 
+	// 释放锁后通知配置层适配器更新订阅
+	defer m.resubscribeAdapters()
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.fieldSetter = setter
-	m.allowedPrefixes = allowedPrefixes
+	m.allowedPrefixes = m.normalizePatterns(allowedPrefixes)
+	m.fieldSetterReg = nil
+	if setter != nil {
+		m.fieldSetterReg = m.newFieldSetterRegLocked(fieldSetterPattern, setter, callerSite(1))
+	}
+	m.rebuildRoutesLocked()
+}
+
+// addRegistrationLocked 登记处理器（调用方需持有写锁）
+// 分配注册序号并绑定 pprof 标签，含通配符的新模式加入通配符列表
+func (m *Manager) addRegistrationLocked(reg *registration) {
+	m.regSeq++
+	reg.seq = m.regSeq
+	reg.labels = pprof.Labels(PprofLabelPattern, reg.pattern, PprofLabelReloader, reg.name)
+	reg.names = []string{reg.name}
+
+	if _, ok := m.handlers[reg.pattern]; !ok && strings.Contains(reg.pattern, "*") {
+		m.wildcards = append(m.wildcards, reg.pattern)
+	}
+	m.handlers[reg.pattern] = append(m.handlers[reg.pattern], reg)
+	m.indexRegistrationLocked(reg.pattern)
 }
 
 // HandleChange 处理配置变更
@@ -127,54 +443,518 @@ func (m *Manager) HandleChange(key, oldValue, newValue string) error {
 		return fmt.Errorf("manager is nil")
 	}
 
-	m.mu.RLock()
-	// 收集所有匹配的处理器
-	matchedHandlers := make([]ConfigChangeHandler, 0)
+	return m.dispatch(context.Background(), Change{
+		Key:      key,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}, 0)
+}
+
+// matchBufferPool 复用分发时收集匹配处理器的缓冲区
+var matchBufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]*registration, 0, 8)
+		return &buf
+	},
+}
 
+// match 将所有匹配配置键的处理器登记追加到 buf 并返回
+// 精确模式只锁定配置键所在的分片，通配符模式与字段设置器从只读路由表读取，匹配过程不分配内存
+func (m *Manager) match(key string, buf []*registration) []*registration {
 	// 1. 匹配精确模式
-	if handlers, ok := m.handlers[key]; ok {
-		matchedHandlers = append(matchedHandlers, handlers...)
+	shard := &m.registry[m.sharder.index(key)]
+	shard.mu.RLock()
+	buf = append(buf, shard.exact[key]...)
+	shard.mu.RUnlock()
+
+	routes := m.routes.Load()
+	if routes == nil {
+		return buf
 	}
 
 	// 2. 匹配通配符模式
-	for pattern, handlers := range m.handlers {
-		if pattern != key && matchPattern(pattern, key) {
-			matchedHandlers = append(matchedHandlers, handlers...)
+	for _, route := range routes.wildcards {
+		if matchPattern(route.pattern, key) {
+			buf = append(buf, route.regs...)
 		}
 	}
 
-	// 3. 系统配置热加载（如果设置了字段设置器）
-	fieldSetter := m.fieldSetter
-	allowedPrefixes := m.allowedPrefixes
-	m.mu.RUnlock()
+	// 3. 系统配置热加载：按配置键首段路由到模块字段设置器，未设置时使用全局字段设置器，再检查是否匹配允许的前缀
+	target := routes.fieldSetter
+	if len(routes.moduleFieldSetters) > 0 {
+		module, _, _ := strings.Cut(key, ".")
+		if moduleTarget, ok := routes.moduleFieldSetters[module]; ok {
+			target = moduleTarget
+		}
+	}
+	if matchFieldTarget(target, key) {
+		buf = append(buf, target.reg)
+	}
 
-	// 检查是否匹配允许的前缀
-	if fieldSetter != nil && len(allowedPrefixes) > 0 {
-		for _, prefix := range allowedPrefixes {
-			if hasPrefix(key, prefix) {
-				matchedHandlers = append(matchedHandlers, func(k, oldVal, newVal string) error {
-					return m.handleSystemConfig(k, oldVal, newVal, fieldSetter)
-				})
-				break
-			}
+	return buf
+}
+
+// dispatch 将配置变更分发给所有匹配的处理器
+// rollbackOf 不为 0 时表示该变更是对指定修订号的回滚
+func (m *Manager) dispatch(ctx context.Context, change Change, rollbackOf uint64) error {
+	return m.dispatchResult(ctx, change, rollbackOf, nil).Err
+}
+
+// dispatchResult 分发配置变更并返回处理结果
+// reports 不为 nil 时收集每个匹配处理器的执行明细（见 ApplyWithReport）
+func (m *Manager) dispatchResult(ctx context.Context, change Change, rollbackOf uint64, reports *[]HandlerReport) ChangeResult {
+	if !m.enter() {
+		return ChangeResult{Outcome: OutcomeFailed, Err: ErrManagerClosed}
+	}
+	defer m.exit()
+
+	change.Key = m.NormalizeKey(change.Key)
+	if change.Deleted {
+		change.NewValue = ""
+	}
+	m.applyDefault(&change)
+	ctx = verifyReloaded(ctx, &change)
+	if change.Actor == "" {
+		change.Actor = ActorFromContext(ctx)
+	}
+	if change.Timestamp.IsZero() {
+		change.Timestamp = time.Now()
+	}
+
+	if err := m.authorize(ctx, change, rollbackOf); err != nil {
+		return ChangeResult{Outcome: OutcomeFailed, Err: err}
+	}
+	if m.deferIfPaused(change, rollbackOf) {
+		return ChangeResult{Outcome: OutcomeDeferred}
+	}
+	if err := m.checkFrozen(change); err != nil {
+		return ChangeResult{Outcome: OutcomeFailed, Err: err}
+	}
+	if err := m.checkImmutable(change); err != nil {
+		return ChangeResult{Outcome: OutcomeFailed, Err: err}
+	}
+	if m.holdProfileOverride(change) {
+		return ChangeResult{Outcome: OutcomeSuperseded}
+	}
+	ctx, unlockOrdering := m.lockOrdering(ctx, change.Key)
+	defer unlockOrdering()
+	if m.checkOrdering(change, rollbackOf) {
+		return ChangeResult{Outcome: OutcomeSuperseded}
+	}
+	if admitted, err := m.admitChange(ctx, change, rollbackOf); !admitted {
+		if err != nil {
+			return ChangeResult{Outcome: OutcomeFailed, Err: err}
 		}
+		return ChangeResult{Outcome: OutcomeDeferred}
+	}
+
+	ctx, release, err := m.acquireSlot(ctx, change.Key)
+	if err != nil {
+		return ChangeResult{Outcome: OutcomeFailed, Err: err}
 	}
+	defer release()
+
+	input := change
+	change = m.runBeforeChangeHooks(ctx, change)
+	result := m.applyChange(ctx, change, rollbackOf, reports)
+	m.mirrorShadow(input, result)
+	m.runAfterChangeHooks(ctx, change, result)
+	if result.Err == nil && result.Revision != 0 && !change.Reloaded {
+		m.recordOrdering(change)
+		m.detectFlap(change)
+	}
+	if m.faults != nil && m.faults.Duplicate(change) {
+		m.logger.Warn("Fault injection: delivering config change twice", "key", change.Key)
+		duplicate := m.applyChange(ctx, change, rollbackOf, nil)
+		m.runAfterChangeHooks(ctx, change, duplicate)
+	}
+	if reports != nil {
+		result.Reports = *reports
+	}
+	return result
+}
+
+// applyChange 调用匹配的处理器应用配置变更，返回处理结果
+// reports 不为 nil 时按执行顺序记录每个匹配处理器的执行明细
+func (m *Manager) applyChange(ctx context.Context, change Change, rollbackOf uint64, reports *[]HandlerReport) ChangeResult {
+	start := time.Now()
+	m.counters.changesTotal.Add(1)
+
+	bufp := matchBufferPool.Get().(*[]*registration)
+	matched := m.match(change.Key, (*bufp)[:0])
+	defer func() {
+		clear(matched)
+		*bufp = matched[:0]
+		matchBufferPool.Put(bufp)
+	}()
+	if len(matched) == 0 {
+		m.counters.changesUnmatched.Add(1)
+		return ChangeResult{Outcome: OutcomeUnmatched, Err: m.unmatchedError(change.Key), Duration: time.Since(start)}
+	}
+
+	// 解压压缩的配置值
+	if cerr := m.decompressChange(&change); cerr != nil {
+		m.failChange(change, cerr, rollbackOf)
+		reportNotRun(reports, matched)
+		return ChangeResult{Outcome: OutcomeFailed, Err: cerr, Duration: time.Since(start)}
+	}
+
+	// 执行过滤器：可改写新值或拒绝变更
+	if cerr := m.runFilters(ctx, &change); cerr != nil {
+		m.failChange(change, cerr, rollbackOf)
+		reportNotRun(reports, matched)
+		return ChangeResult{Outcome: OutcomeFailed, Err: cerr, Duration: time.Since(start)}
+	}
+
+	// 执行转换流水线：将新值与旧值规范化
+	if cerr := m.runTransforms(&change); cerr != nil {
+		m.failChange(change, cerr, rollbackOf)
+		reportNotRun(reports, matched)
+		return ChangeResult{Outcome: OutcomeFailed, Err: cerr, Duration: time.Since(start)}
+	}
+
+	key, oldValue, newValue := change.Key, change.OldValue, change.NewValue
 
 	// 调用所有匹配的处理器
-	for _, handler := range matchedHandlers {
-		if err := handler(key, oldValue, newValue); err != nil {
-			log.Error("Failed to handle config change",
-				zap.String("key", key),
-				zap.String("old_value", oldValue),
-				zap.String("new_value", newValue),
-				zap.Error(err))
-			return err
+	var ran []string
+	var skipped *registration
+	var restartReasons []string
+	var parallel []*registration
+	for i, reg := range matched {
+		// 跳过已被隔离的处理器
+		if reg.quarantined.Load() {
+			m.logger.Warn("Skipping quarantined config handler",
+				"key", key,
+				"pattern", reg.pattern,
+				"reloader", reg.name)
+			if skipped == nil {
+				skipped = reg
+			}
+			reportHandler(reports, reg, HandlerQuarantined, 0, nil)
+			continue
+		}
+		// 并行处理器在串行处理器全部成功后并发调用
+		if reg.parallel {
+			parallel = append(parallel, reg)
+			continue
+		}
+
+		m.counters.handlerInvocations.Add(1)
+		invokedAt := time.Now()
+		err := m.callHandler(ctx, reg, change)
+		duration, restarts := time.Since(invokedAt), len(restartReasons)
+		if cerr := m.settleHandler(reg, change, err, duration, &restartReasons); cerr != nil {
+			m.failChange(change, cerr, rollbackOf)
+			reportHandler(reports, reg, HandlerFailed, duration, cerr)
+			reportNotRun(reports, parallel)
+			reportNotRun(reports, matched[i+1:])
+			return ChangeResult{Outcome: OutcomeFailed, Handlers: ran, Err: cerr, Duration: time.Since(start)}
 		}
+		reportHandler(reports, reg, settledStatus(restarts, restartReasons), duration, nil)
+		ran = appendHandlerName(ran, reg, len(matched))
+	}
+	if len(parallel) > 0 {
+		errs, durations := m.callParallel(ctx, parallel, change)
+		var first *ChangeError
+		for i, reg := range parallel {
+			restarts := len(restartReasons)
+			if cerr := m.settleHandler(reg, change, errs[i], durations[i], &restartReasons); cerr != nil {
+				if first == nil {
+					first = cerr
+				}
+				reportHandler(reports, reg, HandlerFailed, durations[i], cerr)
+				continue
+			}
+			reportHandler(reports, reg, settledStatus(restarts, restartReasons), durations[i], nil)
+			ran = appendHandlerName(ran, reg, len(matched))
+		}
+		if first != nil {
+			m.failChange(change, first, rollbackOf)
+			return ChangeResult{Outcome: OutcomeFailed, Handlers: ran, Err: first, Duration: time.Since(start)}
+		}
+	}
+
+	// 匹配的处理器均处于隔离状态时，变更未被应用
+	if len(ran) == 0 && skipped != nil {
+		cerr := &ChangeError{
+			Kind:     ErrorKindQuarantine,
+			Key:      key,
+			Pattern:  skipped.pattern,
+			Reloader: skipped.name,
+			Err:      ErrHandlerQuarantined,
+		}
+		m.failChange(change, cerr, rollbackOf)
+		return ChangeResult{Outcome: OutcomeFailed, Err: cerr, Duration: time.Since(start)}
+	}
+
+	now := time.Now()
+	m.health.recordSuccess()
+	revision := m.counters.markApplied(now)
+
+	if m.infoEnabled() {
+		m.logChange(key, "Config change applied",
+			"key", key,
+			"source", change.Source,
+			"actor", change.Actor,
+			"source_revision", change.SourceRevision,
+			"revision", revision)
+	}
+
+	outcome, reason := OutcomeApplied, ""
+	if len(restartReasons) > 0 {
+		outcome, reason = OutcomeRestartRequired, strings.Join(restartReasons, "; ")
+		m.logger.Warn("Config change requires restart to take effect",
+			"key", key,
+			"revision", revision,
+			"reason", reason)
 	}
 
+	m.recordApplied(ctx, key, newValue, change.Deleted)
+	existed := m.storeApplied(change.Deleted, AppliedValue{
+		Key:            key,
+		Value:          newValue,
+		Revision:       revision,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Defaulted:      change.Defaulted,
+		AppliedAt:      now,
+		Handlers:       ran,
+	})
+	m.history.add(HistoryEntry{
+		Revision:       revision,
+		Key:            key,
+		OldValue:       oldValue,
+		NewValue:       newValue,
+		Deleted:        change.Deleted,
+		Created:        !existed && !change.Deleted,
+		Defaulted:      change.Defaulted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Outcome:        outcome,
+		Error:          reason,
+		RollbackOf:     rollbackOf,
+		Time:           now,
+	})
+	m.publishEvent(Event{
+		Type:           EventChangeApplied,
+		Key:            key,
+		OldValue:       oldValue,
+		NewValue:       newValue,
+		Deleted:        change.Deleted,
+		Defaulted:      change.Defaulted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Revision:       revision,
+		Time:           now,
+	})
+	m.notify(Notification{
+		Key:            key,
+		OldValue:       oldValue,
+		NewValue:       newValue,
+		Deleted:        change.Deleted,
+		Defaulted:      change.Defaulted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Revision:       revision,
+		Time:           now,
+	})
+
+	return ChangeResult{Outcome: outcome, Revision: revision, Handlers: ran, Duration: time.Since(start)}
+}
+
+// settleHandler 记录处理器的调用结果，返回归类后的失败错误
+// 已接受但需重启才能生效的变更不视为失败，原因追加到 restartReasons
+func (m *Manager) settleHandler(reg *registration, change Change, err error, duration time.Duration, restartReasons *[]string) *ChangeError {
+	if err != nil && errors.Is(err, ErrRestartRequired) {
+		*restartReasons = append(*restartReasons, err.Error())
+		err = nil
+	}
+	var cerr *ChangeError
+	if err != nil {
+		cerr = classifyError(reg, change, err)
+		err = cerr
+	}
+	m.observeHandler(reg, duration, err)
+	m.checkSlowHandler(reg, change, duration)
+	if cerr != nil {
+		m.recordHandlerFailure(reg, change, cerr)
+		return cerr
+	}
+	reg.consecutiveFailures.Store(0)
 	return nil
 }
 
+// appendHandlerName 追加已执行的处理器名称
+// 第一个执行的处理器直接复用其注册时创建的名称列表，之后追加时才分配容量为 capacity 的新列表；
+// 因此处理结果的 Handlers 在交给调用方之前需要复制（见 ChangeResult）
+func appendHandlerName(ran []string, reg *registration, capacity int) []string {
+	if ran == nil && reg.names != nil {
+		return reg.names
+	}
+	if len(ran) == 1 && cap(ran) == 1 {
+		ran = append(make([]string, 0, capacity), ran[0])
+	}
+	return append(ran, reg.name)
+}
+
+// failChange 记录处理失败的配置变更：更新计数与健康状态，输出日志、事件、告警与历史记录
+func (m *Manager) failChange(change Change, err *ChangeError, rollbackOf uint64) {
+	m.counters.recordFailure(err.Kind)
+	m.health.recordFailure()
+	m.logger.Error("Failed to handle config change",
+		"key", change.Key,
+		"old_value", change.OldValue,
+		"new_value", change.NewValue,
+		"source", change.Source,
+		"actor", change.Actor,
+		"pattern", err.Pattern,
+		"reloader", err.Reloader,
+		"error_kind", err.Kind,
+		"error", err)
+	m.publishEvent(Event{
+		Type:           EventChangeFailed,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Deleted:        change.Deleted,
+		Defaulted:      change.Defaulted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Pattern:        err.Pattern,
+		Reloader:       err.Reloader,
+		ErrorKind:      err.Kind,
+		Error:          err.Error(),
+	})
+	m.emitAlert(AlertEvent{
+		Type:           AlertChangeFailed,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Pattern:        err.Pattern,
+		Reloader:       err.Reloader,
+		ErrorKind:      err.Kind,
+		Error:          err.Error(),
+	})
+	m.history.add(HistoryEntry{
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Deleted:        change.Deleted,
+		Defaulted:      change.Defaulted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Outcome:        OutcomeFailed,
+		ErrorKind:      err.Kind,
+		Error:          err.Error(),
+		RollbackOf:     rollbackOf,
+		Time:           time.Now(),
+	})
+}
+
+// recordHandlerFailure 记录处理器失败，连续失败次数达到阈值时隔离该处理器
+func (m *Manager) recordHandlerFailure(reg *registration, change Change, err error) {
+	failures := reg.consecutiveFailures.Add(1)
+	if m.quarantineThreshold <= 0 || failures < int64(m.quarantineThreshold) {
+		return
+	}
+	if reg.quarantined.CompareAndSwap(false, true) {
+		m.logger.Error("Config handler quarantined after consecutive failures",
+			"pattern", reg.pattern,
+			"reloader", reg.name,
+			"consecutive_failures", failures)
+		m.publishEvent(Event{
+			Type:           EventHandlerQuarantined,
+			Key:            change.Key,
+			OldValue:       change.OldValue,
+			NewValue:       change.NewValue,
+			Source:         change.Source,
+			Actor:          change.Actor,
+			SourceRevision: change.SourceRevision,
+			Pattern:        reg.pattern,
+			Reloader:       reg.name,
+			Error:          err.Error(),
+		})
+		m.emitAlert(AlertEvent{
+			Type:           AlertHandlerQuarantined,
+			Key:            change.Key,
+			OldValue:       change.OldValue,
+			NewValue:       change.NewValue,
+			Source:         change.Source,
+			Actor:          change.Actor,
+			SourceRevision: change.SourceRevision,
+			Pattern:        reg.pattern,
+			Reloader:       reg.name,
+			Error:          err.Error(),
+		})
+	}
+}
+
+// LiftQuarantine 解除指定模式下所有处理器的隔离状态
+// 返回被解除隔离的处理器数量
+func (m *Manager) LiftQuarantine(pattern string) int {
+	if m == nil {
+		return 0
+	}
+
+	pattern = m.NormalizeKey(pattern)
+	m.mu.RLock()
+	regs := append([]*registration(nil), m.handlers[pattern]...)
+	for _, reg := range m.fieldSetterRegsLocked() {
+		if reg.pattern == pattern {
+			regs = append(regs, reg)
+		}
+	}
+	m.mu.RUnlock()
+
+	lifted := 0
+	for _, reg := range regs {
+		if reg.quarantined.CompareAndSwap(true, false) {
+			reg.consecutiveFailures.Store(0)
+			lifted++
+		}
+	}
+
+	if lifted > 0 {
+		m.logger.Info("Config handler quarantine lifted",
+			"pattern", pattern,
+			"handler_count", lifted)
+	}
+
+	return lifted
+}
+
+// quarantinedPatterns 返回当前处于隔离状态的处理器模式列表
+func (m *Manager) quarantinedPatterns() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	patterns := make([]string, 0)
+	for pattern, regs := range m.handlers {
+		for _, reg := range regs {
+			if reg.quarantined.Load() {
+				patterns = append(patterns, pattern)
+				break
+			}
+		}
+	}
+	for _, reg := range m.fieldSetterRegsLocked() {
+		if reg.quarantined.Load() {
+			patterns = append(patterns, reg.pattern)
+		}
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
 // handleSystemConfig 处理系统配置热加载
 func (m *Manager) handleSystemConfig(key, oldValue, newValue string, setter FieldSetter) error {
 	// 检查值是否真的变化了，如果没有变化则跳过处理
@@ -196,19 +976,22 @@ func (m *Manager) handleSystemConfig(key, oldValue, newValue string, setter Fiel
 		return fmt.Errorf("failed to set field %s.%s: %w", module, fieldPath, err)
 	}
 
-	log.Info("System config updated via hot-reload",
-		zap.String("key", key),
-		zap.String("module", module),
-		zap.String("field_path", fieldPath),
-		zap.String("old_value", oldValue),
-		zap.String("new_value", newValue))
+	m.logChange(key, "System config updated via hot-reload",
+		"key", key,
+		"module", module,
+		"field_path", fieldPath,
+		"old_value", oldValue,
+		"new_value", newValue)
 
 	return nil
 }
 
 // hasPrefix 检查配置键是否匹配前缀（支持通配符）
 func hasPrefix(key, prefix string) bool {
-	return matchPattern(prefix+"*", key) || strings.HasPrefix(key, prefix)
+	if strings.HasPrefix(key, prefix) {
+		return true
+	}
+	return strings.Contains(prefix, "*") && matchPattern(prefix+"*", key)
 }
 
 // splitKey 分割配置键
@@ -241,19 +1024,16 @@ func matchPattern(pattern, key string) bool {
 		}
 	}
 
-	// 通配符匹配：pattern 包含 "*"
-	if strings.Contains(pattern, "*") {
-		// 简单的通配符匹配：支持单级通配符
-		parts := strings.Split(pattern, "*")
-		if len(parts) == 2 {
-			prefix := parts[0]
-			suffix := parts[1]
-			if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix) {
-				// 检查中间部分不包含额外的分隔符（单级通配符）
-				middle := key[len(prefix) : len(key)-len(suffix)]
-				return !strings.Contains(middle, ".")
-			}
-		}
+	// 通配符匹配：pattern 包含单个 "*"
+	prefix, suffix, ok := strings.Cut(pattern, "*")
+	if !ok || strings.Contains(suffix, "*") {
+		return false
+	}
+	// 简单的通配符匹配：支持单级通配符
+	if len(key) >= len(prefix)+len(suffix) && strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix) {
+		// 检查中间部分不包含额外的分隔符（单级通配符）
+		middle := key[len(prefix) : len(key)-len(suffix)]
+		return !strings.Contains(middle, ".")
 	}
 
 	return false
@@ -0,0 +1,280 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics 热加载指标接口
+// 可基于 Prometheus、OpenTelemetry 等实现，用于定位慢或不稳定的重载器
+type Metrics interface {
+	// ObserveHandler 记录一次处理器调用
+	// pattern: 处理器注册时使用的配置键模式
+	// reloader: 重载器/处理器名称
+	// duration: 调用耗时
+	// err: 调用结果（nil 表示成功），失败时可通过 ErrorKindOf(err) 取得错误分类用作指标标签
+	ObserveHandler(pattern, reloader string, duration time.Duration, err error)
+}
+
+// WithMetrics 设置指标接口
+func WithMetrics(metrics Metrics) Option {
+	return func(m *Manager) {
+		m.metrics = metrics
+	}
+}
+
+// handlerStats 处理器调用统计
+type handlerStats struct {
+	invocations atomic.Uint64
+	failures    atomic.Uint64
+	// 超过慢处理器阈值的调用次数
+	slowInvocations atomic.Uint64
+	// 累计耗时（纳秒）
+	totalNanos atomic.Int64
+	// 最大耗时（纳秒）
+	maxNanos atomic.Int64
+	// 最近一次耗时（纳秒）
+	lastNanos atomic.Int64
+
+	// 最近一次成功的时间（UnixNano）
+	lastSuccessAt atomic.Int64
+	// 最近一次失败的时间（UnixNano）
+	lastFailureAt atomic.Int64
+	// 最近一次失败的错误信息
+	lastError atomic.Pointer[string]
+}
+
+// observe 记录一次处理器调用
+func (s *handlerStats) observe(duration time.Duration, err error) {
+	s.invocations.Add(1)
+	now := time.Now().UnixNano()
+	if err != nil {
+		s.failures.Add(1)
+		msg := err.Error()
+		s.lastError.Store(&msg)
+		s.lastFailureAt.Store(now)
+	} else {
+		s.lastSuccessAt.Store(now)
+	}
+
+	nanos := duration.Nanoseconds()
+	s.totalNanos.Add(nanos)
+	s.lastNanos.Store(nanos)
+	for {
+		current := s.maxNanos.Load()
+		if nanos <= current || s.maxNanos.CompareAndSwap(current, nanos) {
+			break
+		}
+	}
+}
+
+// observeHandler 记录处理器调用的统计与指标
+func (m *Manager) observeHandler(reg *registration, duration time.Duration, err error) {
+	reg.stats.observe(duration, err)
+	if m.metrics != nil {
+		m.metrics.ObserveHandler(reg.pattern, reg.name, duration, err)
+	}
+}
+
+// PatternStats 按模式与重载器统计的处理器调用情况
+type PatternStats struct {
+	// 配置键模式
+	Pattern string `json:"pattern"`
+	// 重载器/处理器名称
+	Reloader string `json:"reloader"`
+	// 调用次数
+	Invocations uint64 `json:"invocations"`
+	// 失败次数
+	Failures uint64 `json:"failures"`
+	// 超过慢处理器阈值的调用次数
+	SlowInvocations uint64 `json:"slow_invocations"`
+	// 平均耗时
+	AvgDuration time.Duration `json:"avg_duration"`
+	// 最大耗时
+	MaxDuration time.Duration `json:"max_duration"`
+	// 最近一次耗时
+	LastDuration time.Duration `json:"last_duration"`
+}
+
+// PatternStats 返回按模式与重载器统计的处理器调用情况，按模式和名称排序
+func (m *Manager) PatternStats() []PatternStats {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	regs := make([]*registration, 0)
+	for _, list := range m.handlers {
+		regs = append(regs, list...)
+	}
+	regs = append(regs, m.fieldSetterRegsLocked()...)
+	m.mu.RUnlock()
+
+	type statsKey struct{ pattern, reloader string }
+	index := make(map[statsKey]int)
+	result := make([]PatternStats, 0, len(regs))
+	totals := make([]int64, 0, len(regs))
+
+	for _, reg := range regs {
+		k := statsKey{reg.pattern, reg.name}
+		i, ok := index[k]
+		if !ok {
+			i = len(result)
+			index[k] = i
+			result = append(result, PatternStats{Pattern: reg.pattern, Reloader: reg.name})
+			totals = append(totals, 0)
+		}
+
+		stats := &result[i]
+		stats.Invocations += reg.stats.invocations.Load()
+		stats.Failures += reg.stats.failures.Load()
+		stats.SlowInvocations += reg.stats.slowInvocations.Load()
+		totals[i] += reg.stats.totalNanos.Load()
+		if d := time.Duration(reg.stats.maxNanos.Load()); d > stats.MaxDuration {
+			stats.MaxDuration = d
+		}
+		if d := time.Duration(reg.stats.lastNanos.Load()); d > 0 {
+			stats.LastDuration = d
+		}
+	}
+
+	for i := range result {
+		if result[i].Invocations > 0 {
+			result[i].AvgDuration = time.Duration(totals[i] / int64(result[i].Invocations))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Pattern != result[j].Pattern {
+			return result[i].Pattern < result[j].Pattern
+		}
+		return result[i].Reloader < result[j].Reloader
+	})
+	return result
+}
+
+// ReloaderStats 按重载器统计的调用情况
+type ReloaderStats struct {
+	// 重载器/处理器名称
+	Name string `json:"name"`
+	// 注册的配置键模式
+	Patterns []string `json:"patterns"`
+	// 调用次数
+	Invocations uint64 `json:"invocations"`
+	// 失败次数
+	Failures uint64 `json:"failures"`
+	// 超过慢处理器阈值的调用次数
+	SlowInvocations uint64 `json:"slow_invocations"`
+	// 当前连续失败次数（取各模式中的最大值）
+	ConsecutiveFailures int64 `json:"consecutive_failures"`
+	// 最近一次失败的错误信息
+	LastError string `json:"last_error,omitempty"`
+	// 最近一次失败的时间
+	LastErrorAt time.Time `json:"last_error_at,omitzero"`
+	// 最近一次成功的时间
+	LastSuccessAt time.Time `json:"last_success_at,omitzero"`
+	// 是否有模式处于隔离状态
+	Quarantined bool `json:"quarantined"`
+	// 处于隔离状态的模式
+	QuarantinedPatterns []string `json:"quarantined_patterns,omitempty"`
+}
+
+// Stats 返回按重载器统计的调用情况，按名称排序
+// 同一重载器注册的多个模式会合并为一条记录，便于嵌入应用渲染自己的诊断页面
+func (m *Manager) Stats() []ReloaderStats {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	regs := make([]*registration, 0)
+	for _, list := range m.handlers {
+		regs = append(regs, list...)
+	}
+	regs = append(regs, m.fieldSetterRegsLocked()...)
+	m.mu.RUnlock()
+
+	index := make(map[string]int)
+	result := make([]ReloaderStats, 0)
+	lastErrorAt := make([]int64, 0)
+
+	for _, reg := range regs {
+		i, ok := index[reg.name]
+		if !ok {
+			i = len(result)
+			index[reg.name] = i
+			result = append(result, ReloaderStats{Name: reg.name})
+			lastErrorAt = append(lastErrorAt, 0)
+		}
+
+		stats := &result[i]
+		stats.Patterns = append(stats.Patterns, reg.pattern)
+		stats.Invocations += reg.stats.invocations.Load()
+		stats.Failures += reg.stats.failures.Load()
+		stats.SlowInvocations += reg.stats.slowInvocations.Load()
+		if n := reg.consecutiveFailures.Load(); n > stats.ConsecutiveFailures {
+			stats.ConsecutiveFailures = n
+		}
+		if at := reg.stats.lastFailureAt.Load(); at > lastErrorAt[i] {
+			lastErrorAt[i] = at
+			stats.LastErrorAt = time.Unix(0, at)
+			if msg := reg.stats.lastError.Load(); msg != nil {
+				stats.LastError = *msg
+			}
+		}
+		if at := reg.stats.lastSuccessAt.Load(); at > 0 && time.Unix(0, at).After(stats.LastSuccessAt) {
+			stats.LastSuccessAt = time.Unix(0, at)
+		}
+		if reg.quarantined.Load() {
+			stats.Quarantined = true
+			stats.QuarantinedPatterns = append(stats.QuarantinedPatterns, reg.pattern)
+		}
+	}
+
+	for i := range result {
+		sort.Strings(result[i].Patterns)
+		sort.Strings(result[i].QuarantinedPatterns)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// reloaderName 返回重载器的名称（实现 NamedReloader 时为 Name()，否则为类型名）
+func reloaderName(reloader Reloader) string {
+	if named, ok := reloader.(NamedReloader); ok {
+		if name := named.Name(); name != "" {
+			return name
+		}
+	}
+	return fmt.Sprintf("%T", reloader)
+}
+
+// funcName 返回处理函数的名称（函数全名）
+func funcName(handler any) string {
+	if fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return "handler"
+}
@@ -0,0 +1,317 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultNamespaceSeparator 默认的命名空间分隔符（如 "tenant-a:server.http.port"）
+	DefaultNamespaceSeparator = ":"
+	// DefaultNamespace 不带命名空间前缀的配置键所属的命名空间
+	DefaultNamespace = ""
+)
+
+// ErrNamespaceNotFound 配置变更所属的命名空间不存在
+var ErrNamespaceNotFound = errors.New("namespace not found")
+
+// Namespaces 多租户热加载管理器
+// 配置键以 "<namespace><sep><key>" 的形式投递（如 "tenant-a:server.*"），每个命名空间由独立的 Manager 处理，
+// 处理器、变更历史、统计、快照与隔离状态在命名空间之间互不影响；不带命名空间前缀的配置键属于 DefaultNamespace
+type Namespaces struct {
+	separator string
+	options   func(namespace string) []Option
+
+	mu       sync.RWMutex
+	managers map[string]*Manager
+	adapters []Adapter
+	health   map[string]bool
+}
+
+var _ ChangeSink = (*Namespaces)(nil)
+
+// NamespacesOption 多租户管理器配置选项
+type NamespacesOption func(*Namespaces)
+
+// WithNamespaceSeparator 设置命名空间分隔符（默认 ":"）
+func WithNamespaceSeparator(separator string) NamespacesOption {
+	return func(n *Namespaces) {
+		if separator != "" {
+			n.separator = separator
+		}
+	}
+}
+
+// WithNamespaceManagerOptions 设置创建命名空间管理器时使用的 Option（可按命名空间返回不同配置，如独立的指标标签）
+func WithNamespaceManagerOptions(options func(namespace string) []Option) NamespacesOption {
+	return func(n *Namespaces) {
+		n.options = options
+	}
+}
+
+// NewNamespaces 创建多租户热加载管理器
+func NewNamespaces(opts ...NamespacesOption) *Namespaces {
+	n := &Namespaces{
+		separator: DefaultNamespaceSeparator,
+		managers:  make(map[string]*Manager),
+		health:    make(map[string]bool),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(n)
+		}
+	}
+	return n
+}
+
+// Namespace 返回命名空间的管理器，不存在时创建
+// 在返回的管理器上注册的处理器只接收该命名空间的配置变更，且收到的配置键不带命名空间前缀
+func (n *Namespaces) Namespace(namespace string) *Manager {
+	if n == nil {
+		return nil
+	}
+
+	n.mu.RLock()
+	m, ok := n.managers[namespace]
+	n.mu.RUnlock()
+	if ok {
+		return m
+	}
+
+	n.mu.Lock()
+	if m, ok = n.managers[namespace]; ok {
+		n.mu.Unlock()
+		return m
+	}
+	var opts []Option
+	if n.options != nil {
+		opts = n.options(namespace)
+	}
+	m = NewManager(opts...)
+	n.managers[namespace] = m
+	sources := make(map[string]bool, len(n.health))
+	for source, connected := range n.health {
+		sources[source] = connected
+	}
+	n.mu.Unlock()
+
+	// 新命名空间继承已上报的配置源状态，并在注册变化时更新配置层适配器的订阅
+	for source, connected := range sources {
+		m.ReportSourceStatus(source, connected)
+	}
+	_ = m.Attach(namespaceLink{namespaces: n})
+	return m
+}
+
+// Lookup 查询已存在的命名空间管理器
+func (n *Namespaces) Lookup(namespace string) (*Manager, bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	m, ok := n.managers[namespace]
+	return m, ok
+}
+
+// Names 按字典序返回所有命名空间
+func (n *Namespaces) Names() []string {
+	if n == nil {
+		return nil
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	names := make([]string, 0, len(n.managers))
+	for name := range n.managers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Remove 移除命名空间（如租户下线）并关闭其管理器，返回是否存在
+// 被移除的管理器不再接收配置变更；之后再次调用 Namespace 会创建新的管理器
+func (n *Namespaces) Remove(namespace string) bool {
+	if n == nil {
+		return false
+	}
+
+	n.mu.Lock()
+	m, ok := n.managers[namespace]
+	delete(n.managers, namespace)
+	n.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	_ = n.resubscribe()
+	if err := m.Close(context.Background()); err != nil {
+		m.logger.Warn("Failed to close removed namespace manager", "namespace", namespace, "error", err)
+	}
+	return true
+}
+
+// SplitKey 将带命名空间前缀的配置键拆分为命名空间与命名空间内的配置键
+func (n *Namespaces) SplitKey(key string) (namespace, local string) {
+	if ns, local, ok := strings.Cut(key, n.separator); ok {
+		return ns, local
+	}
+	return DefaultNamespace, key
+}
+
+// JoinKey 将命名空间与配置键拼接为带命名空间前缀的配置键
+func (n *Namespaces) JoinKey(namespace, key string) string {
+	if namespace == DefaultNamespace {
+		return key
+	}
+	return namespace + n.separator + key
+}
+
+// Apply 将配置变更路由到配置键所属命名空间的管理器
+// 命名空间需已通过 Namespace 创建，否则返回 ErrNamespaceNotFound（投递配置键的一方不能借此创建管理器）
+func (n *Namespaces) Apply(ctx context.Context, change Change) error {
+	if n == nil {
+		return fmt.Errorf("namespaces is nil")
+	}
+
+	namespace, key := n.SplitKey(change.Key)
+	m, ok := n.Lookup(namespace)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNamespaceNotFound, namespace)
+	}
+	change.Key = key
+	return m.Apply(ctx, change)
+}
+
+// HandleChange 处理带命名空间前缀的配置变更
+func (n *Namespaces) HandleChange(key, oldValue, newValue string) error {
+	return n.Apply(context.Background(), Change{Key: key, OldValue: oldValue, NewValue: newValue})
+}
+
+// ReportSourceStatus 向所有命名空间上报配置源的连接状态
+func (n *Namespaces) ReportSourceStatus(source string, connected bool) {
+	if n == nil || source == "" {
+		return
+	}
+
+	n.mu.Lock()
+	n.health[source] = connected
+	managers := n.managerList()
+	n.mu.Unlock()
+
+	for _, m := range managers {
+		m.ReportSourceStatus(source, connected)
+	}
+}
+
+// Attach 绑定配置层适配器：适配器投递带命名空间前缀的配置键，并订阅所有命名空间的配置键模式
+func (n *Namespaces) Attach(adapter Adapter) error {
+	if n == nil || adapter == nil {
+		return fmt.Errorf("namespaces or adapter is nil")
+	}
+	if err := adapter.Attach(n); err != nil {
+		return fmt.Errorf("failed to attach config adapter %s: %w", adapter.Name(), err)
+	}
+	if err := adapter.Subscribe(n.Patterns()); err != nil {
+		return fmt.Errorf("failed to subscribe config adapter %s: %w", adapter.Name(), err)
+	}
+
+	n.mu.Lock()
+	n.adapters = append(n.adapters, adapter)
+	n.mu.Unlock()
+	return nil
+}
+
+// Patterns 按字典序返回所有命名空间已注册的配置键模式（带命名空间前缀）
+func (n *Namespaces) Patterns() []string {
+	if n == nil {
+		return nil
+	}
+
+	n.mu.RLock()
+	managers := make(map[string]*Manager, len(n.managers))
+	for name, m := range n.managers {
+		managers[name] = m
+	}
+	n.mu.RUnlock()
+
+	patterns := make([]string, 0)
+	for name, m := range managers {
+		for _, pattern := range m.Patterns() {
+			patterns = append(patterns, n.JoinKey(name, pattern))
+		}
+	}
+	sort.Strings(patterns)
+	return patterns
+}
+
+// managerList 返回所有命名空间管理器（调用方需持有锁）
+func (n *Namespaces) managerList() []*Manager {
+	managers := make([]*Manager, 0, len(n.managers))
+	for _, m := range n.managers {
+		managers = append(managers, m)
+	}
+	return managers
+}
+
+// resubscribe 以所有命名空间的模式列表更新配置层适配器的订阅，返回所有失败的合并错误
+func (n *Namespaces) resubscribe() error {
+	n.mu.RLock()
+	adapters := n.adapters
+	n.mu.RUnlock()
+	if len(adapters) == 0 {
+		return nil
+	}
+
+	patterns := n.Patterns()
+	var errs []error
+	for _, adapter := range adapters {
+		if err := adapter.Subscribe(patterns); err != nil {
+			errs = append(errs, fmt.Errorf("config adapter %s: %w", adapter.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// namespaceLink 绑定到命名空间管理器的内部适配器
+// 命名空间管理器的注册变化时通过 Subscribe 通知 Namespaces 更新外部适配器的订阅
+type namespaceLink struct {
+	namespaces *Namespaces
+}
+
+// Name 返回适配器名称
+func (l namespaceLink) Name() string {
+	return "namespaces"
+}
+
+// Attach 绑定变更接收端（配置变更由 Namespaces 路由，无需保存）
+func (l namespaceLink) Attach(sink ChangeSink) error {
+	return nil
+}
+
+// Subscribe 命名空间管理器的模式变化时更新外部适配器的订阅（失败由命名空间管理器记录日志）
+func (l namespaceLink) Subscribe(patterns []string) error {
+	return l.namespaces.resubscribe()
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNamespacesApplyRejectsUnknownNamespace(t *testing.T) {
+	tenants := NewNamespaces(WithNamespaceManagerOptions(func(string) []Option {
+		return []Option{WithLogger(NopLogger())}
+	}))
+	m := tenants.Namespace("tenant-a")
+	if err := m.RegisterHandler("server.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	if err := tenants.HandleChange("tenant-a:server.port", "", "8080"); err != nil {
+		t.Fatalf("HandleChange() error = %v", err)
+	}
+	if err := tenants.HandleChange("tenant-b:server.port", "", "8080"); !errors.Is(err, ErrNamespaceNotFound) {
+		t.Fatalf("HandleChange() unknown namespace error = %v, want ErrNamespaceNotFound", err)
+	}
+	if _, ok := tenants.Lookup("tenant-b"); ok {
+		t.Fatal("HandleChange() created a manager for an unknown namespace")
+	}
+
+	if !tenants.Remove("tenant-a") {
+		t.Fatal("Remove() = false, want true")
+	}
+	if err := m.Apply(context.Background(), Change{Key: "server.port", NewValue: "9090"}); !errors.Is(err, ErrManagerClosed) {
+		t.Fatalf("Apply() on removed namespace manager error = %v, want ErrManagerClosed", err)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"strings"
+)
+
+// KeyNormalizer 配置键规范化函数
+// 同时作用于注册时的配置键模式与收到的配置键，使不同配置源投递的同一配置键（如 "Server.HTTP.Port" 与 "server.http.port"）
+// 匹配到相同的处理器；规范化函数不得改变模式中的 "*"
+type KeyNormalizer func(key string) string
+
+// KeyNormalization 内置的配置键规范化规则
+type KeyNormalization uint
+
+const (
+	// NormalizeTrimSpace 去除首尾空白
+	NormalizeTrimSpace KeyNormalization = 1 << iota
+	// NormalizeCase 转换为小写（大小写不敏感匹配）
+	NormalizeCase
+	// NormalizeSeparators 将 "-" 视为 "_"（如 "rate-limit" 与 "rate_limit" 视为同一配置键）
+	// "." 仍作为层级分隔符，不与 "-"、"_" 互换
+	NormalizeSeparators
+
+	// NormalizeAll 启用所有内置规则
+	NormalizeAll = NormalizeTrimSpace | NormalizeCase | NormalizeSeparators
+)
+
+// Normalizer 返回按内置规则规范化配置键的函数
+func (n KeyNormalization) Normalizer() KeyNormalizer {
+	return func(key string) string {
+		if n&NormalizeTrimSpace != 0 {
+			key = strings.TrimSpace(key)
+		}
+		if n&NormalizeCase != 0 {
+			key = strings.ToLower(key)
+		}
+		if n&NormalizeSeparators != 0 {
+			key = strings.ReplaceAll(key, "-", "_")
+		}
+		return key
+	}
+}
+
+// WithKeyNormalization 按内置规则规范化配置键
+//
+//	m := hotreload.NewManager(hotreload.WithKeyNormalization(hotreload.NormalizeAll))
+func WithKeyNormalization(n KeyNormalization) Option {
+	return WithKeyNormalizer(n.Normalizer())
+}
+
+// WithKeyNormalizer 设置自定义配置键规范化函数
+// 规范化作用于处理器、过滤器、通知路由与日志采样的模式、字段设置器的允许前缀，以及所有收到的配置键；
+// 处理器、变更历史与事件中看到的都是规范化后的配置键
+func WithKeyNormalizer(normalizer KeyNormalizer) Option {
+	return func(m *Manager) {
+		m.normalizer = normalizer
+	}
+}
+
+// NormalizeKey 返回规范化后的配置键（未设置规范化函数时原样返回）
+// 配置层适配器可据此将订阅模式映射回配置源的配置键
+func (m *Manager) NormalizeKey(key string) string {
+	if m == nil || m.normalizer == nil {
+		return key
+	}
+	return m.normalizer(key)
+}
+
+// normalizePatterns 返回规范化后的模式列表（未设置规范化函数时原样返回）
+func (m *Manager) normalizePatterns(patterns []string) []string {
+	if m.normalizer == nil || len(patterns) == 0 {
+		return patterns
+	}
+	result := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		result[i] = m.normalizer(pattern)
+	}
+	return result
+}
+
+// normalizeRoutes 规范化通过 Option 配置的通知路由、日志采样、限流、抖动检测与不可变配置键与授权策略模式
+// 在所有 Option 应用完成后调用，与 Option 的传入顺序无关
+func (m *Manager) normalizeRoutes() {
+	if m.normalizer == nil {
+		return
+	}
+	for i := range m.notifiers {
+		m.notifiers[i].patterns = m.normalizePatterns(m.notifiers[i].patterns)
+	}
+	for i := range m.logSampler.routes {
+		m.logSampler.routes[i].pattern = m.normalizer(m.logSampler.routes[i].pattern)
+	}
+	for i := range m.rateLimiter.routes {
+		m.rateLimiter.routes[i].pattern = m.normalizer(m.rateLimiter.routes[i].pattern)
+	}
+	for i := range m.flaps.routes {
+		m.flaps.routes[i].pattern = m.normalizer(m.flaps.routes[i].pattern)
+	}
+	m.immutable = m.normalizePatterns(m.immutable)
+	for i := range m.authorizers {
+		m.authorizers[i].patterns = m.normalizePatterns(m.authorizers[i].patterns)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"time"
+)
+
+// defaultNotifyTimeout 默认的变更通知发送超时时间
+const defaultNotifyTimeout = 10 * time.Second
+
+// Notification 配置变更通知
+type Notification struct {
+	// 配置键
+	Key string `json:"key"`
+	// 旧值
+	OldValue string `json:"old_value"`
+	// 新值
+	NewValue string `json:"new_value"`
+	// 配置键已被删除
+	Deleted bool `json:"deleted,omitempty"`
+	// 新值来自默认值注册表
+	Defaulted bool `json:"defaulted,omitempty"`
+	// 变更来源（如配置中心名称、admin-http）
+	Source string `json:"source,omitempty"`
+	// 操作者身份（如配置中心用户、管理接口调用方）
+	Actor string `json:"actor,omitempty"`
+	// 配置源的修订号
+	SourceRevision string `json:"source_revision,omitempty"`
+	// 应用后的修订号
+	Revision uint64 `json:"revision"`
+	// 应用时间
+	Time time.Time `json:"time"`
+}
+
+// Notifier 配置变更通知器接口
+// 配置变更成功应用后，Manager 会异步调用匹配路由的通知器（如 Slack、邮件、PagerDuty）
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// NotifierFunc 函数形式的通知器
+type NotifierFunc func(ctx context.Context, notification Notification) error
+
+// Notify 实现 Notifier 接口
+func (f NotifierFunc) Notify(ctx context.Context, notification Notification) error {
+	return f(ctx, notification)
+}
+
+// notifierRoute 通知路由规则
+type notifierRoute struct {
+	// 配置键模式列表（为空表示接收所有配置变更）
+	patterns []string
+	// 通知器
+	notifier Notifier
+}
+
+// matches 检查配置键是否匹配路由规则
+func (r notifierRoute) matches(key string) bool {
+	if len(r.patterns) == 0 {
+		return true
+	}
+	for _, pattern := range r.patterns {
+		if pattern == "*" || matchPattern(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithNotifier 添加通知路由
+// patterns 为配置键模式（支持通配符），为空时接收所有配置变更
+// 可多次使用以配置多条路由，同一变更会扇出到所有匹配的通知器
+func WithNotifier(notifier Notifier, patterns ...string) Option {
+	return func(m *Manager) {
+		if notifier == nil {
+			return
+		}
+		m.notifiers = append(m.notifiers, notifierRoute{
+			patterns: patterns,
+			notifier: notifier,
+		})
+	}
+}
+
+// notify 异步将配置变更通知扇出到所有匹配的通知器
+// 通知发送失败只记录日志，不影响配置变更处理
+func (m *Manager) notify(notification Notification) {
+	for _, route := range m.notifiers {
+		if !route.matches(notification.Key) {
+			continue
+		}
+
+		// 仅在有匹配的通知器时复制通知，未配置通知器时 notification 不会逃逸到堆上
+		notifier, notification := route.notifier, notification
+		m.goBackground(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), defaultNotifyTimeout)
+			defer cancel()
+
+			if err := notifier.Notify(ctx, notification); err != nil {
+				m.logger.Warn("Failed to send config change notification",
+					"key", notification.Key,
+					"error", err)
+			}
+		})
+	}
+}
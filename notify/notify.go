@@ -0,0 +1,37 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package notify 提供常用的配置变更通知器实现（Slack、SMTP 邮件）
+package notify
+
+import (
+	"fmt"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// FormatFunc 通知内容格式化函数
+type FormatFunc func(notification hotreload.Notification) string
+
+// DefaultFormat 默认的通知内容格式
+func DefaultFormat(notification hotreload.Notification) string {
+	return fmt.Sprintf("Config %s changed (revision %d): %q -> %q at %s",
+		notification.Key,
+		notification.Revision,
+		notification.OldValue,
+		notification.NewValue,
+		notification.Time.Format("2006-01-02 15:04:05 MST"))
+}
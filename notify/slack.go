@@ -0,0 +1,106 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// SlackConfig Slack 通知配置
+type SlackConfig struct {
+	// Slack Incoming Webhook 地址
+	WebhookURL string
+
+	// 频道（可选，覆盖 Webhook 默认频道）
+	Channel string
+
+	// 发送者名称（可选）
+	Username string
+
+	// 消息格式化函数，为空时使用 DefaultFormat
+	Format FormatFunc
+
+	// HTTP 客户端，为空时使用带超时的默认客户端
+	Client *http.Client
+}
+
+// SlackNotifier 通过 Slack Incoming Webhook 发送配置变更通知
+type SlackNotifier struct {
+	cfg SlackConfig
+}
+
+var _ hotreload.Notifier = (*SlackNotifier)(nil)
+
+// NewSlackNotifier 创建 Slack 通知器
+func NewSlackNotifier(cfg SlackConfig) (*SlackNotifier, error) {
+	if cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("slack webhook url is empty")
+	}
+	if cfg.Format == nil {
+		cfg.Format = DefaultFormat
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &SlackNotifier{cfg: cfg}, nil
+}
+
+// slackMessage Slack 消息体
+type slackMessage struct {
+	Text     string `json:"text"`
+	Channel  string `json:"channel,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+// Notify 发送通知
+func (n *SlackNotifier) Notify(ctx context.Context, notification hotreload.Notification) error {
+	body, err := json.Marshal(slackMessage{
+		Text:     n.cfg.Format(notification),
+		Channel:  n.cfg.Channel,
+		Username: n.cfg.Username,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.cfg.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post slack message: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("slack returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// SMTPConfig SMTP 邮件通知配置
+type SMTPConfig struct {
+	// SMTP 服务器地址（host:port）
+	Addr string
+
+	// 认证信息（可选，如 smtp.PlainAuth）
+	Auth smtp.Auth
+
+	// 发件人
+	From string
+
+	// 收件人列表
+	To []string
+
+	// 邮件主题前缀，默认 "[hotreload]"
+	SubjectPrefix string
+
+	// 邮件正文格式化函数，为空时使用 DefaultFormat
+	Format FormatFunc
+}
+
+// SMTPNotifier 通过 SMTP 发送配置变更通知邮件
+type SMTPNotifier struct {
+	cfg SMTPConfig
+
+	// sendMail 发送邮件函数（便于替换）
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+var _ hotreload.Notifier = (*SMTPNotifier)(nil)
+
+// NewSMTPNotifier 创建 SMTP 邮件通知器
+func NewSMTPNotifier(cfg SMTPConfig) (*SMTPNotifier, error) {
+	if cfg.Addr == "" {
+		return nil, fmt.Errorf("smtp addr is empty")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Addr); err != nil {
+		return nil, fmt.Errorf("invalid smtp addr %s: %w", cfg.Addr, err)
+	}
+	if cfg.From == "" {
+		return nil, fmt.Errorf("smtp sender is empty")
+	}
+	if len(cfg.To) == 0 {
+		return nil, fmt.Errorf("smtp recipients are empty")
+	}
+	if cfg.SubjectPrefix == "" {
+		cfg.SubjectPrefix = "[hotreload]"
+	}
+	if cfg.Format == nil {
+		cfg.Format = DefaultFormat
+	}
+	return &SMTPNotifier{cfg: cfg, sendMail: smtp.SendMail}, nil
+}
+
+// Notify 发送通知
+// net/smtp 不支持 context，ctx 仅用于在发送前检查是否已取消
+func (n *SMTPNotifier) Notify(ctx context.Context, notification hotreload.Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg := n.buildMessage(notification)
+	if err := n.sendMail(n.cfg.Addr, n.cfg.Auth, n.cfg.From, n.cfg.To, msg); err != nil {
+		return fmt.Errorf("failed to send notification mail: %w", err)
+	}
+	return nil
+}
+
+// buildMessage 构建 RFC 5322 邮件内容
+func (n *SMTPNotifier) buildMessage(notification hotreload.Notification) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(n.cfg.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s config %s changed\r\n", n.cfg.SubjectPrefix, sanitizeHeader(notification.Key))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(n.cfg.Format(notification))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// sanitizeHeader 去除邮件头中的换行符，防止头注入
+func sanitizeHeader(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+// Option 热加载管理器配置选项
+type Option func(*Manager)
+
+// WithQuarantineThreshold 设置处理器隔离阈值
+// 同一处理器连续失败 n 次后会被隔离，不再参与配置变更分发，直到调用 LiftQuarantine 解除
+// n <= 0 表示不隔离（默认）
+func WithQuarantineThreshold(n int) Option {
+	return func(m *Manager) {
+		m.quarantineThreshold = n
+	}
+}
+
+// WithHealthFailureThreshold 设置健康检查的失败阈值
+// 最近连续 n 次配置变更处理失败时，健康检查判定为降级
+// n <= 0 表示不根据配置变更失败判定降级
+func WithHealthFailureThreshold(n int) Option {
+	return func(m *Manager) {
+		m.healthFailureThreshold = n
+	}
+}
+
+// RegisterOption 处理器注册选项
+type RegisterOption func(*registerOptions)
+
+// registerOptions 处理器注册配置
+type registerOptions struct {
+	// 重载器/处理器名称
+	name string
+	// 执行方式
+	mode ExecutionMode
+}
+
+// newRegisterOptions 应用注册选项
+func newRegisterOptions(opts []RegisterOption) registerOptions {
+	var o registerOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// WithName 设置重载器/处理器名称
+// 名称用于日志、指标、统计与管理接口中标识处理（或处理失败）配置变更的重载器，
+// 优先级高于 NamedReloader.Name()；未设置时重载器使用类型名，处理器使用函数名
+func WithName(name string) RegisterOption {
+	return func(o *registerOptions) {
+		o.name = name
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ConflictPolicy 多个配置源投递同一配置键时的冲突解决策略
+type ConflictPolicy string
+
+const (
+	// ConflictLatest 以变更时间（Change.Timestamp，应由配置源填写）较新的为准
+	ConflictLatest ConflictPolicy = "latest"
+	// ConflictPrecedence 以优先级较高的配置源为准，优先级相同时以变更时间较新的为准
+	ConflictPrecedence ConflictPolicy = "precedence"
+)
+
+// WithSourceOrdering 开启跨配置源的变更排序，防止乱序投递使配置键回退到旧值
+//   - 同一配置源：携带 Sequence 的变更，序号不大于该配置源上次应用的序号时视为过期
+//   - 不同配置源：按 policy 判断；ConflictPrecedence 时 precedence 按优先级从高到低列出配置源，
+//     未列出的配置源优先级最低（需要管理接口覆盖配置时应将 SourceAdminHTTP 等列在前面）
+//
+// 过期或被更高优先级覆盖的变更不会应用，发布 EventChangeSuperseded 事件后直接返回 nil；回滚不受限制
+// 同一配置键的变更从排序检查到应用完成串行执行，并发投递的旧值不会在新值之后生效（处理器内发起的嵌套变更在配置键已被持有时除外）
+func WithSourceOrdering(policy ConflictPolicy, precedence ...string) Option {
+	return func(m *Manager) {
+		if policy == "" {
+			policy = ConflictLatest
+		}
+		m.ordering.policy = policy
+		m.ordering.ranks = make(map[string]int, len(precedence))
+		for i, source := range precedence {
+			if _, ok := m.ordering.ranks[source]; !ok {
+				m.ordering.ranks[source] = len(precedence) - i
+			}
+		}
+	}
+}
+
+// keyOrder 配置键最近一次应用的来源信息
+type keyOrder struct {
+	// 当前值的来源（配置键被删除后为空）
+	source string
+	// 当前值的变更时间
+	timestamp time.Time
+	// 各配置源最近一次应用的序号
+	sequences map[string]uint64
+}
+
+// sourceOrdering 跨配置源的变更排序状态
+type sourceOrdering struct {
+	policy ConflictPolicy
+	// 配置源优先级（数值越大优先级越高，未列出为 0）
+	ranks map[string]int
+	keys  map[string]*keyOrder
+	// 按配置键串行化从排序检查到记录的过程
+	locks map[string]*orderingLock
+
+	mu sync.Mutex
+}
+
+// orderingLock 配置键的排序锁
+type orderingLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// orderingLockKey 标记 context 已持有排序锁，处理器内发起的嵌套变更不再等待排序锁
+type orderingLockKey struct{}
+
+// lock 获取配置键的排序锁
+func (o *sourceOrdering) lock(key string) *orderingLock {
+	o.mu.Lock()
+	if o.locks == nil {
+		o.locks = make(map[string]*orderingLock)
+	}
+	l, ok := o.locks[key]
+	if !ok {
+		l = &orderingLock{}
+		o.locks[key] = l
+	}
+	l.refs++
+	o.mu.Unlock()
+
+	l.mu.Lock()
+	return l
+}
+
+// tryLock 尝试获取配置键的排序锁，已被持有时返回 nil
+func (o *sourceOrdering) tryLock(key string) *orderingLock {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.locks == nil {
+		o.locks = make(map[string]*orderingLock)
+	}
+	l, ok := o.locks[key]
+	if !ok {
+		l = &orderingLock{}
+	}
+	if !l.mu.TryLock() {
+		return nil
+	}
+	o.locks[key] = l
+	l.refs++
+	return l
+}
+
+// unlock 释放配置键的排序锁，没有等待者时移除
+func (o *sourceOrdering) unlock(key string, l *orderingLock) {
+	l.mu.Unlock()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	l.refs--
+	if l.refs == 0 {
+		delete(o.locks, key)
+	}
+}
+
+// superseded 判断配置变更是否过期或被更高优先级的配置源覆盖，返回原因
+func (o *sourceOrdering) superseded(change Change) string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	order, ok := o.keys[change.Key]
+	if !ok {
+		return ""
+	}
+	if change.Sequence > 0 && change.Sequence <= order.sequences[change.Source] {
+		return "stale sequence from the same source"
+	}
+	if order.source == "" || order.source == change.Source {
+		return ""
+	}
+	if o.policy == ConflictPrecedence {
+		current, incoming := o.ranks[order.source], o.ranks[change.Source]
+		if incoming < current {
+			return "value owned by higher precedence source " + order.source
+		}
+		if incoming > current {
+			return ""
+		}
+	}
+	if change.Timestamp.Before(order.timestamp) {
+		return "older than the value applied from source " + order.source
+	}
+	return ""
+}
+
+// record 记录成功应用的配置变更
+func (o *sourceOrdering) record(change Change) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.keys == nil {
+		o.keys = make(map[string]*keyOrder)
+	}
+	order, ok := o.keys[change.Key]
+	if !ok {
+		order = &keyOrder{sequences: make(map[string]uint64)}
+		o.keys[change.Key] = order
+	}
+	if change.Sequence > order.sequences[change.Source] {
+		order.sequences[change.Source] = change.Sequence
+	}
+	// 删除后不再占有配置键，其他配置源的变更可直接应用
+	if change.Deleted {
+		order.source = ""
+		order.timestamp = time.Time{}
+		return
+	}
+	order.source = change.Source
+	order.timestamp = change.Timestamp
+}
+
+// lockOrdering 开启跨配置源排序时获取配置键的排序锁，返回标记了已持有排序锁的 context 与释放函数
+// 锁从排序检查一直持有到变更应用并记录完成，避免两个并发的变更都通过检查后旧值最后生效。
+// 处理器内发起的嵌套变更（context 已持有排序锁）只尝试获取锁，配置键已被持有（包括被外层变更自身持有）时不加锁执行，
+// 只有不持有任何排序锁的变更才会等待，A→B 与 B→A 的嵌套变更不会互相等待而死锁
+func (m *Manager) lockOrdering(ctx context.Context, key string) (context.Context, func()) {
+	if m.ordering.policy == "" {
+		return ctx, func() {}
+	}
+	var l *orderingLock
+	if ctx.Value(orderingLockKey{}) != nil {
+		if l = m.ordering.tryLock(key); l == nil {
+			return ctx, func() {}
+		}
+	} else {
+		l = m.ordering.lock(key)
+		ctx = context.WithValue(ctx, orderingLockKey{}, true)
+	}
+	return ctx, func() { m.ordering.unlock(key, l) }
+}
+
+// checkOrdering 开启跨配置源排序时判断配置变更是否应被丢弃
+func (m *Manager) checkOrdering(change Change, rollbackOf uint64) bool {
+	if m.ordering.policy == "" || rollbackOf != 0 || change.Reloaded || change.Source == SourceProfile {
+		return false
+	}
+	reason := m.ordering.superseded(change)
+	if reason == "" {
+		return false
+	}
+
+	m.logChange(change.Key, "Config change superseded",
+		"key", change.Key,
+		"new_value", change.NewValue,
+		"source", change.Source,
+		"sequence", change.Sequence,
+		"reason", reason)
+	m.publishEvent(Event{
+		Type:           EventChangeSuperseded,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Deleted:        change.Deleted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Error:          reason,
+		Time:           time.Now(),
+	})
+	return true
+}
+
+// recordOrdering 开启跨配置源排序时记录成功应用的配置变更
+func (m *Manager) recordOrdering(change Change) {
+	if m.ordering.policy == "" {
+		return
+	}
+	m.ordering.record(change)
+}
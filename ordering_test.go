@@ -0,0 +1,111 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSourceOrderingSerializesConcurrentChanges(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()), WithSourceOrdering(ConflictLatest))
+	defer m.Close(context.Background())
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	err := m.RegisterHandler("server.timeout", func(key, oldValue, newValue string) error {
+		if newValue == "old" {
+			close(entered)
+			<-release
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	now := time.Now()
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = m.Apply(ctx, Change{Key: "server.timeout", NewValue: "old", Source: "file", Timestamp: now.Add(-time.Minute)})
+	}()
+	<-entered
+	go func() {
+		defer wg.Done()
+		_ = m.Apply(ctx, Change{Key: "server.timeout", NewValue: "new", Source: "nacos", Timestamp: now})
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if applied, _ := m.LastApplied("server.timeout"); applied.Value != "new" {
+		t.Fatalf("LastApplied(server.timeout) = %q, want new", applied.Value)
+	}
+}
+
+func TestSourceOrderingNestedCrossKeyChanges(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()), WithSourceOrdering(ConflictLatest))
+	// 死锁时在途变更无法完成，Close 不能无限等待
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		m.Close(ctx)
+	}()
+
+	var entered sync.WaitGroup
+	entered.Add(2)
+	// 两个配置键的处理器都在对方的排序锁被持有后，对另一个配置键发起嵌套变更
+	nest := func(other string) func(ctx context.Context, change Change) error {
+		return func(ctx context.Context, change Change) error {
+			if change.NewValue == "nested" {
+				return nil
+			}
+			entered.Done()
+			entered.Wait()
+			return m.Apply(ctx, Change{Key: other, NewValue: "nested", Source: "handler", Timestamp: time.Now()})
+		}
+	}
+	if err := m.RegisterChangeHandler("a", nest("b")); err != nil {
+		t.Fatalf("RegisterChangeHandler(a) error = %v", err)
+	}
+	if err := m.RegisterChangeHandler("b", nest("a")); err != nil {
+		t.Fatalf("RegisterChangeHandler(b) error = %v", err)
+	}
+
+	ctx := context.Background()
+	errs := make(chan error, 2)
+	for _, key := range []string{"a", "b"} {
+		go func() {
+			errs <- m.Apply(ctx, Change{Key: key, NewValue: "top", Source: "file", Timestamp: time.Now()})
+		}()
+	}
+	for range 2 {
+		select {
+		case err := <-errs:
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("nested cross-key changes deadlocked")
+		}
+	}
+}
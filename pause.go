@@ -0,0 +1,130 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// pendingChange 暂停期间暂存的配置变更
+type pendingChange struct {
+	change     Change
+	rollbackOf uint64
+}
+
+// Pause 暂停配置变更分发
+// 暂停期间收到的配置变更会被暂存（同一配置键只保留首次的旧值和最新的新值），
+// 调用 Resume 后按首次到达顺序依次应用
+func (m *Manager) Pause() {
+	if m == nil {
+		return
+	}
+
+	m.pauseMu.Lock()
+	defer m.pauseMu.Unlock()
+
+	if m.paused.Load() {
+		return
+	}
+	m.paused.Store(true)
+	m.logger.Info("Config hot reload paused")
+}
+
+// Resume 恢复配置变更分发，并依次应用暂停期间暂存的配置变更
+// 返回所有应用失败的配置变更错误（使用 errors.Join 合并）
+func (m *Manager) Resume() error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+
+	m.pauseMu.Lock()
+	if !m.paused.Load() {
+		m.pauseMu.Unlock()
+		return nil
+	}
+	m.paused.Store(false)
+	pending := m.pending
+	m.pending = nil
+	m.pendingIndex = nil
+	m.pauseMu.Unlock()
+
+	m.logger.Info("Config hot reload resumed", "pending_count", len(pending))
+
+	var errs []error
+	for _, change := range pending {
+		if err := m.dispatch(context.Background(), change.change, change.rollbackOf); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Paused 返回配置变更分发是否已暂停
+func (m *Manager) Paused() bool {
+	if m == nil {
+		return false
+	}
+	return m.paused.Load()
+}
+
+// PendingChanges 返回暂停期间暂存的配置变更数量
+func (m *Manager) PendingChanges() int {
+	if m == nil {
+		return 0
+	}
+
+	m.pauseMu.Lock()
+	defer m.pauseMu.Unlock()
+	return len(m.pending)
+}
+
+// deferIfPaused 暂停期间暂存配置变更，返回是否已暂存
+// 未暂停时只读取原子标志，不争用暂停锁
+func (m *Manager) deferIfPaused(change Change, rollbackOf uint64) bool {
+	if !m.paused.Load() {
+		return false
+	}
+
+	m.pauseMu.Lock()
+	defer m.pauseMu.Unlock()
+
+	if !m.paused.Load() {
+		return false
+	}
+
+	if m.pendingIndex == nil {
+		m.pendingIndex = make(map[string]int)
+	}
+	if idx, ok := m.pendingIndex[change.Key]; ok {
+		// 保留首次的旧值，其余信息以最新的变更为准
+		change.OldValue = m.pending[idx].change.OldValue
+		m.pending[idx] = pendingChange{change: change, rollbackOf: rollbackOf}
+	} else {
+		m.pendingIndex[change.Key] = len(m.pending)
+		m.pending = append(m.pending, pendingChange{change: change, rollbackOf: rollbackOf})
+	}
+
+	m.logChange(change.Key, "Config change deferred while hot reload is paused",
+		"key", change.Key,
+		"new_value", change.NewValue,
+		"source", change.Source,
+		"actor", change.Actor)
+
+	return true
+}
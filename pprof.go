@@ -0,0 +1,71 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"runtime/pprof"
+)
+
+const (
+	// PprofLabelPattern 处理器执行期间设置的 pprof 标签：处理器注册时使用的配置键模式
+	PprofLabelPattern = "hotreload_pattern"
+	// PprofLabelReloader 处理器执行期间设置的 pprof 标签：重载器/处理器名称
+	PprofLabelReloader = "hotreload_reloader"
+)
+
+// WithPprofLabels 设置是否在处理器执行期间附加 pprof 标签（默认开启）
+// 开启后，配置变更风暴期间采集的 CPU profile 可按 hotreload_pattern、hotreload_reloader 标签
+// 将耗时归因到具体的重载器（如 go tool pprof -tagfocus=hotreload_reloader=xxx）
+func WithPprofLabels(enabled bool) Option {
+	return func(m *Manager) {
+		m.pprofLabels = enabled
+	}
+}
+
+// invokeHandler 调用处理器，开启 pprof 标签时在标签作用域内执行
+// 处理器 panic 时恢复并返回 ErrorKindPanic 错误
+func (m *Manager) invokeHandler(ctx context.Context, reg *registration, change Change) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("Config handler panicked",
+				"key", change.Key,
+				"pattern", reg.pattern,
+				"reloader", reg.name,
+				"panic", r,
+				"stack", string(debug.Stack()))
+			err = &ChangeError{Kind: ErrorKindPanic, Err: fmt.Errorf("%v", r)}
+		}
+	}()
+
+	if m.faults != nil {
+		if err := m.faults.BeforeHandler(ctx, change, reg.pattern, reg.name); err != nil {
+			return err
+		}
+	}
+
+	if !m.pprofLabels {
+		return reg.handler(ctx, change)
+	}
+
+	pprof.Do(ctx, reg.labels, func(ctx context.Context) {
+		err = reg.handler(ctx, change)
+	})
+	return err
+}
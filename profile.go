@@ -0,0 +1,339 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// SourceProfile 配置档切换产生的配置变更来源
+const SourceProfile = "profile"
+
+// ErrProfileNotFound 配置档未定义
+var ErrProfileNotFound = errors.New("profile not found")
+
+// ErrProfilePartiallyApplied 配置档切换中的部分变更失败，已生效的覆盖值保留，配置档仍视为已激活
+var ErrProfilePartiallyApplied = errors.New("profile partially applied")
+
+// profileState 配置档定义与当前激活状态
+type profileState struct {
+	// 配置档名称到覆盖配置的映射
+	definitions map[string]map[string]string
+	// 当前激活的配置档（为空表示未激活任何配置档）
+	active string
+	// 被当前激活的配置档覆盖的配置键在覆盖前的值（包括覆盖期间配置源下发的新值）
+	base map[string]profileBase
+	// base 的条目数，分发时无锁判断是否需要检查覆盖
+	overridden atomic.Int64
+	// 配置档切换开关的配置键（见 BindProfileKey）
+	key string
+
+	mu sync.Mutex
+	// 串行化配置档切换
+	switchMu sync.Mutex
+}
+
+// profileBase 配置键被配置档覆盖前的值
+type profileBase struct {
+	value   string
+	source  string
+	present bool
+}
+
+// DefineProfile 定义（或重新定义）命名配置档，如 "normal"、"high-load"、"degraded"
+// 配置档是一组配置键覆盖值；重新定义已激活的配置档在下一次 ActivateProfile 时生效
+func (m *Manager) DefineProfile(name string, overrides map[string]string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if name == "" {
+		return fmt.Errorf("profile name is empty")
+	}
+
+	normalized := make(map[string]string, len(overrides))
+	for key, value := range overrides {
+		normalized[m.NormalizeKey(key)] = value
+	}
+
+	p := &m.profiles
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := normalized[p.key]; ok && p.key != "" {
+		return fmt.Errorf("profile %s must not override profile key %s", name, p.key)
+	}
+	if p.definitions == nil {
+		p.definitions = make(map[string]map[string]string)
+	}
+	p.definitions[name] = normalized
+	return nil
+}
+
+// RemoveProfile 移除配置档定义（不能移除当前激活的配置档）
+func (m *Manager) RemoveProfile(name string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+
+	p := &m.profiles
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.definitions[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	if p.active == name {
+		return fmt.Errorf("profile %s is active", name)
+	}
+	delete(p.definitions, name)
+	return nil
+}
+
+// Profiles 按字典序返回所有已定义的配置档名称
+func (m *Manager) Profiles() []string {
+	if m == nil {
+		return nil
+	}
+
+	p := &m.profiles
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return slices.Sorted(maps.Keys(p.definitions))
+}
+
+// ActiveProfile 返回当前激活的配置档（未激活任何配置档时为空）
+func (m *Manager) ActiveProfile() string {
+	if m == nil {
+		return ""
+	}
+
+	p := &m.profiles
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active
+}
+
+// ActivateProfile 激活配置档：将其所有覆盖值作为一个批次经完整的分发流程应用（见 ApplyBatch），
+// 先前配置档覆盖、而新配置档未覆盖的配置键恢复为覆盖前的值；name 为空时取消激活并恢复所有被覆盖的配置键。
+// 配置档激活期间，配置源对被覆盖配置键的变更不会生效，而是记录为恢复时使用的值；
+// 回滚与管理接口的变更视为操作人员的明确意图，照常生效并同时作为恢复时使用的值。
+// 部分变更失败时返回包装了 ErrProfilePartiallyApplied 与各变更错误的错误，ActiveProfile 仍返回 name
+func (m *Manager) ActivateProfile(ctx context.Context, name string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	p := &m.profiles
+	p.switchMu.Lock()
+	defer p.switchMu.Unlock()
+
+	p.mu.Lock()
+	overrides, ok := p.definitions[name]
+	if name != "" && !ok {
+		p.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+	previous := p.active
+
+	var changes []Change
+	restored := make(map[string]profileBase)
+	added := make(map[string]struct{})
+	// 恢复不再被覆盖的配置键
+	for key, base := range p.base {
+		if _, ok := overrides[key]; ok {
+			continue
+		}
+		current, _ := m.store.get(key)
+		changes = append(changes, Change{
+			Key:      key,
+			OldValue: current.Value,
+			NewValue: base.value,
+			Deleted:  !base.present,
+			Source:   base.source,
+		})
+		restored[key] = base
+		delete(p.base, key)
+	}
+	// 应用新配置档的覆盖值
+	if len(overrides) > 0 && p.base == nil {
+		p.base = make(map[string]profileBase, len(overrides))
+	}
+	for key, value := range overrides {
+		current, present := m.store.get(key)
+		if _, tracked := p.base[key]; !tracked {
+			p.base[key] = profileBase{value: current.Value, source: current.Source, present: present}
+			added[key] = struct{}{}
+		}
+		if present && current.Value == value {
+			continue
+		}
+		changes = append(changes, Change{
+			Key:            key,
+			OldValue:       current.Value,
+			NewValue:       value,
+			Source:         SourceProfile,
+			SourceRevision: name,
+		})
+	}
+	p.overridden.Store(int64(len(p.base)))
+	p.active = name
+	p.mu.Unlock()
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		switch {
+		case a.Key < b.Key:
+			return -1
+		case a.Key > b.Key:
+			return 1
+		}
+		return 0
+	})
+
+	m.logger.Info("Activating config profile",
+		"profile", name,
+		"previous_profile", previous,
+		"key_count", len(changes))
+	m.publishEvent(Event{
+		Type:     EventProfileActivated,
+		OldValue: previous,
+		NewValue: name,
+		Actor:    ActorFromContext(ctx),
+	})
+	if len(changes) == 0 {
+		return nil
+	}
+	err := m.ApplyBatch(ctx, changes)
+	if err != nil {
+		m.releaseFailedProfileChanges(err, changes, overrides, restored, added)
+		return fmt.Errorf("%w: %s: %w", ErrProfilePartiallyApplied, name, err)
+	}
+	return nil
+}
+
+// releaseFailedProfileChanges 配置档切换中的部分变更失败时回滚对应配置键的覆盖状态：
+// 未生效的覆盖值不再拦截配置源的变更，未能恢复的配置键继续被拦截，下一次切换时再次恢复
+func (m *Manager) releaseFailedProfileChanges(err error, changes []Change, overrides map[string]string, restored map[string]profileBase, added map[string]struct{}) {
+	failed, unattributed := failedChangeKeys(err)
+
+	p := &m.profiles
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, change := range changes {
+		if _, ok := failed[change.Key]; !ok && !unattributed {
+			continue
+		}
+		if _, ok := overrides[change.Key]; ok {
+			if _, ok := added[change.Key]; ok {
+				delete(p.base, change.Key)
+			}
+			continue
+		}
+		if _, held := p.base[change.Key]; !held {
+			p.base[change.Key] = restored[change.Key]
+		}
+	}
+	p.overridden.Store(int64(len(p.base)))
+}
+
+// failedChangeKeys 收集合并错误中各 ChangeError 的配置键，存在无法对应到配置键的错误时 unattributed 为 true
+func failedChangeKeys(err error) (keys map[string]struct{}, unattributed bool) {
+	keys = make(map[string]struct{})
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, err := range errs {
+		var cerr *ChangeError
+		if !errors.As(err, &cerr) || cerr.Key == "" {
+			unattributed = true
+			continue
+		}
+		keys[cerr.Key] = struct{}{}
+	}
+	return keys, unattributed
+}
+
+// BindProfileKey 将配置键绑定为配置档切换开关：该配置键的值即为要激活的配置档名称，
+// 删除或置空时取消激活，从而通过推送一个配置键完成整套配置的切换（如故障应急时切换到 "degraded"）
+func (m *Manager) BindProfileKey(key string) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if key == "" {
+		return fmt.Errorf("profile key is empty")
+	}
+
+	key = m.NormalizeKey(key)
+	p := &m.profiles
+	p.mu.Lock()
+	for name, overrides := range p.definitions {
+		if _, ok := overrides[key]; ok {
+			p.mu.Unlock()
+			return fmt.Errorf("profile %s must not override profile key %s", name, key)
+		}
+	}
+	p.key = key
+	p.mu.Unlock()
+
+	return m.RegisterChangeHandler(key, func(ctx context.Context, change Change) error {
+		// 配置档切换中的各个变更不应受开关变更处理的超时或取消影响
+		return m.ActivateProfile(context.WithoutCancel(ctx), change.NewValue)
+	}, WithName("profile-switch"))
+}
+
+// holdProfileOverride 配置键被激活的配置档覆盖时拦截其他来源的变更，记录为恢复时使用的值
+// 回滚与管理接口的变更不拦截，只记录为恢复时使用的值
+func (m *Manager) holdProfileOverride(change Change) bool {
+	p := &m.profiles
+	if p.overridden.Load() == 0 || change.Source == SourceProfile {
+		return false
+	}
+
+	p.mu.Lock()
+	_, held := p.base[change.Key]
+	if held {
+		p.base[change.Key] = profileBase{value: change.NewValue, source: change.Source, present: !change.Deleted}
+	}
+	active := p.active
+	p.mu.Unlock()
+	if !held {
+		return false
+	}
+	switch change.Source {
+	case SourceRollback, SourceAdminHTTP, SourceAdminGRPC:
+		m.logChange(change.Key, "Config change overrides active profile",
+			"key", change.Key,
+			"new_value", change.NewValue,
+			"source", change.Source,
+			"profile", active)
+		return false
+	}
+
+	m.logChange(change.Key, "Config change held by active profile",
+		"key", change.Key,
+		"new_value", change.NewValue,
+		"source", change.Source,
+		"profile", active)
+	return true
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestActivateProfileReleasesFailedOverride(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+	err := m.RegisterHandler("server.*", func(key, oldValue, newValue string) error {
+		if newValue == "invalid" {
+			return errors.New("invalid value")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	ctx := context.Background()
+	for key, value := range map[string]string{"server.workers": "8", "server.timeout": "10s"} {
+		if err := m.Apply(ctx, Change{Key: key, NewValue: value, Source: "nacos"}); err != nil {
+			t.Fatalf("Apply(%s) error = %v", key, err)
+		}
+	}
+	if err := m.DefineProfile("high-load", map[string]string{
+		"server.workers": "32",
+		"server.timeout": "invalid",
+	}); err != nil {
+		t.Fatalf("DefineProfile() error = %v", err)
+	}
+	if err := m.ActivateProfile(ctx, "high-load"); !errors.Is(err, ErrProfilePartiallyApplied) {
+		t.Fatalf("ActivateProfile() error = %v, want ErrProfilePartiallyApplied", err)
+	}
+	if active := m.ActiveProfile(); active != "high-load" {
+		t.Fatalf("ActiveProfile() = %q, want high-load", active)
+	}
+
+	// 未生效的覆盖值不再拦截配置源的变更
+	if err := m.Apply(ctx, Change{Key: "server.timeout", NewValue: "20s", Source: "nacos"}); err != nil {
+		t.Fatalf("Apply(server.timeout) error = %v", err)
+	}
+	if applied, _ := m.LastApplied("server.timeout"); applied.Value != "20s" {
+		t.Fatalf("LastApplied(server.timeout) = %q, want 20s", applied.Value)
+	}
+
+	// 已生效的覆盖值仍然拦截配置源的变更，取消激活时恢复为配置源的最新值
+	if err := m.Apply(ctx, Change{Key: "server.workers", NewValue: "16", Source: "nacos"}); err != nil {
+		t.Fatalf("Apply(server.workers) error = %v", err)
+	}
+	if applied, _ := m.LastApplied("server.workers"); applied.Value != "32" {
+		t.Fatalf("LastApplied(server.workers) = %q, want 32", applied.Value)
+	}
+	if err := m.ActivateProfile(ctx, ""); err != nil {
+		t.Fatalf("ActivateProfile(\"\") error = %v", err)
+	}
+	for key, want := range map[string]string{"server.workers": "16", "server.timeout": "20s"} {
+		if applied, _ := m.LastApplied(key); applied.Value != want {
+			t.Fatalf("LastApplied(%s) = %q, want %q", key, applied.Value, want)
+		}
+	}
+}
+
+func TestActivateProfileLetsOperatorChangesThrough(t *testing.T) {
+	m := NewManager(WithLogger(NopLogger()))
+	defer m.Close(context.Background())
+	if err := m.RegisterHandler("server.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	ctx := context.Background()
+	for key, value := range map[string]string{"server.workers": "8", "server.timeout": "10s"} {
+		if err := m.Apply(ctx, Change{Key: key, NewValue: value, Source: "nacos"}); err != nil {
+			t.Fatalf("Apply(%s) error = %v", key, err)
+		}
+	}
+	if err := m.DefineProfile("high-load", map[string]string{
+		"server.workers": "32",
+		"server.timeout": "30s",
+	}); err != nil {
+		t.Fatalf("DefineProfile() error = %v", err)
+	}
+	if err := m.ActivateProfile(ctx, "high-load"); err != nil {
+		t.Fatalf("ActivateProfile() error = %v", err)
+	}
+
+	// 管理接口的变更照常生效
+	if err := m.Apply(ctx, Change{Key: "server.workers", NewValue: "64", Source: SourceAdminHTTP}); err != nil {
+		t.Fatalf("Apply(server.workers) error = %v", err)
+	}
+	if applied, _ := m.LastApplied("server.workers"); applied.Value != "64" {
+		t.Fatalf("LastApplied(server.workers) = %q, want 64", applied.Value)
+	}
+
+	// 回滚配置档的覆盖值照常生效
+	overridden, _ := m.LastApplied("server.timeout")
+	if err := m.Rollback(ctx, overridden.Revision); err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if applied, _ := m.LastApplied("server.timeout"); applied.Value != "10s" {
+		t.Fatalf("LastApplied(server.timeout) = %q, want 10s", applied.Value)
+	}
+
+	// 取消激活时保留操作人员的值
+	if err := m.ActivateProfile(ctx, ""); err != nil {
+		t.Fatalf("ActivateProfile(\"\") error = %v", err)
+	}
+	for key, want := range map[string]string{"server.workers": "64", "server.timeout": "10s"} {
+		if applied, _ := m.LastApplied(key); applied.Value != want {
+			t.Fatalf("LastApplied(%s) = %q, want %q", key, applied.Value, want)
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited 配置键的变更频率超过限制
+var ErrRateLimited = errors.New("change rate limit exceeded")
+
+// RateLimitPolicy 超出变更频率限制时的处理策略
+type RateLimitPolicy string
+
+const (
+	// RateLimitCoalesce 合并超出限制的变更：只保留首次的旧值与最新的新值，窗口内有余量时再应用（默认）
+	RateLimitCoalesce RateLimitPolicy = "coalesce"
+	// RateLimitReject 拒绝超出限制的变更，返回 ErrorKindRateLimited 错误
+	RateLimitReject RateLimitPolicy = "reject"
+)
+
+// RateLimitRule 变更频率限制规则：每个 Window 窗口内同一配置键最多应用 Limit 次
+type RateLimitRule struct {
+	// 窗口内最多应用的次数
+	Limit int
+	// 滑动窗口
+	Window time.Duration
+	// 超出限制时的处理策略（默认 RateLimitCoalesce）
+	Policy RateLimitPolicy
+}
+
+// minStateSweep 按配置键保存的状态数达到该值后才开始清理过期状态
+const minStateSweep = 64
+
+// rateLimitRoute 按模式配置的变更频率限制规则
+type rateLimitRoute struct {
+	pattern string
+	rule    RateLimitRule
+}
+
+// WithRateLimit 为匹配 pattern 的配置键设置变更频率限制
+// 用于保护代价高昂的重载器（如重建连接池、重启监听器）免受失控发布方的冲击，
+// 多条规则按添加顺序匹配第一条；被合并或拒绝的变更会发布 EventChangeRateLimited 事件
+func WithRateLimit(pattern string, rule RateLimitRule) Option {
+	return func(m *Manager) {
+		if pattern == "" || rule.Limit <= 0 || rule.Window <= 0 {
+			return
+		}
+		if rule.Policy == "" {
+			rule.Policy = RateLimitCoalesce
+		}
+		m.rateLimiter.routes = append(m.rateLimiter.routes, rateLimitRoute{
+			pattern: pattern,
+			rule:    rule,
+		})
+	}
+}
+
+// rateLimitState 单个配置键的限流状态
+type rateLimitState struct {
+	// 窗口内已应用的时间（按时间先后）
+	admitted []time.Time
+	// 等待合并应用的变更
+	pending *pendingChange
+	// 合并变更的应用定时器
+	timer *time.Timer
+}
+
+// rateLimiter 按配置键的变更频率限制器
+type rateLimiter struct {
+	routes []rateLimitRoute
+	states map[string]*rateLimitState
+	// 状态数达到该值时清理过期状态，清理后翻倍，均摊开销为常数
+	sweepAt int
+	closed  bool
+
+	mu sync.Mutex
+}
+
+// rateLimitFlushKey 标记合并变更的再次分发（已计入窗口）
+type rateLimitFlushKey struct{}
+
+// rule 返回配置键匹配的限流规则
+func (l *rateLimiter) rule(key string) *RateLimitRule {
+	for i := range l.routes {
+		if l.routes[i].pattern == "*" || matchPattern(l.routes[i].pattern, key) {
+			return &l.routes[i].rule
+		}
+	}
+	return nil
+}
+
+// prune 移除窗口外的应用记录
+func (s *rateLimitState) prune(now time.Time, window time.Duration) {
+	n := 0
+	for n < len(s.admitted) && now.Sub(s.admitted[n]) >= window {
+		n++
+	}
+	if n > 0 {
+		s.admitted = append(s.admitted[:0], s.admitted[n:]...)
+	}
+}
+
+// admitChange 按变更频率限制判断配置变更是否立即应用
+// 返回 false 且错误为 nil 表示变更已合并，稍后自动应用
+func (m *Manager) admitChange(ctx context.Context, change Change, rollbackOf uint64) (bool, error) {
+	l := &m.rateLimiter
+	if len(l.routes) == 0 || ctx.Value(rateLimitFlushKey{}) != nil {
+		return true, nil
+	}
+	rule := l.rule(change.Key)
+	if rule == nil {
+		return true, nil
+	}
+
+	now := time.Now()
+	l.mu.Lock()
+	if l.states == nil {
+		l.states = make(map[string]*rateLimitState)
+	}
+	state, ok := l.states[change.Key]
+	if !ok {
+		if len(l.states) >= l.sweepAt {
+			l.sweepLocked(now)
+			l.sweepAt = max(2*len(l.states), minStateSweep)
+		}
+		state = &rateLimitState{}
+		l.states[change.Key] = state
+	}
+	state.prune(now, rule.Window)
+
+	// 已有等待合并的变更时并入其中，保证按到达顺序生效
+	if state.pending != nil {
+		change.OldValue = state.pending.change.OldValue
+		state.pending = &pendingChange{change: change, rollbackOf: rollbackOf}
+		l.mu.Unlock()
+		m.rateLimited(change, nil)
+		return false, nil
+	}
+	if len(state.admitted) < rule.Limit {
+		state.admitted = append(state.admitted, now)
+		l.mu.Unlock()
+		return true, nil
+	}
+
+	if rule.Policy == RateLimitReject {
+		l.mu.Unlock()
+		cerr := &ChangeError{Kind: ErrorKindRateLimited, Key: change.Key, Err: ErrRateLimited}
+		m.counters.recordFailure(ErrorKindRateLimited)
+		m.rateLimited(change, cerr)
+		return false, cerr
+	}
+
+	key := change.Key
+	state.pending = &pendingChange{change: change, rollbackOf: rollbackOf}
+	state.timer = time.AfterFunc(state.admitted[0].Add(rule.Window).Sub(now), func() {
+		m.flushRateLimited(key)
+	})
+	l.mu.Unlock()
+	m.rateLimited(change, nil)
+	return false, nil
+}
+
+// sweepLocked 移除窗口已过期且没有等待合并变更的状态，避免不再变更的配置键长期占用内存
+func (l *rateLimiter) sweepLocked(now time.Time) {
+	for key, state := range l.states {
+		if state.pending != nil {
+			continue
+		}
+		if rule := l.rule(key); rule != nil {
+			state.prune(now, rule.Window)
+			if len(state.admitted) > 0 {
+				continue
+			}
+		}
+		delete(l.states, key)
+	}
+}
+
+// flushRateLimited 窗口有余量后应用等待合并的变更
+func (m *Manager) flushRateLimited(key string) {
+	l := &m.rateLimiter
+	l.mu.Lock()
+	state, ok := l.states[key]
+	if !ok || state.pending == nil || l.closed {
+		l.mu.Unlock()
+		return
+	}
+	pending := *state.pending
+	state.pending = nil
+	state.timer = nil
+	now := time.Now()
+	if rule := l.rule(key); rule != nil {
+		state.prune(now, rule.Window)
+	}
+	state.admitted = append(state.admitted, now)
+	l.mu.Unlock()
+
+	ctx := context.WithValue(context.Background(), rateLimitFlushKey{}, struct{}{})
+	if err := m.dispatch(ctx, pending.change, pending.rollbackOf); err != nil {
+		m.logger.Warn("Failed to apply coalesced config change",
+			"key", key,
+			"error", err)
+	}
+}
+
+// rateLimited 记录被合并（err 为 nil）或拒绝的配置变更
+func (m *Manager) rateLimited(change Change, err *ChangeError) {
+	event := Event{
+		Type:           EventChangeRateLimited,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Deleted:        change.Deleted,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		ErrorKind:      ErrorKindRateLimited,
+		Time:           time.Now(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+		m.logger.Warn("Config change rejected by rate limit",
+			"key", change.Key,
+			"new_value", change.NewValue,
+			"source", change.Source,
+			"actor", change.Actor)
+	} else {
+		event.Error = "coalesced until the rate limit window allows"
+		m.logChange(change.Key, "Config change coalesced by rate limit",
+			"key", change.Key,
+			"new_value", change.NewValue,
+			"source", change.Source,
+			"actor", change.Actor)
+	}
+	m.publishEvent(event)
+}
+
+// close 停止所有合并定时器，返回被丢弃的等待合并的变更数
+func (l *rateLimiter) close() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closed = true
+	dropped := 0
+	for _, state := range l.states {
+		if state.timer != nil {
+			state.timer.Stop()
+			state.timer = nil
+		}
+		if state.pending != nil {
+			state.pending = nil
+			dropped++
+		}
+	}
+	return dropped
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterSweepsExpiredStates(t *testing.T) {
+	const window = 20 * time.Millisecond
+	m := NewManager(WithLogger(NopLogger()), WithRateLimit("app.*", RateLimitRule{Limit: 1, Window: window}))
+	defer m.Close(context.Background())
+
+	apply := func(key string) {
+		t.Helper()
+		if err := m.Apply(context.Background(), Change{Key: key, NewValue: "v"}); err != nil {
+			t.Fatalf("Apply(%q) error = %v", key, err)
+		}
+	}
+	for i := range minStateSweep {
+		apply(fmt.Sprintf("app.old%d", i))
+	}
+	time.Sleep(2 * window)
+	for i := range 2 * minStateSweep {
+		apply(fmt.Sprintf("app.new%d", i))
+	}
+
+	m.rateLimiter.mu.Lock()
+	defer m.rateLimiter.mu.Unlock()
+	for key := range m.rateLimiter.states {
+		if strings.HasPrefix(key, "app.old") {
+			t.Fatalf("expired state for %q not swept", key)
+		}
+	}
+	if _, ok := m.rateLimiter.states["app.new0"]; !ok {
+		t.Fatal("state within the window was swept")
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StateFetcher 可拉取权威配置状态的配置源
+// 对账时以 Fetch 返回的扁平化配置（配置键 -> 值）为准，修正因遗漏监听事件而过期的配置
+type StateFetcher interface {
+	// Fetch 拉取配置源当前的全部配置
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// ReconcileResult 一次对账的结果
+type ReconcileResult struct {
+	// 配置源名称（即其投递变更的 Source）
+	Source string `json:"source"`
+	// 比较的配置键数
+	Checked int `json:"checked"`
+	// 发生漂移并重新分发的配置键（按字典序）
+	Drifted []string `json:"drifted,omitempty"`
+	// 对账耗时
+	Duration time.Duration `json:"duration"`
+}
+
+// WithReconciliation 开启周期性对账（默认关闭）
+// 每隔 interval 对通过 StartSources、AddSource 等交由管理器管理且实现 StateFetcher 的配置源执行一次 Reconcile，
+// 配置源名称需与其投递变更时使用的 Source 一致（见 StartSources 的命名规则）
+func WithReconciliation(interval time.Duration) Option {
+	return func(m *Manager) {
+		m.reconcileInterval = interval
+	}
+}
+
+// Reconcile 以配置源的权威状态对账：拉取 fetcher 的全部配置，与已应用的配置比较，
+// 将值不一致或缺失的配置键（仅限有处理器匹配的）、以及已从配置源删除的配置键作为来自 source 的变更重新分发。
+// 只比较最近一次由 source 应用的配置键，其他来源（如管理接口）覆盖的配置键不受影响；
+// 从快照回退应用（SourceSnapshotFallback）的配置键视为回退规则中提供该前缀的配置源所有，没有规则覆盖时只按 source 的值修正、不删除；
+// 漂移的配置键通过 ApplyBatch 一次性应用并发布 EventDriftDetected 事件
+func (m *Manager) Reconcile(ctx context.Context, source string, fetcher StateFetcher) (ReconcileResult, error) {
+	if m == nil || fetcher == nil {
+		return ReconcileResult{}, fmt.Errorf("manager or state fetcher is nil")
+	}
+	if m.Closed() {
+		return ReconcileResult{}, ErrManagerClosed
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	start := time.Now()
+	result := ReconcileResult{Source: source}
+	state, err := fetcher.Fetch(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to fetch state from config source %s: %w", source, err)
+	}
+
+	authoritative := make(map[string]string, len(state))
+	for key, value := range state {
+		authoritative[m.NormalizeKey(key)] = value
+	}
+	applied := make(map[string]AppliedValue)
+	for _, value := range m.store.list() {
+		applied[value.Key] = value
+	}
+
+	var changes []Change
+	for key, value := range authoritative {
+		current, ok := applied[key]
+		if ok && current.Source != source {
+			if current.Source != SourceSnapshotFallback {
+				continue
+			}
+			if owned, known := m.fallbackOwner(key, source); known && !owned {
+				continue
+			}
+		}
+		if !ok && len(m.match(key, nil)) == 0 {
+			continue
+		}
+		result.Checked++
+		if ok && current.Value == value {
+			continue
+		}
+		changes = append(changes, Change{Key: key, OldValue: current.Value, NewValue: value, Source: source})
+	}
+	for key, current := range applied {
+		if current.Source != source {
+			if current.Source != SourceSnapshotFallback {
+				continue
+			}
+			if owned, _ := m.fallbackOwner(key, source); !owned {
+				continue
+			}
+		}
+		if _, ok := authoritative[key]; ok {
+			continue
+		}
+		result.Checked++
+		changes = append(changes, Change{Key: key, OldValue: current.Value, Deleted: true, Source: source})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	for _, change := range changes {
+		result.Drifted = append(result.Drifted, change.Key)
+		m.logger.Warn("Config drift detected",
+			"key", change.Key,
+			"source", source,
+			"applied_value", change.OldValue,
+			"source_value", change.NewValue,
+			"deleted", change.Deleted)
+		m.publishEvent(Event{
+			Type:     EventDriftDetected,
+			Key:      change.Key,
+			OldValue: change.OldValue,
+			NewValue: change.NewValue,
+			Deleted:  change.Deleted,
+			Source:   source,
+			Time:     time.Now(),
+		})
+	}
+
+	if len(changes) > 0 {
+		err = m.ApplyBatch(ctx, changes)
+	}
+	result.Duration = time.Since(start)
+	return result, err
+}
+
+// ReconcileSources 对所有交由管理器管理且实现 StateFetcher 的配置源执行一次对账
+// 返回每个配置源的对账结果以及所有失败的合并错误
+func (m *Manager) ReconcileSources(ctx context.Context) ([]ReconcileResult, error) {
+	if m == nil {
+		return nil, fmt.Errorf("manager is nil")
+	}
+
+	m.lifecycle.sourcesMu.Lock()
+	sources := append([]managedSource(nil), m.lifecycle.sources...)
+	m.lifecycle.sourcesMu.Unlock()
+
+	var results []ReconcileResult
+	var errs []error
+	for _, entry := range sources {
+		fetcher, ok := entry.source.(StateFetcher)
+		if !ok {
+			continue
+		}
+		result, err := m.Reconcile(ctx, entry.name, fetcher)
+		results = append(results, result)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// startReconciler 开启周期性对账时启动后台对账循环，管理器关闭时退出
+func (m *Manager) startReconciler() {
+	if m.reconcileInterval <= 0 {
+		return
+	}
+
+	interval := m.reconcileInterval
+	m.goBackground(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.lifecycle.done:
+				return
+			case <-ticker.C:
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			results, err := m.ReconcileSources(ctx)
+			cancel()
+			if err != nil && !errors.Is(err, ErrManagerClosed) {
+				m.logger.Warn("Config reconciliation failed", "error", err)
+			}
+			for _, result := range results {
+				if len(result.Drifted) > 0 {
+					m.logger.Info("Config reconciliation repaired drifted keys",
+						"source", result.Source,
+						"drifted_count", len(result.Drifted),
+						"checked", result.Checked)
+				}
+			}
+		}
+	})
+}
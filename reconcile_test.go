@@ -0,0 +1,91 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"testing"
+)
+
+// memorySnapshotStore 内存中的快照存储
+type memorySnapshotStore struct {
+	values map[string]string
+	mu     sync.Mutex
+}
+
+func (s *memorySnapshotStore) Load(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return maps.Clone(s.values), nil
+}
+
+func (s *memorySnapshotStore) Save(ctx context.Context, values map[string]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values = maps.Clone(values)
+	return nil
+}
+
+// stateFetcher 返回固定配置的 StateFetcher
+type stateFetcher map[string]string
+
+func (f stateFetcher) Fetch(ctx context.Context) (map[string]string, error) {
+	return f, nil
+}
+
+func TestReconcileCorrectsFallbackKeys(t *testing.T) {
+	store := &memorySnapshotStore{values: map[string]string{
+		"app.timeout": "10s",
+		"app.retired": "on",
+		"db.pool":     "4",
+	}}
+	m := NewManager(WithLogger(NopLogger()),
+		WithSnapshotStore(store),
+		WithSnapshotFallback(FallbackRule{Prefix: "app", Sources: []string{"nacos"}}),
+		WithSnapshotFallback(FallbackRule{Prefix: "db", Sources: []string{"etcd"}}))
+	defer m.Close(context.Background())
+	for _, pattern := range []string{"app.*", "db.*"} {
+		if err := m.RegisterHandler(pattern, func(key, oldValue, newValue string) error { return nil }); err != nil {
+			t.Fatalf("RegisterHandler(%s) error = %v", pattern, err)
+		}
+	}
+
+	ctx := context.Background()
+	if _, err := m.ApplyFallback(ctx, ""); err != nil {
+		t.Fatalf("ApplyFallback() error = %v", err)
+	}
+	result, err := m.Reconcile(ctx, "nacos", stateFetcher{"app.timeout": "30s", "db.pool": "16"})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if len(result.Drifted) != 2 {
+		t.Fatalf("Drifted = %v, want [app.retired app.timeout]", result.Drifted)
+	}
+
+	if applied, _ := m.LastApplied("app.timeout"); applied.Value != "30s" || applied.Source != "nacos" {
+		t.Fatalf("LastApplied(app.timeout) = %q from %q, want 30s from nacos", applied.Value, applied.Source)
+	}
+	if _, ok := m.LastApplied("app.retired"); ok {
+		t.Fatal("LastApplied(app.retired) found a key deleted from its owning source")
+	}
+	// 其他配置源回退的配置键不受影响
+	if applied, _ := m.LastApplied("db.pool"); applied.Value != "4" || applied.Source != SourceSnapshotFallback {
+		t.Fatalf("LastApplied(db.pool) = %q from %q, want 4 from %s", applied.Value, applied.Source, SourceSnapshotFallback)
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// RegistrationKind 处理器登记类型
+type RegistrationKind string
+
+const (
+	// RegistrationReloader 通过 RegisterReloader 注册的重载器
+	RegistrationReloader RegistrationKind = "reloader"
+	// RegistrationHandler 通过 RegisterHandler/RegisterChangeHandler 注册的处理器
+	RegistrationHandler RegistrationKind = "handler"
+	// RegistrationFieldSetter 通过 SetFieldSetter 设置的字段设置器
+	RegistrationFieldSetter RegistrationKind = "field_setter"
+)
+
+// Registration 处理器登记信息
+type Registration struct {
+	// 配置键模式（字段设置器为允许的前缀加通配符，如 "server.features.*"）
+	Pattern string `json:"pattern"`
+	// 重载器/处理器名称
+	Name string `json:"name"`
+	// 登记类型
+	Kind RegistrationKind `json:"kind"`
+	// 注册位置（file:line）
+	Site string `json:"site,omitempty"`
+	// 是否处于隔离状态
+	Quarantined bool `json:"quarantined"`
+	// 执行方式
+	Mode ExecutionMode `json:"mode"`
+}
+
+// Registrations 返回所有已注册的配置键模式及其处理器，按模式、注册顺序排序
+// 用于回答“这个服务里哪些配置键支持热加载”
+func (m *Manager) Registrations() []Registration {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	regs := make([]*registration, 0)
+	for _, list := range m.handlers {
+		regs = append(regs, list...)
+	}
+	fieldTargets := m.fieldTargetsLocked()
+	m.mu.RUnlock()
+
+	sort.SliceStable(regs, func(i, j int) bool {
+		if regs[i].pattern != regs[j].pattern {
+			return regs[i].pattern < regs[j].pattern
+		}
+		return regs[i].seq < regs[j].seq
+	})
+
+	result := make([]Registration, 0, len(regs)+len(fieldTargets))
+	for _, reg := range regs {
+		result = append(result, Registration{
+			Pattern:     reg.pattern,
+			Name:        reg.name,
+			Kind:        reg.kind,
+			Site:        reg.site,
+			Quarantined: reg.quarantined.Load(),
+			Mode:        reg.mode(),
+		})
+	}
+
+	for _, target := range fieldTargets {
+		for _, prefix := range target.allowedPrefixes {
+			pattern := prefix
+			if !strings.HasSuffix(pattern, "*") {
+				pattern = strings.TrimSuffix(pattern, ".") + ".*"
+			}
+			result = append(result, Registration{
+				Pattern:     pattern,
+				Name:        target.reg.name,
+				Kind:        RegistrationFieldSetter,
+				Site:        target.reg.site,
+				Quarantined: target.reg.quarantined.Load(),
+				Mode:        ExecutionSerial,
+			})
+		}
+	}
+
+	return result
+}
+
+// callerSite 返回调用注册方法的位置（file:line）
+// skip 为相对于 callerSite 调用者的栈帧数
+func callerSite(skip int) string {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d", file, line)
+}
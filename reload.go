@@ -0,0 +1,79 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// reloadContextKey 标记由 ReloadPrefix 发起的重新分发的 context 键
+// Change.Reloaded 由调用方控制，只有携带该标记的分发才会保留它（从而跳过授权与顺序检查）
+type reloadContextKey struct{}
+
+// ReloadAll 以当前已应用的值重新分发所有配置键，强制匹配的处理器重新应用
+// 适用于重载器重新注册、解除隔离或依赖的资源被重建之后；返回重新分发的配置键数以及所有失败的合并错误
+func (m *Manager) ReloadAll(ctx context.Context) (int, error) {
+	return m.ReloadPrefix(ctx, "")
+}
+
+// ReloadPrefix 以当前已应用的值重新分发前缀下的配置键（prefix 为空时为所有配置键）
+// 变更的旧值与新值均为当前值，来源与配置源修订号沿用最近一次应用时的记录，并标记 Change.Reloaded（跳过授权与顺序检查）；
+// 所有配置键通过 ApplyBatch 一次性分发
+func (m *Manager) ReloadPrefix(ctx context.Context, prefix string) (int, error) {
+	if m == nil {
+		return 0, fmt.Errorf("manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	prefix = m.NormalizeKey(strings.TrimSuffix(prefix, "."))
+	var changes []Change
+	for _, applied := range m.store.list() {
+		if !underPrefix(applied.Key, prefix) {
+			continue
+		}
+		changes = append(changes, Change{
+			Key:            applied.Key,
+			OldValue:       applied.Value,
+			NewValue:       applied.Value,
+			Source:         applied.Source,
+			SourceRevision: applied.SourceRevision,
+			Defaulted:      applied.Defaulted,
+			Reloaded:       true,
+		})
+	}
+	if len(changes) == 0 {
+		return 0, nil
+	}
+
+	m.logger.Info("Reloading applied config values", "prefix", prefix, "key_count", len(changes))
+	return len(changes), m.ApplyBatch(context.WithValue(ctx, reloadContextKey{}, true), changes)
+}
+
+// verifyReloaded 仅信任由 ReloadPrefix 发起的 Change.Reloaded，调用方自行设置的标记会被清除
+// 返回的 context 不再携带重新分发标记，处理器内部发起的配置变更不会继承它
+func verifyReloaded(ctx context.Context, change *Change) context.Context {
+	reloading, _ := ctx.Value(reloadContextKey{}).(bool)
+	if !reloading {
+		change.Reloaded = false
+		return ctx
+	}
+	return context.WithValue(ctx, reloadContextKey{}, false)
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package allowlist 提供 IP/API Key 允许与拒绝名单重载器
+//
+// 配置键（默认前缀 "access"，列表值支持 JSON 数组或逗号分隔文本）：
+//
+//	access.allow_ips      允许的 IP 或 CIDR（为空表示不按 IP 限制）
+//	access.deny_ips       拒绝的 IP 或 CIDR（优先于允许名单）
+//	access.api_keys       允许的 API Key（为空表示不按 API Key 限制）
+//	access.deny_api_keys  拒绝的 API Key（优先于允许名单）
+//
+// 名单在变更时整体重建并原子替换，查询无锁，适合在中间件中对每个请求调用
+package allowlist
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-anyway/framework-hotreload/internal/values"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "access"
+
+// ipSet IP 集合
+type ipSet struct {
+	addrs    map[netip.Addr]struct{}
+	prefixes []netip.Prefix
+}
+
+// contains 返回集合是否包含 addr
+func (s *ipSet) contains(addr netip.Addr) bool {
+	if _, ok := s.addrs[addr]; ok {
+		return true
+	}
+	for _, prefix := range s.prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// empty 返回集合是否为空
+func (s *ipSet) empty() bool {
+	return len(s.addrs) == 0 && len(s.prefixes) == 0
+}
+
+// lists 不可修改的名单
+type lists struct {
+	allowIPs     ipSet
+	denyIPs      ipSet
+	allowAPIKeys map[string]struct{}
+	denyAPIKeys  map[string]struct{}
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "access"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// Reloader 允许与拒绝名单重载器
+type Reloader struct {
+	prefix string
+
+	// 串行化配置变更
+	mu    sync.Mutex
+	lists atomic.Pointer[lists]
+}
+
+// New 创建名单重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	r.lists.Store(&lists{})
+	return r
+}
+
+// AllowAddr 返回是否允许该 IP 访问：不在拒绝名单中，且允许名单为空或包含该 IP
+func (r *Reloader) AllowAddr(addr netip.Addr) bool {
+	l := r.lists.Load()
+	addr = addr.Unmap()
+	if l.denyIPs.contains(addr) {
+		return false
+	}
+	return l.allowIPs.empty() || l.allowIPs.contains(addr)
+}
+
+// AllowIP 返回是否允许该 IP 访问，ip 可以为 "host:port" 形式（如 http.Request.RemoteAddr）
+// ip 无法解析时返回 false
+func (r *Reloader) AllowIP(ip string) bool {
+	if addrPort, err := netip.ParseAddrPort(ip); err == nil {
+		return r.AllowAddr(addrPort.Addr())
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	return r.AllowAddr(addr)
+}
+
+// AllowAPIKey 返回是否允许该 API Key 访问：不在拒绝名单中，且允许名单为空或包含该 API Key
+func (r *Reloader) AllowAPIKey(key string) bool {
+	l := r.lists.Load()
+	if _, denied := l.denyAPIKeys[key]; denied {
+		return false
+	}
+	if len(l.allowAPIKeys) == 0 {
+		return true
+	}
+	_, ok := l.allowAPIKeys[key]
+	return ok
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "allowlist"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{
+		r.prefix + ".allow_ips",
+		r.prefix + ".deny_ips",
+		r.prefix + ".api_keys",
+		r.prefix + ".deny_api_keys",
+	}
+}
+
+// Validate 验证名单配置
+func (r *Reloader) Validate(key, value string) error {
+	_, err := r.next(key, value)
+	return err
+}
+
+// OnChange 以新名单原子替换当前名单
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, err := r.next(key, newValue)
+	if err != nil {
+		return err
+	}
+	r.lists.Store(l)
+	return nil
+}
+
+// next 基于当前名单与配置变更构建新名单
+func (r *Reloader) next(key, value string) (*lists, error) {
+	setting, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return nil, fmt.Errorf("unsupported allowlist key: %s", key)
+	}
+	items, err := values.ParseList(value)
+	if err != nil {
+		return nil, err
+	}
+
+	l := *r.lists.Load()
+	switch setting {
+	case "allow_ips":
+		l.allowIPs, err = parseIPSet(items)
+	case "deny_ips":
+		l.denyIPs, err = parseIPSet(items)
+	case "api_keys":
+		l.allowAPIKeys = toSet(items)
+	case "deny_api_keys":
+		l.denyAPIKeys = toSet(items)
+	default:
+		return nil, fmt.Errorf("unsupported allowlist key: %s", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// parseIPSet 解析 IP 与 CIDR 列表
+func parseIPSet(items []string) (ipSet, error) {
+	set := ipSet{addrs: make(map[netip.Addr]struct{})}
+	for _, item := range items {
+		if strings.Contains(item, "/") {
+			prefix, err := netip.ParsePrefix(item)
+			if err != nil {
+				return ipSet{}, fmt.Errorf("invalid cidr %q: %w", item, err)
+			}
+			set.prefixes = append(set.prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(item)
+		if err != nil {
+			return ipSet{}, fmt.Errorf("invalid ip %q: %w", item, err)
+		}
+		set.addrs[addr.Unmap()] = struct{}{}
+	}
+	return set, nil
+}
+
+// toSet 将列表转换为集合
+func toSet(items []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		set[item] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package allowlist
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"access.allow_ips", "10.0.0.0/8, 192.168.1.10", false},
+		{"access.allow_ips", `["::1", "fd00::/8"]`, false},
+		{"access.allow_ips", "10.0.0.0/33", true},
+		{"access.deny_ips", "not-an-ip", true},
+		{"access.api_keys", "k1, k2", false},
+		{"access.unknown", "", true},
+		{"security.allow_ips", "10.0.0.1", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestAllowIP(t *testing.T) {
+	r := New()
+	if !r.AllowIP("203.0.113.7") {
+		t.Fatal("AllowIP() = false with empty lists, want true")
+	}
+	for key, value := range map[string]string{
+		"access.allow_ips": "10.0.0.0/8, 192.168.1.10",
+		"access.deny_ips":  "10.0.0.13",
+	} {
+		if err := r.OnChange(key, "", value); err != nil {
+			t.Fatalf("OnChange(%q) error = %v", key, err)
+		}
+	}
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.1.2.3", true},
+		{"10.1.2.3:8080", true},
+		{"::ffff:10.1.2.3", true},
+		{"192.168.1.10", true},
+		{"192.168.1.11", false},
+		{"10.0.0.13", false},
+		{"garbage", false},
+	}
+	for _, tt := range tests {
+		if got := r.AllowIP(tt.ip); got != tt.want {
+			t.Errorf("AllowIP(%q) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestAllowAPIKey(t *testing.T) {
+	r := New()
+	if err := r.OnChange("access.deny_api_keys", "", "leaked"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if !r.AllowAPIKey("any") || r.AllowAPIKey("leaked") {
+		t.Fatal("AllowAPIKey() with deny list only, want all keys except denied allowed")
+	}
+	if err := r.OnChange("access.api_keys", "", "k1, leaked"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if !r.AllowAPIKey("k1") || r.AllowAPIKey("any") || r.AllowAPIKey("leaked") {
+		t.Fatal("AllowAPIKey() with allow list, want only listed keys that are not denied allowed")
+	}
+
+	if err := r.OnChange("access.allow_ips", "", "10.0.0.0/33"); err == nil {
+		t.Fatal("OnChange() with invalid cidr error = nil")
+	}
+	if !r.AllowAPIKey("k1") {
+		t.Fatal("rejected change altered API key lists")
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package assetdir 提供模板与静态资源目录热加载重载器
+//
+// 配置键（默认前缀 "templates"）：
+//
+//	templates.dir     资源目录路径，变更后从新目录重新加载
+//	templates.reload  任意新值（如时间戳）触发从当前目录重新加载
+//
+// 资源由 Loader 从目录解析（如 HTMLTemplates 解析 html/template），解析成功后原子替换；
+// 解析失败时继续使用上一次成功加载的结果。Watch 可定期检查目录中文件的修改并自动重新加载
+package assetdir
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "templates"
+
+// Loader 资源加载函数，从目录解析出资源
+type Loader[T any] func(fsys fs.FS) (T, error)
+
+// HTMLTemplates 返回解析 html/template 的 Loader
+// pattern 为 fs.Glob 模式（如 "*.html"、"layouts/*.tmpl"），funcs 为模板函数（可为 nil）
+func HTMLTemplates(pattern string, funcs template.FuncMap) Loader[*template.Template] {
+	return func(fsys fs.FS) (*template.Template, error) {
+		return template.New("").Funcs(funcs).ParseFS(fsys, pattern)
+	}
+}
+
+// Option 重载器配置选项
+type Option func(*options)
+
+// options 重载器配置
+type options struct {
+	prefix string
+	dir    string
+}
+
+// WithPrefix 设置配置键前缀（默认 "templates"）
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		if prefix != "" {
+			o.prefix = prefix
+		}
+	}
+}
+
+// WithDir 设置初始资源目录
+func WithDir(dir string) Option {
+	return func(o *options) {
+		o.dir = dir
+	}
+}
+
+// loaded 一次成功加载的结果
+type loaded[T any] struct {
+	value T
+	dir   string
+	// 加载时目录中文件的修改时间指纹
+	fingerprint string
+}
+
+// Reloader 资源目录重载器
+type Reloader[T any] struct {
+	prefix string
+	loader Loader[T]
+
+	// 串行化加载
+	mu      sync.Mutex
+	current atomic.Pointer[loaded[T]]
+}
+
+// New 创建资源目录重载器，设置了初始目录时立即加载，加载失败返回错误
+func New[T any](loader Loader[T], opts ...Option) (*Reloader[T], error) {
+	if loader == nil {
+		return nil, fmt.Errorf("loader is nil")
+	}
+	o := &options{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	r := &Reloader[T]{prefix: o.prefix, loader: loader}
+	if o.dir != "" {
+		if err := r.load(o.dir); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Get 返回最近一次成功加载的资源，尚未加载时返回零值与 false
+func (r *Reloader[T]) Get() (T, bool) {
+	current := r.current.Load()
+	if current == nil {
+		var zero T
+		return zero, false
+	}
+	return current.value, true
+}
+
+// Dir 返回当前资源目录
+func (r *Reloader[T]) Dir() string {
+	if current := r.current.Load(); current != nil {
+		return current.dir
+	}
+	return ""
+}
+
+// Name 返回重载器名称
+func (r *Reloader[T]) Name() string {
+	return "assetdir"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader[T]) Patterns() []string {
+	return []string{r.prefix + ".dir", r.prefix + ".reload"}
+}
+
+// Validate 验证资源目录可被加载
+func (r *Reloader[T]) Validate(key, value string) error {
+	dir, err := r.targetDir(key, value)
+	if err != nil {
+		return err
+	}
+	_, err = r.loader(os.DirFS(dir))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", dir, err)
+	}
+	return nil
+}
+
+// OnChange 重新加载资源目录
+func (r *Reloader[T]) OnChange(key, oldValue, newValue string) error {
+	dir, err := r.targetDir(key, newValue)
+	if err != nil {
+		return err
+	}
+	return r.load(dir)
+}
+
+// Reload 从当前目录重新加载资源
+func (r *Reloader[T]) Reload() error {
+	dir := r.Dir()
+	if dir == "" {
+		return fmt.Errorf("asset dir is not configured")
+	}
+	return r.load(dir)
+}
+
+// Watch 每隔 interval 检查资源目录中文件的修改，发生变化时重新加载，直到 ctx 结束
+// 重新加载失败时保留上一次成功加载的结果，错误通过 onError 回调（可为 nil）通知
+func (r *Reloader[T]) Watch(ctx context.Context, interval time.Duration, onError func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := r.current.Load()
+			if current == nil {
+				continue
+			}
+			fingerprint, err := fingerprintDir(current.dir)
+			if err == nil && fingerprint == current.fingerprint {
+				continue
+			}
+			if err == nil {
+				err = r.load(current.dir)
+			}
+			if err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// targetDir 返回配置变更对应的资源目录
+func (r *Reloader[T]) targetDir(key, value string) (string, error) {
+	switch key {
+	case r.prefix + ".dir":
+		dir := strings.TrimSpace(value)
+		if dir == "" {
+			return "", fmt.Errorf("asset dir is empty")
+		}
+		return dir, nil
+	case r.prefix + ".reload":
+		dir := r.Dir()
+		if dir == "" {
+			return "", fmt.Errorf("asset dir is not configured")
+		}
+		return dir, nil
+	default:
+		return "", fmt.Errorf("unsupported asset dir key: %s", key)
+	}
+}
+
+// load 加载资源目录，成功后原子替换
+func (r *Reloader[T]) load(dir string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fingerprint, err := fingerprintDir(dir)
+	if err != nil {
+		return err
+	}
+	value, err := r.loader(os.DirFS(dir))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", dir, err)
+	}
+	r.current.Store(&loaded[T]{value: value, dir: dir, fingerprint: fingerprint})
+	return nil
+}
+
+// fingerprintDir 计算目录中所有文件的路径、大小与修改时间指纹
+func fingerprintDir(dir string) (string, error) {
+	var b strings.Builder
+	err := fs.WalkDir(os.DirFS(dir), ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s:%d:%d;", path.Clean(p), info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package assetdir
+
+import (
+	"context"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeTemplate 在 dir 中写入名为 page.html 的模板
+func writeTemplate(t *testing.T, dir, body string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "page.html"), []byte(body), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+// render 渲染当前模板集中的 page.html
+func render(t *testing.T, r *Reloader[*template.Template]) string {
+	t.Helper()
+	tmpl, ok := r.Get()
+	if !ok {
+		t.Fatal("Get() ok = false")
+	}
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, "page.html", nil); err != nil {
+		t.Fatalf("ExecuteTemplate() error = %v", err)
+	}
+	return b.String()
+}
+
+func TestValidate(t *testing.T) {
+	valid, broken := t.TempDir(), t.TempDir()
+	writeTemplate(t, valid, "v1")
+	writeTemplate(t, broken, "{{ .Missing ")
+	r, err := New(HTMLTemplates("*.html", nil))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"templates.dir", valid, false},
+		{"templates.dir", broken, true},
+		{"templates.dir", "", true},
+		{"templates.reload", "1", true},
+		{"templates.path", valid, true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+	if _, ok := r.Get(); ok {
+		t.Fatal("Validate() loaded the templates")
+	}
+}
+
+func TestOnChangeSwitchesAndReloadsDir(t *testing.T) {
+	v1, v2 := t.TempDir(), t.TempDir()
+	writeTemplate(t, v1, "v1")
+	writeTemplate(t, v2, "v2")
+	r, err := New(HTMLTemplates("*.html", nil), WithDir(v1))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := render(t, r); got != "v1" {
+		t.Fatalf("render = %q, want v1", got)
+	}
+
+	if err := r.OnChange("templates.dir", v1, v2); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := render(t, r); got != "v2" || r.Dir() != v2 {
+		t.Fatalf("render = %q in %s, want v2 in %s", got, r.Dir(), v2)
+	}
+
+	writeTemplate(t, v2, "{{ .Missing ")
+	if err := r.OnChange("templates.reload", "", "1"); err == nil {
+		t.Fatal("OnChange() reloading broken templates error = nil")
+	}
+	if got := render(t, r); got != "v2" {
+		t.Fatalf("render after failed reload = %q, want v2", got)
+	}
+	writeTemplate(t, v2, "v3")
+	if err := r.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if got := render(t, r); got != "v3" {
+		t.Fatalf("render after Reload() = %q, want v3", got)
+	}
+}
+
+func TestWatchReloadsModifiedFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "v1")
+	r, err := New(HTMLTemplates("*.html", nil), WithDir(dir))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond, nil)
+
+	writeTemplate(t, dir, "v2")
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(filepath.Join(dir, "page.html"), future, future); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for render(t, r) != "v2" {
+		if time.Now().After(deadline) {
+			t.Fatal("Watch() did not reload modified templates")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
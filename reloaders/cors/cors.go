@@ -0,0 +1,271 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package cors 提供可原子替换的 CORS 策略重载器及配套 HTTP 中间件
+//
+// 配置键（默认前缀 "cors"，列表值支持 JSON 数组或逗号分隔文本）：
+//
+//	cors.allowed_origins    允许的来源（如 "https://a.example.com, https://*.example.com"，"*" 表示任意来源）
+//	cors.allowed_methods    允许的方法（如 "GET, POST"）
+//	cors.allowed_headers    允许的请求头（"*" 表示任意请求头）
+//	cors.exposed_headers    允许浏览器读取的响应头
+//	cors.allow_credentials  是否允许携带凭证（true/false）
+//	cors.max_age            预检结果缓存时间（如 "10m"）
+package cors
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload/internal/values"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "cors"
+
+// Policy CORS 策略，创建后不可修改
+type Policy struct {
+	// 允许的来源
+	AllowedOrigins []string
+	// 允许的方法
+	AllowedMethods []string
+	// 允许的请求头
+	AllowedHeaders []string
+	// 允许浏览器读取的响应头
+	ExposedHeaders []string
+	// 是否允许携带凭证
+	AllowCredentials bool
+	// 预检结果缓存时间
+	MaxAge time.Duration
+}
+
+// DefaultPolicy 默认 CORS 策略：不允许任何跨域来源
+func DefaultPolicy() Policy {
+	return Policy{
+		AllowedMethods: []string{http.MethodGet, http.MethodHead, http.MethodPost},
+	}
+}
+
+// AllowsOrigin 返回是否允许指定来源
+func (p *Policy) AllowsOrigin(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		// 子域名通配：https://*.example.com
+		if scheme, host, ok := strings.Cut(allowed, "://*."); ok {
+			if rest, ok := strings.CutPrefix(strings.ToLower(origin), strings.ToLower(scheme)+"://"); ok &&
+				strings.HasSuffix(rest, "."+strings.ToLower(host)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validate 验证 CORS 策略
+func (p *Policy) validate() error {
+	for _, origin := range p.AllowedOrigins {
+		if origin == "*" {
+			if p.AllowCredentials {
+				return fmt.Errorf("allowed_origins must not contain \"*\" when allow_credentials is true")
+			}
+			continue
+		}
+		u, err := url.Parse(strings.Replace(origin, "://*.", "://wildcard.", 1))
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") {
+			return fmt.Errorf("invalid origin: %s", origin)
+		}
+	}
+	for _, method := range p.AllowedMethods {
+		if method != strings.ToUpper(method) || strings.ContainsAny(method, " \t") {
+			return fmt.Errorf("invalid method: %s", method)
+		}
+	}
+	if p.MaxAge < 0 {
+		return fmt.Errorf("max_age must be >= 0, got %s", p.MaxAge)
+	}
+	return nil
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "cors"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithPolicy 设置初始 CORS 策略
+func WithPolicy(policy Policy) Option {
+	return func(r *Reloader) {
+		r.policy.Store(&policy)
+	}
+}
+
+// Reloader CORS 策略重载器
+type Reloader struct {
+	prefix string
+
+	// 串行化配置变更
+	mu     sync.Mutex
+	policy atomic.Pointer[Policy]
+}
+
+// New 创建 CORS 策略重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix}
+	policy := DefaultPolicy()
+	r.policy.Store(&policy)
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Policy 返回当前 CORS 策略（只读）
+func (r *Reloader) Policy() *Policy {
+	return r.policy.Load()
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "cors"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".*"}
+}
+
+// Validate 验证 CORS 策略配置
+func (r *Reloader) Validate(key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err := r.next(key, value)
+	return err
+}
+
+// OnChange 以新配置构建 CORS 策略并原子替换
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	policy, err := r.next(key, newValue)
+	if err != nil {
+		return err
+	}
+	r.policy.Store(policy)
+	return nil
+}
+
+// next 基于当前策略与配置变更构建新策略
+func (r *Reloader) next(key, value string) (*Policy, error) {
+	setting, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return nil, fmt.Errorf("unsupported cors key: %s", key)
+	}
+
+	policy := *r.policy.Load()
+	value = strings.TrimSpace(value)
+	var err error
+	switch setting {
+	case "allowed_origins":
+		policy.AllowedOrigins, err = values.ParseList(value)
+	case "allowed_methods":
+		policy.AllowedMethods, err = values.ParseList(strings.ToUpper(value))
+	case "allowed_headers":
+		policy.AllowedHeaders, err = values.ParseList(value)
+	case "exposed_headers":
+		policy.ExposedHeaders, err = values.ParseList(value)
+	case "allow_credentials":
+		policy.AllowCredentials, err = strconv.ParseBool(value)
+	case "max_age":
+		policy.MaxAge, err = time.ParseDuration(value)
+	default:
+		return nil, fmt.Errorf("unsupported cors key: %s", key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", setting, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Middleware 返回按当前 CORS 策略处理跨域请求的 HTTP 中间件
+// 预检请求（OPTIONS + Access-Control-Request-Method）由中间件直接响应
+func (r *Reloader) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		policy := r.policy.Load()
+		origin := req.Header.Get("Origin")
+		header := w.Header()
+		header.Add("Vary", "Origin")
+
+		preflight := req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != ""
+		if !policy.AllowsOrigin(origin) {
+			if preflight {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		header.Set("Access-Control-Allow-Origin", origin)
+		if policy.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if len(policy.ExposedHeaders) > 0 {
+				header.Set("Access-Control-Expose-Headers", strings.Join(policy.ExposedHeaders, ", "))
+			}
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+		header.Set("Access-Control-Allow-Methods", strings.Join(policy.AllowedMethods, ", "))
+		if len(policy.AllowedHeaders) == 1 && policy.AllowedHeaders[0] == "*" {
+			if requested := req.Header.Get("Access-Control-Request-Headers"); requested != "" {
+				header.Set("Access-Control-Allow-Headers", requested)
+			}
+		} else if len(policy.AllowedHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ", "))
+		}
+		if policy.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge.Seconds())))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
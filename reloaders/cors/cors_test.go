@@ -0,0 +1,118 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"cors.allowed_origins", "https://app.example.com, https://*.example.com", false},
+		{"cors.allowed_origins", "*", false},
+		{"cors.allowed_origins", "app.example.com", true},
+		{"cors.allowed_origins", "https://app.example.com/path", true},
+		{"cors.allowed_methods", "get, post", false},
+		{"cors.allow_credentials", "maybe", true},
+		{"cors.max_age", "10m", false},
+		{"cors.max_age", "-1s", true},
+		{"cors.unknown", "1", true},
+		{"http.allowed_origins", "*", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateRejectsWildcardWithCredentials(t *testing.T) {
+	r := New(WithPolicy(Policy{AllowedOrigins: []string{"*"}}))
+	if err := r.Validate("cors.allow_credentials", "true"); err == nil {
+		t.Fatal("Validate() error = nil, want wildcard origin rejected with credentials")
+	}
+}
+
+func TestAllowsOrigin(t *testing.T) {
+	policy := Policy{AllowedOrigins: []string{"https://app.example.com", "https://*.example.org"}}
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://app.example.com", true},
+		{"HTTPS://APP.EXAMPLE.COM", true},
+		{"http://app.example.com", false},
+		{"https://api.example.org", true},
+		{"https://example.org", false},
+		{"https://evil-example.org", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := policy.AllowsOrigin(tt.origin); got != tt.want {
+			t.Errorf("AllowsOrigin(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestMiddlewareFollowsPolicyChanges(t *testing.T) {
+	r := New()
+	handler := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	preflight := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodOptions, "/", nil)
+		req.Header.Set("Origin", "https://app.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if got := preflight().Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Fatalf("Access-Control-Allow-Origin = %q before origin allowed, want empty", got)
+	}
+
+	for key, value := range map[string]string{
+		"cors.allowed_origins": "https://app.example.com",
+		"cors.allowed_methods": "get, put",
+		"cors.max_age":         "10m",
+	} {
+		if err := r.OnChange(key, "", value); err != nil {
+			t.Fatalf("OnChange(%q) error = %v", key, err)
+		}
+	}
+	rec := preflight()
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	header := rec.Header()
+	if got := header.Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Fatalf("Access-Control-Allow-Origin = %q, want https://app.example.com", got)
+	}
+	if got := header.Get("Access-Control-Allow-Methods"); got != "GET, PUT" {
+		t.Fatalf("Access-Control-Allow-Methods = %q, want \"GET, PUT\"", got)
+	}
+	if got := header.Get("Access-Control-Max-Age"); got != "600" {
+		t.Fatalf("Access-Control-Max-Age = %q, want 600", got)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package cronjobs 提供定时任务调度表达式热加载重载器
+//
+// 配置键（默认前缀 "jobs"，<name> 为任务名称，不能包含 "."）：
+//
+//	jobs.<name>.schedule  任务的 cron 表达式（如 "*/5 * * * *"、"@every 1m"），值为空表示暂停该任务
+//
+// 重载器只负责校验表达式并通知调度器，调度器通过 Scheduler 接口接入；
+// 基于 robfig/cron 的调度器可直接使用 NewCronScheduler
+package cronjobs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "jobs"
+
+// Scheduler 定时任务调度器接口
+type Scheduler interface {
+	// Reschedule 以新的 cron 表达式重新调度任务，spec 为空表示暂停该任务
+	Reschedule(name, spec string) error
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "jobs"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithParser 设置 cron 表达式解析器（默认支持 5 段表达式与 @every 等描述符）
+// 调度器使用秒级表达式时应传入相同配置的解析器，如 cron.NewParser(cron.Second | ...)
+func WithParser(parser cron.ScheduleParser) Option {
+	return func(r *Reloader) {
+		if parser != nil {
+			r.parser = parser
+		}
+	}
+}
+
+// WithJobs 限定允许调度的任务名称，未设置时接受任意任务名称
+func WithJobs(names ...string) Option {
+	return func(r *Reloader) {
+		if r.jobs == nil {
+			r.jobs = make(map[string]struct{})
+		}
+		for _, name := range names {
+			r.jobs[name] = struct{}{}
+		}
+	}
+}
+
+// Reloader 定时任务调度表达式重载器
+type Reloader struct {
+	prefix    string
+	scheduler Scheduler
+	parser    cron.ScheduleParser
+	jobs      map[string]struct{}
+}
+
+// New 创建定时任务调度表达式重载器
+func New(scheduler Scheduler, opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix:    DefaultPrefix,
+		scheduler: scheduler,
+		parser:    cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "cronjobs"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".*.schedule"}
+}
+
+// Validate 验证 cron 表达式
+func (r *Reloader) Validate(key, value string) error {
+	name, err := r.jobName(key)
+	if err != nil {
+		return err
+	}
+	spec := strings.TrimSpace(value)
+	if spec == "" {
+		return nil
+	}
+	if _, err := r.parser.Parse(spec); err != nil {
+		return fmt.Errorf("invalid schedule for job %s: %w", name, err)
+	}
+	return nil
+}
+
+// OnChange 通知调度器重新调度任务
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	if err := r.Validate(key, newValue); err != nil {
+		return err
+	}
+	name, _ := r.jobName(key)
+	if r.scheduler == nil {
+		return fmt.Errorf("scheduler is nil")
+	}
+	return r.scheduler.Reschedule(name, strings.TrimSpace(newValue))
+}
+
+// jobName 解析配置键中的任务名称
+func (r *Reloader) jobName(key string) (string, error) {
+	rest, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return "", fmt.Errorf("unsupported jobs key: %s", key)
+	}
+	name, ok := strings.CutSuffix(rest, ".schedule")
+	// 配置键模式中的 "*" 只匹配单级，任务名称不能包含 "."
+	if !ok || name == "" || strings.Contains(name, ".") {
+		return "", fmt.Errorf("unsupported jobs key: %s", key)
+	}
+	if r.jobs != nil {
+		if _, known := r.jobs[name]; !known {
+			return "", fmt.Errorf("unknown job: %s", name)
+		}
+	}
+	return name, nil
+}
+
+// CronScheduler 基于 robfig/cron 的调度器
+type CronScheduler struct {
+	cron *cron.Cron
+
+	mu      sync.Mutex
+	jobs    map[string]cron.Job
+	entries map[string]cron.EntryID
+}
+
+// NewCronScheduler 创建基于 robfig/cron 的调度器
+// jobs 为任务名称到任务的映射，任务在对应的调度表达式首次下发后才会被调度
+func NewCronScheduler(c *cron.Cron, jobs map[string]cron.Job) *CronScheduler {
+	s := &CronScheduler{
+		cron:    c,
+		jobs:    make(map[string]cron.Job, len(jobs)),
+		entries: make(map[string]cron.EntryID),
+	}
+	for name, job := range jobs {
+		s.jobs[name] = job
+	}
+	return s
+}
+
+// Reschedule 实现 Scheduler 接口：移除旧的调度项并以新的表达式添加
+func (s *CronScheduler) Reschedule(name, spec string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return fmt.Errorf("job not registered: %s", name)
+	}
+
+	var id cron.EntryID
+	if spec != "" {
+		var err error
+		if id, err = s.cron.AddJob(spec, job); err != nil {
+			return fmt.Errorf("failed to schedule job %s: %w", name, err)
+		}
+	}
+	if old, exists := s.entries[name]; exists {
+		s.cron.Remove(old)
+		delete(s.entries, name)
+	}
+	if spec != "" {
+		s.entries[name] = id
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package cronjobs
+
+import (
+	"testing"
+
+	"github.com/robfig/cron/v3"
+)
+
+// recordingScheduler 记录重新调度请求的 Scheduler
+type recordingScheduler struct {
+	specs map[string]string
+}
+
+func (s *recordingScheduler) Reschedule(name, spec string) error {
+	s.specs[name] = spec
+	return nil
+}
+
+func TestValidate(t *testing.T) {
+	r := New(nil, WithJobs("cleanup"))
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"jobs.cleanup.schedule", "*/5 * * * *", false},
+		{"jobs.cleanup.schedule", "@every 1m", false},
+		{"jobs.cleanup.schedule", "", false},
+		{"jobs.cleanup.schedule", "every minute", true},
+		{"jobs.cleanup.schedule", "0 */5 * * * *", true},
+		{"jobs.report.schedule", "@daily", true},
+		{"jobs.cleanup.interval", "1m", true},
+		{"jobs.nightly.cleanup.schedule", "@daily", true},
+		{"tasks.cleanup.schedule", "@daily", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeNotifiesScheduler(t *testing.T) {
+	scheduler := &recordingScheduler{specs: make(map[string]string)}
+	r := New(scheduler)
+	if err := r.OnChange("jobs.cleanup.schedule", "", " @hourly "); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := scheduler.specs["cleanup"]; got != "@hourly" {
+		t.Fatalf("spec = %q, want @hourly", got)
+	}
+	if err := r.OnChange("jobs.cleanup.schedule", "@hourly", "bad"); err == nil {
+		t.Fatal("OnChange() with invalid schedule error = nil")
+	}
+	if got := scheduler.specs["cleanup"]; got != "@hourly" {
+		t.Fatalf("spec after rejected change = %q, want @hourly", got)
+	}
+	if err := r.OnChange("jobs.nightly.cleanup.schedule", "", "@daily"); err == nil {
+		t.Fatal("OnChange() with dotted job name error = nil")
+	}
+	if err := New(nil).OnChange("jobs.cleanup.schedule", "", "@hourly"); err == nil {
+		t.Fatal("OnChange() with nil scheduler error = nil")
+	}
+}
+
+func TestCronSchedulerReschedule(t *testing.T) {
+	c := cron.New()
+	s := NewCronScheduler(c, map[string]cron.Job{"cleanup": cron.FuncJob(func() {})})
+
+	if err := s.Reschedule("cleanup", "@every 1m"); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+	if err := s.Reschedule("cleanup", "@every 2m"); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+	if n := len(c.Entries()); n != 1 {
+		t.Fatalf("len(Entries()) = %d, want 1", n)
+	}
+
+	// 新表达式无效时保留原有调度
+	if err := s.Reschedule("cleanup", "bad"); err == nil {
+		t.Fatal("Reschedule() with invalid spec error = nil")
+	}
+	if n := len(c.Entries()); n != 1 {
+		t.Fatalf("len(Entries()) after failed reschedule = %d, want 1", n)
+	}
+
+	if err := s.Reschedule("cleanup", ""); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+	if n := len(c.Entries()); n != 0 {
+		t.Fatalf("len(Entries()) after pause = %d, want 0", n)
+	}
+	if err := s.Reschedule("report", "@daily"); err == nil {
+		t.Fatal("Reschedule() with unregistered job error = nil")
+	}
+}
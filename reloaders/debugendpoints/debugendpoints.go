@@ -0,0 +1,236 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package debugendpoints 提供运行时开启/关闭 pprof 等调试接口的重载器
+//
+// 配置键（默认前缀 "debug"）：
+//
+//	debug.endpoints_enabled  是否开启调试接口（true/false）
+//	debug.endpoints_ttl      开启后自动关闭的时间（如 "30m"，0 表示不自动关闭）
+//
+// 调试接口在首次开启时注册到提供的路由上，之后由开关控制访问：关闭状态下返回 404。
+// 默认注册 /debug/pprof/ 系列接口与 /debug/vars（expvar），可通过 WithEndpoint 追加
+package debugendpoints
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "debug"
+
+// Mux 可注册路由的 HTTP 路由器（*http.ServeMux 满足该接口）
+type Mux interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// endpoint 调试接口
+type endpoint struct {
+	pattern string
+	handler http.Handler
+}
+
+// defaultEndpoints 默认注册的调试接口
+func defaultEndpoints() []endpoint {
+	return []endpoint{
+		{"/debug/pprof/", http.HandlerFunc(pprof.Index)},
+		{"/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline)},
+		{"/debug/pprof/profile", http.HandlerFunc(pprof.Profile)},
+		{"/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol)},
+		{"/debug/pprof/trace", http.HandlerFunc(pprof.Trace)},
+		{"/debug/vars", expvar.Handler()},
+	}
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "debug"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithEndpoint 追加受开关控制的调试接口
+func WithEndpoint(pattern string, handler http.Handler) Option {
+	return func(r *Reloader) {
+		if pattern != "" && handler != nil {
+			r.endpoints = append(r.endpoints, endpoint{pattern, handler})
+		}
+	}
+}
+
+// WithoutDefaultEndpoints 不注册默认的 pprof 与 expvar 接口
+func WithoutDefaultEndpoints() Option {
+	return func(r *Reloader) {
+		r.endpoints = r.endpoints[:0]
+	}
+}
+
+// Reloader 调试接口开关重载器
+type Reloader struct {
+	prefix    string
+	mux       Mux
+	endpoints []endpoint
+
+	enabled atomic.Bool
+
+	mu         sync.Mutex
+	registered bool
+	ttl        time.Duration
+	timer      *time.Timer
+}
+
+// New 创建调试接口开关重载器，调试接口初始为关闭状态
+func New(mux Mux, opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix:    DefaultPrefix,
+		mux:       mux,
+		endpoints: defaultEndpoints(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Enabled 返回调试接口是否开启
+func (r *Reloader) Enabled() bool {
+	return r.enabled.Load()
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "debugendpoints"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".endpoints_enabled", r.prefix + ".endpoints_ttl"}
+}
+
+// Validate 验证开关配置
+func (r *Reloader) Validate(key, value string) error {
+	value = strings.TrimSpace(value)
+	switch key {
+	case r.prefix + ".endpoints_enabled":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("invalid endpoints_enabled: %s", value)
+		}
+	case r.prefix + ".endpoints_ttl":
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid endpoints_ttl: %s", value)
+		}
+	default:
+		return fmt.Errorf("unsupported debug key: %s", key)
+	}
+	return nil
+}
+
+// OnChange 开启或关闭调试接口
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	if err := r.Validate(key, newValue); err != nil {
+		return err
+	}
+	value := strings.TrimSpace(newValue)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if key == r.prefix+".endpoints_ttl" {
+		r.ttl, _ = time.ParseDuration(value)
+		if r.enabled.Load() {
+			r.resetTimer()
+		}
+		return nil
+	}
+
+	enabled, _ := strconv.ParseBool(value)
+	if enabled {
+		r.register()
+		r.enabled.Store(true)
+		r.resetTimer()
+		return nil
+	}
+	r.disable()
+	return nil
+}
+
+// register 首次开启时将调试接口注册到路由上
+func (r *Reloader) register() {
+	if r.registered || r.mux == nil {
+		return
+	}
+	for _, ep := range r.endpoints {
+		r.mux.Handle(ep.pattern, r.guard(ep.handler))
+	}
+	r.registered = true
+}
+
+// guard 包装调试接口，关闭状态下返回 404
+func (r *Reloader) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if !r.enabled.Load() {
+			http.NotFound(w, req)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+// resetTimer 按 TTL 重新设置自动关闭定时器
+func (r *Reloader) resetTimer() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	if r.ttl <= 0 {
+		return
+	}
+	var timer *time.Timer
+	timer = time.AfterFunc(r.ttl, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		// 定时器已被重新设置时忽略
+		if r.timer == timer {
+			r.disable()
+		}
+	})
+	r.timer = timer
+}
+
+// disable 关闭调试接口
+func (r *Reloader) disable() {
+	r.enabled.Store(false)
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
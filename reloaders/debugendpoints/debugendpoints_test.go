@@ -0,0 +1,105 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package debugendpoints
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	r := New(nil)
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"debug.endpoints_enabled", "true", false},
+		{"debug.endpoints_enabled", "on", true},
+		{"debug.endpoints_ttl", "15m", false},
+		{"debug.endpoints_ttl", "-1m", true},
+		{"debug.endpoints_ttl", "forever", true},
+		{"debug.unknown", "1", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+// newReloader 创建只注册 /debug/ping 端点的重载器
+func newReloader() (*Reloader, *http.ServeMux) {
+	mux := http.NewServeMux()
+	ping := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return New(mux, WithoutDefaultEndpoints(), WithEndpoint("/debug/ping", ping)), mux
+}
+
+// status 返回请求 /debug/ping 的状态码
+func status(mux *http.ServeMux) int {
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/ping", nil))
+	return rec.Code
+}
+
+func TestOnChangeTogglesEndpoints(t *testing.T) {
+	r, mux := newReloader()
+	if got := status(mux); got != http.StatusNotFound {
+		t.Fatalf("status = %d before enabled, want %d", got, http.StatusNotFound)
+	}
+	// 重复启用不会重复注册路由
+	for range 2 {
+		if err := r.OnChange("debug.endpoints_enabled", "", "true"); err != nil {
+			t.Fatalf("OnChange() error = %v", err)
+		}
+	}
+	if got := status(mux); got != http.StatusOK {
+		t.Fatalf("status = %d after enabled, want %d", got, http.StatusOK)
+	}
+	if err := r.OnChange("debug.endpoints_enabled", "true", "false"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := status(mux); got != http.StatusNotFound {
+		t.Fatalf("status = %d after disabled, want %d", got, http.StatusNotFound)
+	}
+}
+
+func TestOnChangeDisablesAfterTTL(t *testing.T) {
+	r, mux := newReloader()
+	if err := r.OnChange("debug.endpoints_ttl", "", "50ms"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("debug.endpoints_enabled", "", "true"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := status(mux); got != http.StatusOK {
+		t.Fatalf("status = %d after enabled, want %d", got, http.StatusOK)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for r.Enabled() {
+		if time.Now().After(deadline) {
+			t.Fatal("endpoints still enabled after ttl")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := status(mux); got != http.StatusNotFound {
+		t.Fatalf("status = %d after ttl, want %d", got, http.StatusNotFound)
+	}
+}
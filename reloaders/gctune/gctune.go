@@ -0,0 +1,224 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package gctune 提供 GOGC 与 GOMEMLIMIT 运行时调优重载器
+//
+// 配置键（默认前缀 "runtime"）：
+//
+//	runtime.gogc         GC 触发百分比（如 "100"，"off" 表示关闭基于增长比例的 GC）
+//	runtime.gomemlimit   软内存上限（如 "512MiB"、"2GiB"，"off" 表示不限制）
+//
+// 取值格式与环境变量 GOGC、GOMEMLIMIT 一致，变更后立即通过
+// debug.SetGCPercent、debug.SetMemoryLimit 生效，变更记录可在热加载管理器的变更历史中查询
+package gctune
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "runtime"
+
+const (
+	// MinGCPercent GOGC 的最小值
+	MinGCPercent = 10
+	// MaxGCPercent GOGC 的最大值
+	MaxGCPercent = 10000
+	// DefaultMinMemoryLimit GOMEMLIMIT 的默认最小值，避免误配过小的上限导致 GC 频繁执行
+	DefaultMinMemoryLimit = 64 << 20
+)
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "runtime"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithMinMemoryLimit 设置 GOMEMLIMIT 的最小值（默认 64MiB）
+func WithMinMemoryLimit(bytes int64) Option {
+	return func(r *Reloader) {
+		if bytes > 0 {
+			r.minMemoryLimit = bytes
+		}
+	}
+}
+
+// Settings GC 调优参数
+type Settings struct {
+	// GC 触发百分比（负数表示关闭）
+	GCPercent int `json:"gc_percent"`
+	// 软内存上限（字节，math.MaxInt64 表示不限制）
+	MemoryLimit int64 `json:"memory_limit"`
+}
+
+// Reloader GC 调优重载器
+type Reloader struct {
+	prefix         string
+	minMemoryLimit int64
+
+	mu       sync.Mutex
+	initial  Settings
+	settings Settings
+}
+
+// New 创建 GC 调优重载器，记录创建时的 GC 参数用于 Reset
+func New(opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix:         DefaultPrefix,
+		minMemoryLimit: DefaultMinMemoryLimit,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+
+	// 读取当前值：SetGCPercent 返回旧值，立即写回；SetMemoryLimit 传入负数只读取不修改
+	percent := debug.SetGCPercent(100)
+	debug.SetGCPercent(percent)
+	r.initial = Settings{GCPercent: percent, MemoryLimit: debug.SetMemoryLimit(-1)}
+	r.settings = r.initial
+	return r
+}
+
+// Settings 返回当前的 GC 调优参数
+func (r *Reloader) Settings() Settings {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.settings
+}
+
+// Reset 恢复创建重载器时的 GC 参数
+func (r *Reloader) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	debug.SetGCPercent(r.initial.GCPercent)
+	debug.SetMemoryLimit(r.initial.MemoryLimit)
+	r.settings = r.initial
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "gctune"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".gogc", r.prefix + ".gomemlimit"}
+}
+
+// Validate 验证 GC 调优参数
+func (r *Reloader) Validate(key, value string) error {
+	switch key {
+	case r.prefix + ".gogc":
+		_, err := ParseGCPercent(value)
+		return err
+	case r.prefix + ".gomemlimit":
+		limit, err := ParseMemoryLimit(value)
+		if err != nil {
+			return err
+		}
+		if limit < r.minMemoryLimit {
+			return fmt.Errorf("gomemlimit must be >= %d bytes, got %d", r.minMemoryLimit, limit)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported runtime key: %s", key)
+	}
+}
+
+// OnChange 应用 GC 调优参数
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	if err := r.Validate(key, newValue); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if key == r.prefix+".gogc" {
+		percent, _ := ParseGCPercent(newValue)
+		debug.SetGCPercent(percent)
+		r.settings.GCPercent = percent
+		return nil
+	}
+	limit, _ := ParseMemoryLimit(newValue)
+	debug.SetMemoryLimit(limit)
+	r.settings.MemoryLimit = limit
+	return nil
+}
+
+// ParseGCPercent 解析 GOGC 格式的取值，"off" 返回 -1
+func ParseGCPercent(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "off") {
+		return -1, nil
+	}
+	percent, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid gogc: %s", value)
+	}
+	if percent < MinGCPercent || percent > MaxGCPercent {
+		return 0, fmt.Errorf("gogc out of range [%d, %d], got %d", MinGCPercent, MaxGCPercent, percent)
+	}
+	return percent, nil
+}
+
+// memoryUnits GOMEMLIMIT 支持的单位
+var memoryUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseMemoryLimit 解析 GOMEMLIMIT 格式的取值（如 "512MiB"），"off" 返回 math.MaxInt64
+func ParseMemoryLimit(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "off") {
+		return math.MaxInt64, nil
+	}
+
+	number, unit := value, int64(1)
+	for _, u := range memoryUnits {
+		if n, ok := strings.CutSuffix(value, u.suffix); ok {
+			number, unit = n, u.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(number), 10, 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid gomemlimit: %s", value)
+	}
+	if n > math.MaxInt64/unit {
+		return 0, fmt.Errorf("gomemlimit overflows: %s", value)
+	}
+	return n * unit, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package gctune
+
+import (
+	"math"
+	"runtime/debug"
+	"testing"
+)
+
+func TestParseGCPercent(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"100", 100, false},
+		{" OFF ", -1, false},
+		{"5", 0, true},
+		{"20000", 0, true},
+		{"fast", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseGCPercent(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseGCPercent(%q) = %d, %v, want %d, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseMemoryLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{"512MiB", 512 << 20, false},
+		{"2 GiB", 2 << 30, false},
+		{"1048576", 1 << 20, false},
+		{"off", math.MaxInt64, false},
+		{"0", 0, true},
+		{"1GB", 0, true},
+		{"9000000TiB", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMemoryLimit(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMemoryLimit(%q) = %d, %v, want %d, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	r := New()
+	if err := r.Validate("runtime.gomemlimit", "32MiB"); err == nil {
+		t.Error("Validate() below minimum memory limit error = nil")
+	}
+	if err := r.Validate("runtime.gomemlimit", "1GiB"); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+	if err := r.Validate("runtime.gomaxprocs", "4"); err == nil {
+		t.Error("Validate() with unsupported key error = nil")
+	}
+}
+
+func TestOnChangeAndReset(t *testing.T) {
+	r := New()
+	initial := r.Settings()
+	defer r.Reset()
+
+	if err := r.OnChange("runtime.gogc", "", "200"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("runtime.gomemlimit", "", "1GiB"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := debug.SetGCPercent(200); got != 200 {
+		t.Fatalf("GC percent = %d, want 200", got)
+	}
+	if got := debug.SetMemoryLimit(-1); got != 1<<30 {
+		t.Fatalf("memory limit = %d, want %d", got, 1<<30)
+	}
+	if got := r.Settings(); got != (Settings{GCPercent: 200, MemoryLimit: 1 << 30}) {
+		t.Fatalf("Settings() = %+v", got)
+	}
+
+	r.Reset()
+	if got := debug.SetMemoryLimit(-1); got != initial.MemoryLimit {
+		t.Fatalf("memory limit after Reset() = %d, want %d", got, initial.MemoryLimit)
+	}
+	if got := r.Settings(); got != initial {
+		t.Fatalf("Settings() after Reset() = %+v, want %+v", got, initial)
+	}
+}
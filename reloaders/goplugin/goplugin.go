@@ -0,0 +1,217 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package goplugin 提供实验性的 Go 插件（.so）热替换重载器
+//
+// 配置键（默认 "plugin.path"）的值为插件文件路径，变更后加载新插件、
+// 查找导出符号并校验其实现了目标接口 T，成功后原子替换当前实现；失败时保留上一个实现。
+//
+// 使用约束（Go 插件机制本身的限制）：
+//   - 仅支持启用 cgo 的 linux、darwin、freebsd，其他平台返回 ErrUnsupported
+//   - 插件无法卸载，旧实现的代码与全局状态会一直留在进程中
+//   - 同一路径只会被加载一次，发布新版本时必须使用新的文件路径（如带版本号的文件名）
+//   - 插件必须与主程序使用相同的 Go 版本、构建参数与依赖版本编译
+package goplugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultKey 默认的配置键
+const DefaultKey = "plugin.path"
+
+// DefaultSymbol 默认查找的导出符号名称
+const DefaultSymbol = "Plugin"
+
+// ErrUnsupported 当前平台或构建方式不支持 Go 插件
+var ErrUnsupported = errors.New("go plugins are not supported on this platform")
+
+// SymbolError 插件导出符号错误
+type SymbolError struct {
+	// 插件文件路径
+	Path string
+	// 符号名称
+	Symbol string
+	// 符号的实际类型（符号不存在时为空）
+	Type string
+	// 期望实现的接口类型
+	Want string
+}
+
+// Error 实现 error 接口
+func (e *SymbolError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("plugin %s does not export symbol %s", e.Path, e.Symbol)
+	}
+	return fmt.Sprintf("plugin %s symbol %s has type %s, want %s", e.Path, e.Symbol, e.Type, e.Want)
+}
+
+// Option 重载器配置选项
+type Option func(*options)
+
+// options 重载器配置
+type options struct {
+	key    string
+	symbol string
+}
+
+// WithKey 设置配置键（默认 "plugin.path"）
+func WithKey(key string) Option {
+	return func(o *options) {
+		if key != "" {
+			o.key = key
+		}
+	}
+}
+
+// WithSymbol 设置查找的导出符号名称（默认 "Plugin"）
+// 符号可以是实现 T 的变量，也可以是返回 T 的无参函数
+func WithSymbol(symbol string) Option {
+	return func(o *options) {
+		if symbol != "" {
+			o.symbol = symbol
+		}
+	}
+}
+
+// loaded 已加载的插件实现
+type loaded[T any] struct {
+	impl T
+	path string
+}
+
+// Reloader Go 插件重载器
+type Reloader[T any] struct {
+	key    string
+	symbol string
+
+	// 串行化插件加载
+	mu      sync.Mutex
+	current atomic.Pointer[loaded[T]]
+}
+
+// New 创建 Go 插件重载器，fallback 为加载插件前使用的默认实现
+func New[T any](fallback T, opts ...Option) *Reloader[T] {
+	o := &options{key: DefaultKey, symbol: DefaultSymbol}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	r := &Reloader[T]{key: o.key, symbol: o.symbol}
+	r.current.Store(&loaded[T]{impl: fallback})
+	return r
+}
+
+// Get 返回当前实现
+func (r *Reloader[T]) Get() T {
+	return r.current.Load().impl
+}
+
+// Path 返回当前实现所在的插件文件路径（使用默认实现时为空）
+func (r *Reloader[T]) Path() string {
+	return r.current.Load().path
+}
+
+// Name 返回重载器名称
+func (r *Reloader[T]) Name() string {
+	return "goplugin"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader[T]) Patterns() []string {
+	return []string{r.key}
+}
+
+// Validate 验证插件文件存在
+// 插件加载不可撤销，因此验证阶段不打开插件，符号校验在 OnChange 中进行
+func (r *Reloader[T]) Validate(key, value string) error {
+	if key != r.key {
+		return fmt.Errorf("unsupported plugin key: %s", key)
+	}
+	if !supported {
+		return ErrUnsupported
+	}
+	path := strings.TrimSpace(value)
+	if path == "" {
+		return fmt.Errorf("plugin path is empty")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to stat plugin: %w", err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("plugin path %s is a directory", path)
+	}
+	return nil
+}
+
+// OnChange 加载新插件并替换当前实现
+func (r *Reloader[T]) OnChange(key, oldValue, newValue string) error {
+	if err := r.Validate(key, newValue); err != nil {
+		return err
+	}
+	return r.Load(strings.TrimSpace(newValue))
+}
+
+// Load 加载指定路径的插件并替换当前实现
+func (r *Reloader[T]) Load(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current := r.current.Load(); current.path == path {
+		return nil
+	}
+
+	sym, err := lookup(path, r.symbol)
+	if err != nil {
+		return err
+	}
+	impl, ok := resolve[T](sym)
+	if !ok {
+		return &SymbolError{
+			Path:   path,
+			Symbol: r.symbol,
+			Type:   fmt.Sprintf("%T", sym),
+			Want:   reflect.TypeFor[T]().String(),
+		}
+	}
+	r.current.Store(&loaded[T]{impl: impl, path: path})
+	return nil
+}
+
+// resolve 将导出符号转换为目标类型
+// 导出变量以指针形式返回，导出函数需为无参且返回 T
+func resolve[T any](sym any) (T, bool) {
+	switch s := sym.(type) {
+	case T:
+		return s, true
+	case *T:
+		if s != nil {
+			return *s, true
+		}
+	case func() T:
+		return s(), true
+	}
+	var zero T
+	return zero, false
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package goplugin
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Greeter 测试使用的插件接口
+type Greeter interface {
+	Greet() string
+}
+
+// staticGreeter 返回固定问候语的 Greeter
+type staticGreeter string
+
+func (g staticGreeter) Greet() string { return string(g) }
+
+func TestResolve(t *testing.T) {
+	var g Greeter = staticGreeter("value")
+	var nilPtr *Greeter
+	tests := []struct {
+		name string
+		sym  any
+		want string
+		ok   bool
+	}{
+		{"value", staticGreeter("value"), "value", true},
+		{"pointer", &g, "value", true},
+		{"constructor", func() Greeter { return staticGreeter("built") }, "built", true},
+		{"nil pointer", nilPtr, "", false},
+		{"wrong type", 42, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := resolve[Greeter](tt.sym)
+		if ok != tt.ok || (ok && got.Greet() != tt.want) {
+			t.Errorf("resolve(%s) = %v, %v, want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	r := New[Greeter](staticGreeter("fallback"))
+	if err := r.Validate("plugins.path", "/tmp/greeter.so"); err == nil {
+		t.Fatal("Validate() with unsupported key error = nil")
+	}
+	if !supported {
+		if err := r.Validate(DefaultKey, "/tmp/greeter.so"); !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("Validate() error = %v, want %v", err, ErrUnsupported)
+		}
+		return
+	}
+
+	dir := t.TempDir()
+	for _, value := range []string{"", filepath.Join(dir, "missing.so"), dir} {
+		if err := r.Validate(DefaultKey, value); err == nil {
+			t.Errorf("Validate(%q) error = nil", value)
+		}
+	}
+}
+
+func TestOnChangeKeepsFallbackOnLoadFailure(t *testing.T) {
+	if !supported {
+		t.Skip("go plugins are not supported on this platform")
+	}
+	path := filepath.Join(t.TempDir(), "greeter.so")
+	if err := os.WriteFile(path, []byte("not a plugin"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	r := New[Greeter](staticGreeter("fallback"))
+	if err := r.OnChange(DefaultKey, "", path); err == nil {
+		t.Fatal("OnChange() with invalid plugin error = nil")
+	}
+	if got := r.Get().Greet(); got != "fallback" || r.Path() != "" {
+		t.Fatalf("Get() = %q from %q, want fallback", got, r.Path())
+	}
+}
+
+func TestSymbolError(t *testing.T) {
+	missing := &SymbolError{Path: "greeter.so", Symbol: "Plugin"}
+	if got := missing.Error(); got != "plugin greeter.so does not export symbol Plugin" {
+		t.Fatalf("Error() = %q", got)
+	}
+	mismatch := &SymbolError{Path: "greeter.so", Symbol: "Plugin", Type: "int", Want: "goplugin.Greeter"}
+	if got := mismatch.Error(); got != "plugin greeter.so symbol Plugin has type int, want goplugin.Greeter" {
+		t.Fatalf("Error() = %q", got)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package goplugin
+
+// supported 当前平台是否支持 Go 插件
+const supported = false
+
+// lookup 当前平台不支持 Go 插件
+func lookup(path, symbol string) (any, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,243 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package grpckeepalive 提供 gRPC 服务端/客户端 keepalive 与连接存活参数重载器
+//
+// 配置键（默认前缀 "grpc"）：
+//
+//	grpc.server.keepalive.time                      服务端 ping 间隔（需重启）
+//	grpc.server.keepalive.timeout                   服务端 ping 超时（需重启）
+//	grpc.server.keepalive.max_connection_idle       连接最大空闲时间（需重启）
+//	grpc.server.keepalive.max_connection_age        连接最大存活时间（需重启）
+//	grpc.server.keepalive.max_connection_age_grace  连接到期后的宽限时间（需重启）
+//	grpc.server.keepalive.min_time                  允许客户端 ping 的最小间隔（需重启）
+//	grpc.server.keepalive.permit_without_stream     是否允许无活跃流时 ping（需重启）
+//	grpc.client.keepalive.time                      客户端 ping 间隔（对新建连接生效）
+//	grpc.client.keepalive.timeout                   客户端 ping 超时（对新建连接生效）
+//	grpc.client.keepalive.permit_without_stream     是否允许无活跃流时 ping（对新建连接生效）
+//
+// grpc-go 的 keepalive 参数只能在创建 grpc.Server 或建立连接时指定：
+// 服务端参数变更会被校验并保存，但需重启才能生效，变更历史中的处理结果记录为 restart_required；
+// 客户端参数通过 DialOptions() 对之后新建的连接生效
+package grpckeepalive
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "grpc"
+
+// minClientTime 客户端 ping 间隔的最小值（grpc-go 会将更小的值提升为 10s）
+const minClientTime = 10 * time.Second
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "grpc"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithServerParameters 设置初始服务端 keepalive 参数
+func WithServerParameters(params keepalive.ServerParameters, policy keepalive.EnforcementPolicy) Option {
+	return func(r *Reloader) {
+		r.server, r.policy = params, policy
+	}
+}
+
+// WithClientParameters 设置初始客户端 keepalive 参数
+func WithClientParameters(params keepalive.ClientParameters) Option {
+	return func(r *Reloader) {
+		r.client = params
+	}
+}
+
+// Reloader gRPC keepalive 参数重载器
+type Reloader struct {
+	prefix string
+
+	mu     sync.RWMutex
+	server keepalive.ServerParameters
+	policy keepalive.EnforcementPolicy
+	client keepalive.ClientParameters
+}
+
+// New 创建 gRPC keepalive 参数重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// ServerOptions 返回当前的服务端 keepalive 选项，用于创建 grpc.Server
+func (r *Reloader) ServerOptions() []grpc.ServerOption {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(r.server),
+		grpc.KeepaliveEnforcementPolicy(r.policy),
+	}
+}
+
+// DialOptions 返回当前的客户端 keepalive 选项，用于建立新连接
+func (r *Reloader) DialOptions() []grpc.DialOption {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return []grpc.DialOption{grpc.WithKeepaliveParams(r.client)}
+}
+
+// ClientParameters 返回当前的客户端 keepalive 参数
+func (r *Reloader) ClientParameters() keepalive.ClientParameters {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.client
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "grpckeepalive"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{
+		r.prefix + ".server.keepalive.*",
+		r.prefix + ".client.keepalive.*",
+	}
+}
+
+// Validate 验证 keepalive 参数
+func (r *Reloader) Validate(key, value string) error {
+	_, err := r.apply(key, value, &Reloader{})
+	return err
+}
+
+// OnChange 保存 keepalive 参数
+// 服务端参数返回 hotreload.RestartRequired，标记该变更需重启才能生效
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	restart, err := r.apply(key, newValue, r)
+	if err != nil {
+		return err
+	}
+	if restart {
+		return hotreload.RestartRequired(key + " takes effect after grpc server restart")
+	}
+	return nil
+}
+
+// apply 解析配置值并写入 target，返回该参数是否需要重启才能生效
+func (r *Reloader) apply(key, value string, target *Reloader) (restart bool, err error) {
+	value = strings.TrimSpace(value)
+
+	if setting, ok := strings.CutPrefix(key, r.prefix+".server.keepalive."); ok {
+		if setting == "permit_without_stream" {
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s: %s", setting, value)
+			}
+			target.policy.PermitWithoutStream = b
+			return true, nil
+		}
+
+		d, err := parseDuration(setting, value)
+		if err != nil {
+			return false, err
+		}
+		switch setting {
+		case "time":
+			if d > 0 && d < time.Second {
+				return false, fmt.Errorf("time must be >= 1s, got %s", d)
+			}
+			target.server.Time = d
+		case "timeout":
+			target.server.Timeout = d
+		case "max_connection_idle":
+			target.server.MaxConnectionIdle = d
+		case "max_connection_age":
+			target.server.MaxConnectionAge = d
+		case "max_connection_age_grace":
+			target.server.MaxConnectionAgeGrace = d
+		case "min_time":
+			target.policy.MinTime = d
+		default:
+			return false, fmt.Errorf("unsupported grpc keepalive key: %s", key)
+		}
+		return true, nil
+	}
+
+	if setting, ok := strings.CutPrefix(key, r.prefix+".client.keepalive."); ok {
+		switch setting {
+		case "permit_without_stream":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return false, fmt.Errorf("invalid %s: %s", setting, value)
+			}
+			target.client.PermitWithoutStream = b
+		case "time":
+			d, err := parseDuration(setting, value)
+			if err != nil {
+				return false, err
+			}
+			if d > 0 && d < minClientTime {
+				return false, fmt.Errorf("time must be >= %s, got %s", minClientTime, d)
+			}
+			target.client.Time = d
+		case "timeout":
+			d, err := parseDuration(setting, value)
+			if err != nil {
+				return false, err
+			}
+			target.client.Timeout = d
+		default:
+			return false, fmt.Errorf("unsupported grpc keepalive key: %s", key)
+		}
+		return false, nil
+	}
+
+	return false, fmt.Errorf("unsupported grpc keepalive key: %s", key)
+}
+
+// parseDuration 解析非负时长
+func parseDuration(setting, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", setting, value)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must be >= 0, got %s", setting, d)
+	}
+	return d, nil
+}
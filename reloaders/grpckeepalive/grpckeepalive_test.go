@@ -0,0 +1,84 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package grpckeepalive
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+	"google.golang.org/grpc/keepalive"
+)
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"grpc.server.keepalive.time", "2h", false},
+		{"grpc.server.keepalive.time", "0", false},
+		{"grpc.server.keepalive.time", "500ms", true},
+		{"grpc.server.keepalive.timeout", "-1s", true},
+		{"grpc.server.keepalive.min_time", "5m", false},
+		{"grpc.server.keepalive.permit_without_stream", "yes", true},
+		{"grpc.server.keepalive.unknown", "1s", true},
+		{"grpc.client.keepalive.time", "30s", false},
+		{"grpc.client.keepalive.time", "5s", true},
+		{"grpc.client.keepalive.timeout", "later", true},
+		{"grpc.client.keepalive.permit_without_stream", "true", false},
+		{"grpc.client.keepalive.min_time", "5m", true},
+		{"grpc.keepalive.time", "30s", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateLeavesParametersUnchanged(t *testing.T) {
+	r := New(WithClientParameters(keepalive.ClientParameters{Time: time.Minute}))
+	if err := r.Validate("grpc.client.keepalive.time", "30s"); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := r.ClientParameters().Time; got != time.Minute {
+		t.Fatalf("ClientParameters().Time = %s, want 1m0s", got)
+	}
+}
+
+func TestOnChange(t *testing.T) {
+	r := New()
+	if err := r.OnChange("grpc.client.keepalive.time", "", "30s"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := r.ClientParameters().Time; got != 30*time.Second {
+		t.Fatalf("ClientParameters().Time = %s, want 30s", got)
+	}
+
+	err := r.OnChange("grpc.server.keepalive.max_connection_age", "", "30m")
+	if !errors.Is(err, hotreload.ErrRestartRequired) {
+		t.Fatalf("OnChange() error = %v, want %v", err, hotreload.ErrRestartRequired)
+	}
+	r.mu.RLock()
+	age := r.server.MaxConnectionAge
+	r.mu.RUnlock()
+	if age != 30*time.Minute {
+		t.Fatalf("MaxConnectionAge = %s, want 30m0s", age)
+	}
+}
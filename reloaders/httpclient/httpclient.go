@@ -0,0 +1,220 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package httpclient 提供共享 HTTP 客户端的超时与连接参数热加载重载器
+//
+// 配置键（默认前缀 "http_client"）：
+//
+//	http_client.timeout                  请求总超时时间（如 "10s"，0 表示不限制）
+//	http_client.max_idle_conns_per_host  每个主机的最大空闲连接数
+//	http_client.idle_conn_timeout        空闲连接超时时间（如 "90s"）
+//	http_client.proxy_url                代理地址（为空表示使用环境变量中的代理设置）
+//
+// 连接参数变更时会基于当前 Transport 创建新的 Transport 并原子替换，旧 Transport 的空闲连接随即关闭；
+// 超时变更时会原子替换 *http.Client。业务代码应每次通过 Client() 获取客户端，或直接使用 Transport()
+package httpclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "http_client"
+
+// 支持的客户端参数
+const (
+	settingTimeout             = "timeout"
+	settingMaxIdleConnsPerHost = "max_idle_conns_per_host"
+	settingIdleConnTimeout     = "idle_conn_timeout"
+	settingProxyURL            = "proxy_url"
+)
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "http_client"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithTransport 设置初始 Transport（默认为 http.DefaultTransport 的副本）
+func WithTransport(transport *http.Transport) Option {
+	return func(r *Reloader) {
+		if transport != nil {
+			r.transport.Store(transport)
+		}
+	}
+}
+
+// WithTimeout 设置初始请求总超时时间
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Reloader) {
+		r.timeout = timeout
+	}
+}
+
+// Reloader HTTP 客户端重载器
+type Reloader struct {
+	prefix  string
+	timeout time.Duration
+
+	// 串行化配置变更
+	mu        sync.Mutex
+	transport atomic.Pointer[http.Transport]
+	client    atomic.Pointer[http.Client]
+}
+
+// New 创建 HTTP 客户端重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	if r.transport.Load() == nil {
+		r.transport.Store(http.DefaultTransport.(*http.Transport).Clone())
+	}
+	r.client.Store(&http.Client{Transport: roundTripper{r}, Timeout: r.timeout})
+	return r
+}
+
+// Client 返回当前使用的 HTTP 客户端
+func (r *Reloader) Client() *http.Client {
+	return r.client.Load()
+}
+
+// Transport 返回始终委托给当前 Transport 的 http.RoundTripper，可用于构建自定义客户端
+func (r *Reloader) Transport() http.RoundTripper {
+	return roundTripper{r}
+}
+
+// roundTripper 委托给当前 Transport 的 http.RoundTripper
+type roundTripper struct {
+	r *Reloader
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (rt roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return rt.r.transport.Load().RoundTrip(req)
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "httpclient"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{
+		r.prefix + "." + settingTimeout,
+		r.prefix + "." + settingMaxIdleConnsPerHost,
+		r.prefix + "." + settingIdleConnTimeout,
+		r.prefix + "." + settingProxyURL,
+	}
+}
+
+// Validate 验证客户端参数
+func (r *Reloader) Validate(key, value string) error {
+	setting, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return fmt.Errorf("unsupported http client key: %s", key)
+	}
+	value = strings.TrimSpace(value)
+
+	switch setting {
+	case settingTimeout, settingIdleConnTimeout:
+		d, err := time.ParseDuration(value)
+		if err != nil && value != "0" {
+			return fmt.Errorf("invalid %s: %s", setting, value)
+		}
+		if d < 0 {
+			return fmt.Errorf("%s must be >= 0, got %s", setting, d)
+		}
+	case settingMaxIdleConnsPerHost:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %s", setting, value)
+		}
+		if n < 0 {
+			return fmt.Errorf("%s must be >= 0, got %d", setting, n)
+		}
+	case settingProxyURL:
+		if value == "" {
+			return nil
+		}
+		u, err := url.Parse(value)
+		if err != nil {
+			return fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid proxy_url: %s", value)
+		}
+	default:
+		return fmt.Errorf("unsupported http client key: %s", key)
+	}
+	return nil
+}
+
+// OnChange 应用客户端参数
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	if err := r.Validate(key, newValue); err != nil {
+		return err
+	}
+	setting := strings.TrimPrefix(key, r.prefix+".")
+	value := strings.TrimSpace(newValue)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if setting == settingTimeout {
+		timeout, _ := time.ParseDuration(value)
+		client := *r.client.Load()
+		client.Timeout = timeout
+		r.client.Store(&client)
+		return nil
+	}
+
+	old := r.transport.Load()
+	transport := old.Clone()
+	switch setting {
+	case settingMaxIdleConnsPerHost:
+		transport.MaxIdleConnsPerHost, _ = strconv.Atoi(value)
+	case settingIdleConnTimeout:
+		transport.IdleConnTimeout, _ = time.ParseDuration(value)
+	case settingProxyURL:
+		if value == "" {
+			transport.Proxy = http.ProxyFromEnvironment
+		} else {
+			proxy, _ := url.Parse(value)
+			transport.Proxy = http.ProxyURL(proxy)
+		}
+	}
+	r.transport.Store(transport)
+	old.CloseIdleConnections()
+	return nil
+}
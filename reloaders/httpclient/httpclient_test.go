@@ -0,0 +1,111 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"http_client.timeout", "10s", false},
+		{"http_client.timeout", "0", false},
+		{"http_client.timeout", "-1s", true},
+		{"http_client.idle_conn_timeout", "soon", true},
+		{"http_client.max_idle_conns_per_host", "32", false},
+		{"http_client.max_idle_conns_per_host", "-1", true},
+		{"http_client.proxy_url", "", false},
+		{"http_client.proxy_url", "http://proxy.internal:3128", false},
+		{"http_client.proxy_url", "proxy.internal", true},
+		{"http_client.unknown", "1", true},
+		{"grpc.timeout", "10s", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeReplacesClientAndTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	r := New(WithTimeout(time.Second))
+	client := r.Client()
+	if err := r.OnChange("http_client.timeout", "1s", "5s"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := r.Client().Timeout; got != 5*time.Second {
+		t.Fatalf("Client().Timeout = %s, want 5s", got)
+	}
+	if client.Timeout != time.Second {
+		t.Fatalf("previous client Timeout = %s, want it left unchanged", client.Timeout)
+	}
+
+	transport := r.transport.Load()
+	if err := r.OnChange("http_client.max_idle_conns_per_host", "", "32"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if r.transport.Load() == transport {
+		t.Fatal("transport was not replaced")
+	}
+	if got := r.transport.Load().MaxIdleConnsPerHost; got != 32 {
+		t.Fatalf("MaxIdleConnsPerHost = %d, want 32", got)
+	}
+
+	// 之前取得的客户端同样委托给新的 Transport
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("StatusCode = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+
+	if err := r.OnChange("http_client.max_idle_conns_per_host", "32", "many"); err == nil {
+		t.Fatal("OnChange() with invalid value error = nil")
+	}
+	if got := r.transport.Load().MaxIdleConnsPerHost; got != 32 {
+		t.Fatalf("MaxIdleConnsPerHost after rejected change = %d, want 32", got)
+	}
+}
+
+func TestOnChangeProxyURL(t *testing.T) {
+	r := New()
+	if err := r.OnChange("http_client.proxy_url", "", "http://proxy.internal:3128"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "http://example.com/", nil)
+	proxy, err := r.transport.Load().Proxy(req)
+	if err != nil {
+		t.Fatalf("Proxy() error = %v", err)
+	}
+	if proxy == nil || proxy.Host != "proxy.internal:3128" {
+		t.Fatalf("Proxy() = %v, want proxy.internal:3128", proxy)
+	}
+}
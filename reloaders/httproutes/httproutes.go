@@ -0,0 +1,273 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package httproutes 提供由配置定义路由表与中间件顺序的 HTTP 管道重载器
+//
+// 配置键（默认前缀 "http"，值均为 JSON 数组）：
+//
+//	http.routes       路由表，如 [{"method": "GET", "path": "/orders/{id}", "handler": "get_order", "middlewares": ["auth"]}]
+//	http.middlewares  全局中间件顺序（由外向内），如 ["recover", "access_log", "cors"]
+//
+// 路由引用的处理器与中间件须预先以名称注册（WithHandler、WithMiddleware）。
+// 配置变更时重建完整的 http.Handler，构建成功后通过 Adapter 原子替换，构建失败时继续使用旧管道
+package httproutes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "http"
+
+// Middleware HTTP 中间件
+type Middleware func(http.Handler) http.Handler
+
+// Route 路由定义
+type Route struct {
+	// HTTP 方法（为空表示任意方法）
+	Method string `json:"method,omitempty"`
+	// 路径模式（http.ServeMux 语法，如 "/orders/{id}"）
+	Path string `json:"path"`
+	// 处理器名称
+	Handler string `json:"handler"`
+	// 路由级中间件（由外向内），在全局中间件之内执行
+	Middlewares []string `json:"middlewares,omitempty"`
+}
+
+// Adapter 路由适配器，接收重建后的 HTTP 管道
+// 可对接任意路由框架，默认可使用 SwapHandler
+type Adapter interface {
+	Swap(handler http.Handler)
+}
+
+// SwapHandler 可原子替换的 http.Handler，实现 Adapter
+type SwapHandler struct {
+	current atomic.Pointer[http.Handler]
+}
+
+// NewSwapHandler 创建可原子替换的 http.Handler，替换前所有请求返回 404
+func NewSwapHandler() *SwapHandler {
+	s := &SwapHandler{}
+	var notFound http.Handler = http.NotFoundHandler()
+	s.current.Store(&notFound)
+	return s
+}
+
+// Swap 实现 Adapter 接口
+func (s *SwapHandler) Swap(handler http.Handler) {
+	s.current.Store(&handler)
+}
+
+// ServeHTTP 实现 http.Handler 接口
+func (s *SwapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*s.current.Load()).ServeHTTP(w, r)
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "http"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithHandler 注册可被路由引用的处理器
+func WithHandler(name string, handler http.Handler) Option {
+	return func(r *Reloader) {
+		if name != "" && handler != nil {
+			r.handlers[name] = handler
+		}
+	}
+}
+
+// WithMiddleware 注册可被路由与全局中间件顺序引用的中间件
+func WithMiddleware(name string, mw Middleware) Option {
+	return func(r *Reloader) {
+		if name != "" && mw != nil {
+			r.middlewares[name] = mw
+		}
+	}
+}
+
+// WithRoutes 设置初始路由表与全局中间件顺序
+func WithRoutes(routes []Route, middlewares []string) Option {
+	return func(r *Reloader) {
+		r.routes, r.chain = routes, middlewares
+	}
+}
+
+// Reloader HTTP 管道重载器
+type Reloader struct {
+	prefix      string
+	adapter     Adapter
+	handlers    map[string]http.Handler
+	middlewares map[string]Middleware
+
+	mu     sync.Mutex
+	routes []Route
+	chain  []string
+}
+
+// New 创建 HTTP 管道重载器
+// 设置了初始路由表时立即构建并提交给 adapter，构建失败返回错误
+func New(adapter Adapter, opts ...Option) (*Reloader, error) {
+	if adapter == nil {
+		return nil, fmt.Errorf("adapter is nil")
+	}
+	r := &Reloader{
+		prefix:      DefaultPrefix,
+		adapter:     adapter,
+		handlers:    make(map[string]http.Handler),
+		middlewares: make(map[string]Middleware),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	if len(r.routes) > 0 {
+		handler, err := r.build(r.routes, r.chain)
+		if err != nil {
+			return nil, err
+		}
+		adapter.Swap(handler)
+	}
+	return r, nil
+}
+
+// Routes 返回当前路由表与全局中间件顺序
+func (r *Reloader) Routes() ([]Route, []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Route(nil), r.routes...), append([]string(nil), r.chain...)
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "httproutes"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".routes", r.prefix + ".middlewares"}
+}
+
+// Validate 解析配置并试构建 HTTP 管道
+func (r *Reloader) Validate(key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	routes, chain, err := r.next(key, value)
+	if err != nil {
+		return err
+	}
+	_, err = r.build(routes, chain)
+	return err
+}
+
+// OnChange 重建 HTTP 管道并通过 Adapter 原子替换
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	routes, chain, err := r.next(key, newValue)
+	if err != nil {
+		return err
+	}
+	handler, err := r.build(routes, chain)
+	if err != nil {
+		return err
+	}
+	r.adapter.Swap(handler)
+	r.routes, r.chain = routes, chain
+	return nil
+}
+
+// next 基于当前配置与配置变更返回新的路由表与全局中间件顺序
+func (r *Reloader) next(key, value string) ([]Route, []string, error) {
+	routes, chain := r.routes, r.chain
+	value = strings.TrimSpace(value)
+	switch key {
+	case r.prefix + ".routes":
+		routes = nil
+		if value != "" {
+			if err := json.Unmarshal([]byte(value), &routes); err != nil {
+				return nil, nil, fmt.Errorf("invalid routes: %w", err)
+			}
+		}
+	case r.prefix + ".middlewares":
+		chain = nil
+		if value != "" {
+			if err := json.Unmarshal([]byte(value), &chain); err != nil {
+				return nil, nil, fmt.Errorf("invalid middlewares: %w", err)
+			}
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported http routes key: %s", key)
+	}
+	return routes, chain, nil
+}
+
+// build 按路由表与全局中间件顺序构建 HTTP 管道
+func (r *Reloader) build(routes []Route, chain []string) (handler http.Handler, err error) {
+	mux := http.NewServeMux()
+	// http.ServeMux 在路由模式非法或冲突时 panic，转换为错误
+	defer func() {
+		if p := recover(); p != nil {
+			handler, err = nil, fmt.Errorf("invalid routes: %v", p)
+		}
+	}()
+
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, "/") {
+			return nil, fmt.Errorf("route path must start with \"/\": %q", route.Path)
+		}
+		h, ok := r.handlers[route.Handler]
+		if !ok {
+			return nil, fmt.Errorf("unknown handler %q for route %s", route.Handler, route.Path)
+		}
+		h, err := r.wrap(h, route.Middlewares)
+		if err != nil {
+			return nil, fmt.Errorf("route %s: %w", route.Path, err)
+		}
+		pattern := route.Path
+		if route.Method != "" {
+			pattern = strings.ToUpper(route.Method) + " " + route.Path
+		}
+		mux.Handle(pattern, h)
+	}
+
+	return r.wrap(mux, chain)
+}
+
+// wrap 按由外向内的顺序为处理器套上中间件
+func (r *Reloader) wrap(handler http.Handler, names []string) (http.Handler, error) {
+	for i := len(names) - 1; i >= 0; i-- {
+		mw, ok := r.middlewares[names[i]]
+		if !ok {
+			return nil, fmt.Errorf("unknown middleware %q", names[i])
+		}
+		handler = mw(handler)
+	}
+	return handler, nil
+}
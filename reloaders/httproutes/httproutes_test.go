@@ -0,0 +1,135 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package httproutes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// text 返回输出固定文本的处理器
+func text(body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+// tag 返回在响应头 X-Chain 中记录名称的中间件
+func tag(name string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Add("X-Chain", name)
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+// newReloader 创建注册了 orders、health 处理器与 auth、log 中间件的重载器
+func newReloader(t *testing.T, opts ...Option) (*Reloader, *SwapHandler) {
+	t.Helper()
+	swap := NewSwapHandler()
+	opts = append([]Option{
+		WithHandler("orders", text("orders")),
+		WithHandler("health", text("ok")),
+		WithMiddleware("auth", tag("auth")),
+		WithMiddleware("log", tag("log")),
+	}, opts...)
+	r, err := New(swap, opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return r, swap
+}
+
+// serve 请求 handler 并返回响应
+func serve(handler http.Handler, method, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestValidate(t *testing.T) {
+	r, _ := newReloader(t)
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"http.routes", `[{"method": "GET", "path": "/orders", "handler": "orders"}]`, false},
+		{"http.routes", "", false},
+		{"http.routes", `[{"path": "orders", "handler": "orders"}]`, true},
+		{"http.routes", `[{"path": "/orders", "handler": "missing"}]`, true},
+		{"http.routes", `[{"path": "/orders", "handler": "orders", "middlewares": ["missing"]}]`, true},
+		// 重复的路由模式会使 ServeMux panic，应作为校验错误返回
+		{"http.routes", `[{"path": "/orders", "handler": "orders"}, {"path": "/orders", "handler": "health"}]`, true},
+		{"http.routes", `{"path": "/orders"}`, true},
+		{"http.middlewares", `["log", "auth"]`, false},
+		{"http.middlewares", `["missing"]`, true},
+		{"http.listen", ":8080", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeSwapsRoutes(t *testing.T) {
+	r, swap := newReloader(t, WithRoutes([]Route{{Path: "/healthz", Handler: "health"}}, nil))
+	if rec := serve(swap, http.MethodGet, "/healthz"); rec.Body.String() != "ok" {
+		t.Fatalf("GET /healthz body = %q, want ok", rec.Body.String())
+	}
+
+	routes := `[{"path": "/healthz", "handler": "health"}, {"method": "post", "path": "/orders", "handler": "orders", "middlewares": ["auth"]}]`
+	if err := r.OnChange("http.routes", "", routes); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("http.middlewares", "", `["log"]`); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+
+	rec := serve(swap, http.MethodPost, "/orders")
+	if rec.Body.String() != "orders" {
+		t.Fatalf("POST /orders body = %q, want orders", rec.Body.String())
+	}
+	if got := strings.Join(rec.Header().Values("X-Chain"), ","); got != "log,auth" {
+		t.Fatalf("middleware chain = %q, want log,auth", got)
+	}
+	if rec := serve(swap, http.MethodGet, "/orders"); rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("GET /orders status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+
+	if err := r.OnChange("http.routes", routes, `[{"path": "/orders", "handler": "missing"}]`); err == nil {
+		t.Fatal("OnChange() with unknown handler error = nil")
+	}
+	if rec := serve(swap, http.MethodPost, "/orders"); rec.Body.String() != "orders" {
+		t.Fatal("rejected change replaced the routes")
+	}
+	if got, chain := r.Routes(); len(got) != 2 || len(chain) != 1 {
+		t.Fatalf("Routes() = %v, %v", got, chain)
+	}
+}
+
+func TestNewRejectsInvalidInitialRoutes(t *testing.T) {
+	if _, err := New(nil); err == nil {
+		t.Fatal("New() with nil adapter error = nil")
+	}
+	if _, err := New(NewSwapHandler(), WithRoutes([]Route{{Path: "/orders", Handler: "orders"}}, nil)); err == nil {
+		t.Fatal("New() with unknown handler error = nil")
+	}
+}
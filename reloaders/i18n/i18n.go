@@ -0,0 +1,273 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package i18n 提供翻译消息包热加载重载器
+//
+// 配置键（默认前缀 "i18n"，<locale> 为语言标签，如 "zh-CN"、"en"）：
+//
+//	i18n.<locale>  消息包：JSON 对象（消息 ID → 文本），或以 "file:" 开头的 JSON 文件路径，值为空表示移除该语言
+//
+// 文件形式的消息包可通过 Watch 定期检查文件修改并重新加载，消息目录整体原子替换，解析失败时保留旧目录
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "i18n"
+
+// filePrefix 文件形式消息包的配置值前缀
+const filePrefix = "file:"
+
+// Bundle 单个语言的消息包
+type Bundle map[string]string
+
+// bundleSource 文件形式消息包的来源
+type bundleSource struct {
+	path    string
+	modTime time.Time
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "i18n"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithFallback 设置缺少翻译时回退的语言（默认 "en"）
+func WithFallback(locale string) Option {
+	return func(r *Reloader) {
+		r.fallback = locale
+	}
+}
+
+// Reloader 翻译消息包重载器
+type Reloader struct {
+	prefix   string
+	fallback string
+
+	// 串行化配置变更
+	mu      sync.Mutex
+	catalog atomic.Pointer[map[string]Bundle]
+	files   map[string]bundleSource
+}
+
+// New 创建翻译消息包重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix:   DefaultPrefix,
+		fallback: "en",
+		files:    make(map[string]bundleSource),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	catalog := make(map[string]Bundle)
+	r.catalog.Store(&catalog)
+	return r
+}
+
+// Lookup 返回指定语言的消息文本
+func (r *Reloader) Lookup(locale, id string) (string, bool) {
+	bundle, ok := (*r.catalog.Load())[locale]
+	if !ok {
+		return "", false
+	}
+	text, ok := bundle[id]
+	return text, ok
+}
+
+// T 返回翻译后的消息文本，args 不为空时按 fmt.Sprintf 格式化
+// 依次尝试 locale、locale 的主语言（如 "zh-CN" → "zh"）与回退语言，均不存在时返回 id
+func (r *Reloader) T(locale, id string, args ...any) string {
+	text, ok := r.Lookup(locale, id)
+	if !ok {
+		if base, _, found := strings.Cut(locale, "-"); found {
+			text, ok = r.Lookup(base, id)
+		}
+	}
+	if !ok && r.fallback != "" {
+		text, ok = r.Lookup(r.fallback, id)
+	}
+	if !ok {
+		return id
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
+
+// Locales 返回已加载的语言
+func (r *Reloader) Locales() []string {
+	catalog := *r.catalog.Load()
+	locales := make([]string, 0, len(catalog))
+	for locale := range catalog {
+		locales = append(locales, locale)
+	}
+	return locales
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "i18n"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".*"}
+}
+
+// Validate 验证消息包能够被解析
+func (r *Reloader) Validate(key, value string) error {
+	if _, err := r.locale(key); err != nil {
+		return err
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	_, _, err := loadBundle(value)
+	return err
+}
+
+// OnChange 加载消息包并原子替换消息目录
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	locale, err := r.locale(key)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if strings.TrimSpace(newValue) == "" {
+		delete(r.files, locale)
+		r.store(locale, nil)
+		return nil
+	}
+
+	bundle, source, err := loadBundle(newValue)
+	if err != nil {
+		return err
+	}
+	if source != nil {
+		r.files[locale] = *source
+	} else {
+		delete(r.files, locale)
+	}
+	r.store(locale, bundle)
+	return nil
+}
+
+// Watch 每隔 interval 检查文件形式的消息包，文件修改后重新加载，直到 ctx 结束
+// 重新加载失败时保留旧消息包，错误通过 onError 回调（可为 nil）通知
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration, onError func(locale string, err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reloadFiles(onError)
+		}
+	}
+}
+
+// reloadFiles 重新加载已修改的文件形式消息包
+func (r *Reloader) reloadFiles(onError func(locale string, err error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for locale, source := range r.files {
+		info, err := os.Stat(source.path)
+		if err == nil && !info.ModTime().After(source.modTime) {
+			continue
+		}
+		bundle, next, err := loadBundle(filePrefix + source.path)
+		if err != nil {
+			if onError != nil {
+				onError(locale, err)
+			}
+			continue
+		}
+		r.files[locale] = *next
+		r.store(locale, bundle)
+	}
+}
+
+// store 以写时复制方式更新消息目录（调用方持有 mu）
+func (r *Reloader) store(locale string, bundle Bundle) {
+	catalog := maps.Clone(*r.catalog.Load())
+	if bundle == nil {
+		delete(catalog, locale)
+	} else {
+		catalog[locale] = bundle
+	}
+	r.catalog.Store(&catalog)
+}
+
+// locale 解析配置键中的语言标签
+func (r *Reloader) locale(key string) (string, error) {
+	locale, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok || locale == "" {
+		return "", fmt.Errorf("unsupported i18n key: %s", key)
+	}
+	return locale, nil
+}
+
+// loadBundle 解析配置值对应的消息包，文件形式时同时返回文件来源
+func loadBundle(value string) (Bundle, *bundleSource, error) {
+	value = strings.TrimSpace(value)
+	var source *bundleSource
+	data := []byte(value)
+
+	if path, ok := strings.CutPrefix(value, filePrefix); ok {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		if data, err = os.ReadFile(path); err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		source = &bundleSource{path: path, modTime: info.ModTime()}
+	}
+
+	var bundle Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, nil, fmt.Errorf("invalid message bundle: %w", err)
+	}
+	if bundle == nil {
+		bundle = Bundle{}
+	}
+	return bundle, source, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	r := New()
+	dir := t.TempDir()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"i18n.en", `{"greeting": "Hello"}`, false},
+		{"i18n.en", "", false},
+		{"i18n.en", `["Hello"]`, true},
+		{"i18n.en", "file:" + filepath.Join(dir, "missing.json"), true},
+		{"i18n.", `{}`, true},
+		{"messages.en", `{}`, true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTFallsBackToBaseAndDefaultLocale(t *testing.T) {
+	r := New()
+	for key, value := range map[string]string{
+		"i18n.en":    `{"greeting": "Hello, %s", "bye": "Bye, %s"}`,
+		"i18n.zh":    `{"greeting": "你好，%s"}`,
+		"i18n.zh-TW": `{"greeting": "您好，%s"}`,
+	} {
+		if err := r.OnChange(key, "", value); err != nil {
+			t.Fatalf("OnChange(%q) error = %v", key, err)
+		}
+	}
+	tests := []struct {
+		locale, id, want string
+	}{
+		{"zh-TW", "greeting", "您好，alice"},
+		{"zh-CN", "greeting", "你好，alice"},
+		{"fr", "greeting", "Hello, alice"},
+		{"zh", "bye", "Bye, alice"},
+		{"zh", "missing", "missing"},
+	}
+	for _, tt := range tests {
+		if got := r.T(tt.locale, tt.id, "alice"); got != tt.want {
+			t.Errorf("T(%q, %q) = %q, want %q", tt.locale, tt.id, got, tt.want)
+		}
+	}
+
+	if err := r.OnChange("i18n.zh", "", ""); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := r.T("zh-CN", "greeting", "alice"); got != "Hello, alice" {
+		t.Fatalf("T() after locale removed = %q, want fallback", got)
+	}
+}
+
+func TestReloadFilesPicksUpModifiedBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "en.json")
+	if err := os.WriteFile(path, []byte(`{"greeting": "Hello"}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	r := New()
+	if err := r.OnChange("i18n.en", "", "file:"+path); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := r.T("en", "greeting"); got != "Hello" {
+		t.Fatalf("T() = %q, want Hello", got)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"greeting": "Hi"}`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	r.reloadFiles(func(locale string, err error) {
+		t.Errorf("reload %s error = %v", locale, err)
+	})
+	if got := r.T("en", "greeting"); got != "Hi" {
+		t.Fatalf("T() after reload = %q, want Hi", got)
+	}
+
+	// 文件内容无效时保留原有消息并回调错误
+	if err := os.WriteFile(path, []byte(`{`), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	later := future.Add(time.Hour)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+	var failed string
+	r.reloadFiles(func(locale string, _ error) { failed = locale })
+	if failed != "en" || r.T("en", "greeting") != "Hi" {
+		t.Fatalf("reload of invalid file: failed = %q, T() = %q", failed, r.T("en", "greeting"))
+	}
+}
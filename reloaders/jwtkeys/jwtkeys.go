@@ -0,0 +1,335 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package jwtkeys 提供 JWT 签名/验签密钥集（kid → key）轮换重载器
+//
+// 配置键（默认前缀 "jwt"）：
+//
+//	jwt.keys.<kid>    密钥：PEM 格式的公钥、证书或私钥，或 "hmac:<base64>" 形式的 HMAC 密钥；值为空表示移除该密钥
+//	jwt.signing_kid   签名使用的 kid（对应密钥须为私钥或 HMAC 密钥）
+//
+// 轮换时应先添加新密钥、切换 signing_kid，待旧令牌过期后再移除旧密钥；
+// 移除最后一个密钥或正在用于签名的密钥会被拒绝，保证始终至少存在一个可用密钥
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "jwt"
+
+// hmacPrefix HMAC 密钥配置值前缀
+const hmacPrefix = "hmac:"
+
+// Key 密钥
+type Key struct {
+	// 密钥 ID
+	ID string
+	// 验签密钥（*rsa.PublicKey、*ecdsa.PublicKey、ed25519.PublicKey 或 HMAC 密钥 []byte）
+	Verify any
+	// 签名密钥（私钥或 HMAC 密钥，仅配置了私钥或 HMAC 密钥时有效）
+	Sign any
+}
+
+// keyset 不可修改的密钥集
+type keyset struct {
+	keys       map[string]*Key
+	signingKID string
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "jwt"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// Reloader JWT 密钥集重载器
+type Reloader struct {
+	prefix string
+
+	// 串行化配置变更
+	mu  sync.Mutex
+	set atomic.Pointer[keyset]
+}
+
+// New 创建 JWT 密钥集重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	r.set.Store(&keyset{keys: make(map[string]*Key)})
+	return r
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "jwtkeys"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".keys.*", r.prefix + ".signing_kid"}
+}
+
+// Validate 验证密钥变更
+func (r *Reloader) Validate(key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err := r.next(key, value)
+	return err
+}
+
+// OnChange 以新密钥集原子替换当前密钥集
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	set, err := r.next(key, newValue)
+	if err != nil {
+		return err
+	}
+	r.set.Store(set)
+	return nil
+}
+
+// next 基于当前密钥集与配置变更构建新密钥集
+func (r *Reloader) next(key, value string) (*keyset, error) {
+	current := r.set.Load()
+	set := &keyset{keys: maps.Clone(current.keys), signingKID: current.signingKID}
+	value = strings.TrimSpace(value)
+
+	if key == r.prefix+".signing_kid" {
+		if value != "" {
+			k, ok := set.keys[value]
+			if !ok {
+				return nil, fmt.Errorf("signing kid not found in keyset: %s", value)
+			}
+			if k.Sign == nil {
+				return nil, fmt.Errorf("key %s has no signing material", value)
+			}
+		}
+		set.signingKID = value
+		return set, nil
+	}
+
+	kid, ok := strings.CutPrefix(key, r.prefix+".keys.")
+	if !ok || kid == "" {
+		return nil, fmt.Errorf("unsupported jwt key: %s", key)
+	}
+
+	if value == "" {
+		if _, exists := set.keys[kid]; !exists {
+			return set, nil
+		}
+		if kid == set.signingKID {
+			return nil, fmt.Errorf("key %s is the current signing key, switch signing_kid first", kid)
+		}
+		if len(set.keys) == 1 {
+			return nil, fmt.Errorf("key %s is the last key in keyset, add a new key first", kid)
+		}
+		delete(set.keys, kid)
+		return set, nil
+	}
+
+	k, err := parseKey(kid, value)
+	if err != nil {
+		return nil, err
+	}
+	if kid == set.signingKID && k.Sign == nil {
+		return nil, fmt.Errorf("key %s is the current signing key and must keep signing material", kid)
+	}
+	set.keys[kid] = k
+	return set, nil
+}
+
+// Lookup 返回指定 kid 的密钥
+func (r *Reloader) Lookup(kid string) (*Key, bool) {
+	k, ok := r.set.Load().keys[kid]
+	return k, ok
+}
+
+// KeyIDs 返回密钥集中的所有 kid
+func (r *Reloader) KeyIDs() []string {
+	set := r.set.Load()
+	ids := make([]string, 0, len(set.keys))
+	for kid := range set.keys {
+		ids = append(ids, kid)
+	}
+	return ids
+}
+
+// SigningKey 返回当前签名使用的 kid 与签名密钥
+func (r *Reloader) SigningKey() (kid string, key any, err error) {
+	set := r.set.Load()
+	if set.signingKID == "" {
+		return "", nil, fmt.Errorf("signing kid is not configured")
+	}
+	return set.signingKID, set.keys[set.signingKID].Sign, nil
+}
+
+// Keyfunc 用作 jwt.Parse 的 jwt.Keyfunc，按令牌头中的 kid 查找验签密钥
+// 令牌未携带 kid 且密钥集中只有一个密钥时使用该密钥；签名算法与密钥类型不匹配时拒绝，防止算法混淆攻击
+func (r *Reloader) Keyfunc(token *jwt.Token) (any, error) {
+	set := r.set.Load()
+
+	var k *Key
+	if kid, _ := token.Header["kid"].(string); kid != "" {
+		found, ok := set.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown kid: %s", kid)
+		}
+		k = found
+	} else if len(set.keys) == 1 {
+		for _, only := range set.keys {
+			k = only
+		}
+	} else {
+		return nil, fmt.Errorf("token has no kid")
+	}
+
+	if !methodMatchesKey(token.Method, k.Verify) {
+		return nil, fmt.Errorf("signing method %s does not match key %s", token.Method.Alg(), k.ID)
+	}
+	return k.Verify, nil
+}
+
+// methodMatchesKey 检查签名算法与密钥类型是否匹配
+func methodMatchesKey(method jwt.SigningMethod, key any) bool {
+	switch key.(type) {
+	case []byte:
+		_, ok := method.(*jwt.SigningMethodHMAC)
+		return ok
+	case *rsa.PublicKey:
+		switch method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			return true
+		}
+	case *ecdsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodECDSA)
+		return ok
+	case ed25519.PublicKey:
+		_, ok := method.(*jwt.SigningMethodEd25519)
+		return ok
+	}
+	return false
+}
+
+// parseKey 解析密钥配置值
+func parseKey(kid, value string) (*Key, error) {
+	if secret, ok := strings.CutPrefix(value, hmacPrefix); ok {
+		data, err := base64.StdEncoding.DecodeString(secret)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hmac key %s: %w", kid, err)
+		}
+		if len(data) < 32 {
+			return nil, fmt.Errorf("hmac key %s must be at least 32 bytes, got %d", kid, len(data))
+		}
+		return &Key{ID: kid, Verify: data, Sign: data}, nil
+	}
+
+	block, _ := pem.Decode([]byte(value))
+	if block == nil {
+		return nil, fmt.Errorf("key %s is neither PEM nor %q prefixed", kid, hmacPrefix)
+	}
+
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate %s: %w", kid, err)
+		}
+		return newPublicKey(kid, cert.PublicKey)
+	case "PUBLIC KEY":
+		pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %w", kid, err)
+		}
+		return newPublicKey(kid, pub)
+	case "RSA PUBLIC KEY":
+		pub, err := x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %s: %w", kid, err)
+		}
+		return newPublicKey(kid, pub)
+	case "PRIVATE KEY", "RSA PRIVATE KEY", "EC PRIVATE KEY":
+		priv, err := parsePrivateKey(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key %s: %w", kid, err)
+		}
+		k, err := newPublicKey(kid, priv.Public())
+		if err != nil {
+			return nil, err
+		}
+		k.Sign = priv
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q for key %s", block.Type, kid)
+	}
+}
+
+// newPublicKey 创建仅用于验签的密钥
+func newPublicKey(kid string, pub any) (*Key, error) {
+	switch pub.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return &Key{ID: kid, Verify: pub}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T for key %s", pub, kid)
+	}
+}
+
+// parsePrivateKey 解析 PEM 私钥
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return s, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newEd25519Key 生成 PKCS8 PEM 编码的 Ed25519 私钥
+func newEd25519Key(t *testing.T) string {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// hmacKey 返回 length 字节的 HMAC 密钥配置值
+func hmacKey(length int) string {
+	return hmacPrefix + base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", length)))
+}
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"jwt.keys.k1", newEd25519Key(t), false},
+		{"jwt.keys.k1", hmacKey(32), false},
+		{"jwt.keys.k1", hmacKey(16), true},
+		{"jwt.keys.k1", "hmac:not base64!", true},
+		{"jwt.keys.k1", "plain secret", true},
+		{"jwt.keys.k1", "", false},
+		{"jwt.keys.", hmacKey(32), true},
+		{"jwt.signing_kid", "missing", true},
+		{"jwt.unknown", "1", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.key, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeRotatesSigningKey(t *testing.T) {
+	r := New()
+	mustChange := func(key, value string) {
+		t.Helper()
+		if err := r.OnChange(key, "", value); err != nil {
+			t.Fatalf("OnChange(%q) error = %v", key, err)
+		}
+	}
+	mustChange("jwt.keys.k1", newEd25519Key(t))
+	mustChange("jwt.signing_kid", "k1")
+
+	sign := func() string {
+		t.Helper()
+		kid, key, err := r.SigningKey()
+		if err != nil {
+			t.Fatalf("SigningKey() error = %v", err)
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"sub": "alice"})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("SignedString() error = %v", err)
+		}
+		return signed
+	}
+	old := sign()
+
+	if err := r.OnChange("jwt.keys.k1", "", ""); err == nil {
+		t.Fatal("OnChange() removing the signing key error = nil")
+	}
+
+	mustChange("jwt.keys.k2", newEd25519Key(t))
+	mustChange("jwt.signing_kid", "k2")
+	current := sign()
+
+	// 轮换窗口内新旧令牌均可验证
+	for _, signed := range []string{old, current} {
+		if _, err := jwt.Parse(signed, r.Keyfunc); err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+	}
+
+	mustChange("jwt.keys.k1", "")
+	if _, err := jwt.Parse(old, r.Keyfunc); err == nil {
+		t.Fatal("Parse() of token signed by removed key error = nil")
+	}
+	if err := r.OnChange("jwt.keys.k2", "", ""); err == nil {
+		t.Fatal("OnChange() removing the last key error = nil")
+	}
+}
+
+func TestKeyfuncRejectsMismatchedMethod(t *testing.T) {
+	r := New()
+	if err := r.OnChange("jwt.keys.k1", "", newEd25519Key(t)); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	secret := []byte(strings.Repeat("k", 32))
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "alice"})
+	signed, err := token.SignedString(secret)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	// 未携带 kid 时使用唯一的密钥，但签名算法与密钥类型不匹配
+	if _, err := jwt.Parse(signed, r.Keyfunc); err == nil {
+		t.Fatal("Parse() error = nil, want signing method mismatch")
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package loglevel 提供基于 zap.AtomicLevel 的日志级别热加载重载器
+//
+// 配置键（默认前缀 "log.level"）：
+//
+//	log.level           全局日志级别（debug、info、warn、error、dpanic、panic、fatal）
+//	log.level.<module>  模块日志级别，值为空时恢复跟随全局级别
+package loglevel
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultKey 默认的全局日志级别配置键
+const DefaultKey = "log.level"
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithKey 设置全局日志级别配置键（默认 "log.level"），模块级别配置键为 "<key>.<module>"
+func WithKey(key string) Option {
+	return func(r *Reloader) {
+		if key != "" {
+			r.key = key
+		}
+	}
+}
+
+// WithModule 绑定模块日志级别，对应配置键 "<key>.<name>"
+func WithModule(name string, level zap.AtomicLevel) Option {
+	return func(r *Reloader) {
+		if name != "" {
+			r.modules[name] = &module{level: level}
+		}
+	}
+}
+
+// module 模块日志级别
+type module struct {
+	level zap.AtomicLevel
+	// 是否单独设置了级别（否则跟随全局级别）
+	override bool
+}
+
+// Reloader 日志级别重载器
+type Reloader struct {
+	key  string
+	root zap.AtomicLevel
+
+	mu      sync.Mutex
+	modules map[string]*module
+}
+
+// New 创建日志级别重载器
+// root 为全局日志级别，通常为构建 zap.Logger 时使用的 zap.Config.Level
+func New(root zap.AtomicLevel, opts ...Option) *Reloader {
+	r := &Reloader{
+		key:     DefaultKey,
+		root:    root,
+		modules: make(map[string]*module),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	for _, mod := range r.modules {
+		mod.level.SetLevel(root.Level())
+	}
+	return r
+}
+
+// Module 返回模块日志级别，不存在时创建并跟随全局级别
+// 可用于为模块构建独立的 zap.Logger（如 zap.New(core, zap.IncreaseLevel(level))）
+func (r *Reloader) Module(name string) zap.AtomicLevel {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if mod, ok := r.modules[name]; ok {
+		return mod.level
+	}
+	mod := &module{level: zap.NewAtomicLevelAt(r.root.Level())}
+	r.modules[name] = mod
+	return mod.level
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "loglevel"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.key + ".*"}
+}
+
+// Validate 验证日志级别名称
+func (r *Reloader) Validate(key, value string) error {
+	name, ok := r.moduleName(key)
+	if !ok {
+		return fmt.Errorf("unsupported log level key: %s", key)
+	}
+	if name != "" && strings.TrimSpace(value) == "" {
+		return nil
+	}
+	_, err := ParseLevel(value)
+	return err
+}
+
+// OnChange 应用日志级别变更
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	name, ok := r.moduleName(key)
+	if !ok {
+		return fmt.Errorf("unsupported log level key: %s", key)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// 模块级别：值为空时恢复跟随全局级别
+	if name != "" {
+		mod, exists := r.modules[name]
+		if !exists {
+			mod = &module{level: zap.NewAtomicLevelAt(r.root.Level())}
+			r.modules[name] = mod
+		}
+		if strings.TrimSpace(newValue) == "" {
+			mod.override = false
+			mod.level.SetLevel(r.root.Level())
+			return nil
+		}
+		level, err := ParseLevel(newValue)
+		if err != nil {
+			return err
+		}
+		mod.override = true
+		mod.level.SetLevel(level)
+		return nil
+	}
+
+	// 全局级别：同步更新未单独设置级别的模块
+	level, err := ParseLevel(newValue)
+	if err != nil {
+		return err
+	}
+	r.root.SetLevel(level)
+	for _, mod := range r.modules {
+		if !mod.override {
+			mod.level.SetLevel(level)
+		}
+	}
+	return nil
+}
+
+// moduleName 解析配置键对应的模块名，全局级别配置键返回空字符串
+func (r *Reloader) moduleName(key string) (string, bool) {
+	if key == r.key {
+		return "", true
+	}
+	name, ok := strings.CutPrefix(key, r.key+".")
+	if !ok || name == "" {
+		return "", false
+	}
+	return name, true
+}
+
+// ParseLevel 解析日志级别名称（不区分大小写，支持 "warning" 作为 "warn" 的别名）
+func ParseLevel(value string) (zapcore.Level, error) {
+	text := strings.ToLower(strings.TrimSpace(value))
+	if text == "warning" {
+		text = "warn"
+	}
+	level, err := zapcore.ParseLevel(text)
+	if text == "" || err != nil {
+		return level, fmt.Errorf("invalid log level %q: must be one of debug, info, warn, error, dpanic, panic, fatal", value)
+	}
+	return level, nil
+}
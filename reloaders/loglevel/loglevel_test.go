@@ -0,0 +1,79 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package loglevel
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestValidate(t *testing.T) {
+	r := New(zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"log.level", "debug", false},
+		{"log.level", " WARNING ", false},
+		{"log.level", "", true},
+		{"log.level", "verbose", true},
+		{"log.level.db", "", false},
+		{"log.level.db", "loud", true},
+		{"log.levels", "info", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeModulesFollowRootUntilOverridden(t *testing.T) {
+	root := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	db := zap.NewAtomicLevel()
+	r := New(root, WithModule("db", db))
+	cache := r.Module("cache")
+
+	if err := r.OnChange("log.level.db", "", "error"); err != nil {
+		t.Fatalf("OnChange(db) error = %v", err)
+	}
+	if err := r.OnChange("log.level", "info", "debug"); err != nil {
+		t.Fatalf("OnChange(root) error = %v", err)
+	}
+	if root.Level() != zapcore.DebugLevel || cache.Level() != zapcore.DebugLevel {
+		t.Fatalf("root, cache = %s, %s, want debug", root.Level(), cache.Level())
+	}
+	if db.Level() != zapcore.ErrorLevel {
+		t.Fatalf("db = %s, want overridden error", db.Level())
+	}
+
+	// 清空模块级别后恢复跟随全局级别
+	if err := r.OnChange("log.level.db", "error", ""); err != nil {
+		t.Fatalf("OnChange(db) error = %v", err)
+	}
+	if db.Level() != zapcore.DebugLevel {
+		t.Fatalf("db = %s, want debug after clearing override", db.Level())
+	}
+	if err := r.OnChange("log.level", "debug", "nope"); err == nil {
+		t.Fatal("OnChange(root) with invalid level error = nil")
+	}
+	if root.Level() != zapcore.DebugLevel {
+		t.Fatalf("root = %s, want unchanged debug", root.Level())
+	}
+}
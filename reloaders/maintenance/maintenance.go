@@ -0,0 +1,183 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package maintenance 提供维护模式开关重载器及配套 HTTP 中间件
+//
+// 配置键（默认前缀 "service"）：
+//
+//	service.maintenance_mode         是否开启维护模式（true/false）
+//	service.maintenance_message      维护模式下返回的提示信息
+//	service.maintenance_allow_paths  维护模式下仍放行的路径前缀（如 "/healthz, /admin/"）
+package maintenance
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-anyway/framework-hotreload/internal/values"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "service"
+
+// DefaultMessage 默认的维护提示信息
+const DefaultMessage = "service is under maintenance"
+
+// State 维护模式状态，创建后不可修改
+type State struct {
+	// 是否开启维护模式
+	Enabled bool `json:"enabled"`
+	// 提示信息
+	Message string `json:"message"`
+	// 仍放行的路径前缀
+	AllowPaths []string `json:"allow_paths,omitempty"`
+}
+
+// Allowed 返回维护模式下是否放行该路径
+func (s *State) Allowed(path string) bool {
+	for _, prefix := range s.AllowPaths {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "service"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// Reloader 维护模式重载器
+type Reloader struct {
+	prefix string
+
+	// 串行化配置变更
+	mu    sync.Mutex
+	state atomic.Pointer[State]
+}
+
+// New 创建维护模式重载器，初始为关闭状态
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	r.state.Store(&State{Message: DefaultMessage})
+	return r
+}
+
+// State 返回当前维护模式状态（只读）
+func (r *Reloader) State() *State {
+	return r.state.Load()
+}
+
+// Enabled 返回是否处于维护模式
+func (r *Reloader) Enabled() bool {
+	return r.state.Load().Enabled
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "maintenance"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{
+		r.prefix + ".maintenance_mode",
+		r.prefix + ".maintenance_message",
+		r.prefix + ".maintenance_allow_paths",
+	}
+}
+
+// Validate 验证维护模式配置
+func (r *Reloader) Validate(key, value string) error {
+	_, err := r.next(key, value)
+	return err
+}
+
+// OnChange 以新状态原子替换当前维护模式状态
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, err := r.next(key, newValue)
+	if err != nil {
+		return err
+	}
+	r.state.Store(state)
+	return nil
+}
+
+// next 基于当前状态与配置变更构建新状态
+func (r *Reloader) next(key, value string) (*State, error) {
+	state := *r.state.Load()
+	var err error
+	switch key {
+	case r.prefix + ".maintenance_mode":
+		state.Enabled, err = strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance_mode: %s", value)
+		}
+	case r.prefix + ".maintenance_message":
+		state.Message = strings.TrimSpace(value)
+		if state.Message == "" {
+			state.Message = DefaultMessage
+		}
+	case r.prefix + ".maintenance_allow_paths":
+		state.AllowPaths, err = values.ParseList(value)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range state.AllowPaths {
+			if !strings.HasPrefix(path, "/") {
+				return nil, fmt.Errorf("allow path must start with \"/\": %s", path)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported maintenance key: %s", key)
+	}
+	return &state, nil
+}
+
+// Middleware 返回维护模式 HTTP 中间件
+// 维护模式下，未放行的请求返回 503 与 JSON 格式的提示信息
+func (r *Reloader) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		state := r.state.Load()
+		if !state.Enabled || state.Allowed(req.URL.Path) {
+			next.ServeHTTP(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": state.Message})
+	})
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package maintenance
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"service.maintenance_mode", "true", false},
+		{"service.maintenance_mode", "soon", true},
+		{"service.maintenance_message", "", false},
+		{"service.maintenance_allow_paths", "/healthz, /admin/", false},
+		{"service.maintenance_allow_paths", "healthz", true},
+		{"service.unknown", "1", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	r := New()
+	handler := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	if rec := serve("/orders"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d before maintenance, want %d", rec.Code, http.StatusOK)
+	}
+
+	for key, value := range map[string]string{
+		"service.maintenance_mode":        "true",
+		"service.maintenance_message":     "back at 10:00",
+		"service.maintenance_allow_paths": "/healthz",
+	} {
+		if err := r.OnChange(key, "", value); err != nil {
+			t.Fatalf("OnChange(%q) error = %v", key, err)
+		}
+	}
+	rec := serve("/orders")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d during maintenance, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if body["error"] != "back at 10:00" {
+		t.Fatalf("error = %q, want %q", body["error"], "back at 10:00")
+	}
+	if rec := serve("/healthz"); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d for allowed path, want %d", rec.Code, http.StatusOK)
+	}
+
+	if err := r.OnChange("service.maintenance_message", "back at 10:00", ""); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := r.State().Message; got != DefaultMessage {
+		t.Fatalf("Message = %q, want %q", got, DefaultMessage)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package mqconsumer 提供消息队列消费者并发度与预取数热加载重载器
+//
+// 配置键（默认前缀 "consumers"，<name> 为注册消费者时使用的名称，不能包含 "."）：
+//
+//	consumers.<name>.concurrency  并发消费数
+//	consumers.<name>.prefetch     预取消息数（如 RabbitMQ basic.qos prefetch count、Kafka 每批拉取数）
+//
+// 消费者通过 ScalableConsumer 接口接入，Kafka、RabbitMQ 等客户端只需实现两个方法即可在线调整吞吐
+package mqconsumer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "consumers"
+
+const (
+	// DefaultMaxConcurrency 默认的并发消费数上限
+	DefaultMaxConcurrency = 1024
+	// DefaultMaxPrefetch 默认的预取消息数上限
+	DefaultMaxPrefetch = 65535
+)
+
+// ScalableConsumer 可在线调整并发度的消费者
+type ScalableConsumer interface {
+	// SetConcurrency 调整并发消费数，实现应平滑增减工作协程而不中断正在处理的消息
+	SetConcurrency(n int) error
+	// SetPrefetch 调整预取消息数
+	SetPrefetch(n int) error
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "consumers"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithLimits 设置并发消费数与预取消息数的上限（默认 1024、65535）
+func WithLimits(maxConcurrency, maxPrefetch int) Option {
+	return func(r *Reloader) {
+		if maxConcurrency > 0 {
+			r.maxConcurrency = maxConcurrency
+		}
+		if maxPrefetch > 0 {
+			r.maxPrefetch = maxPrefetch
+		}
+	}
+}
+
+// Reloader 消费者并发度重载器
+type Reloader struct {
+	prefix         string
+	maxConcurrency int
+	maxPrefetch    int
+
+	mu        sync.RWMutex
+	consumers map[string]ScalableConsumer
+}
+
+// New 创建消费者并发度重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix:         DefaultPrefix,
+		maxConcurrency: DefaultMaxConcurrency,
+		maxPrefetch:    DefaultMaxPrefetch,
+		consumers:      make(map[string]ScalableConsumer),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Register 注册消费者
+func (r *Reloader) Register(name string, consumer ScalableConsumer) error {
+	if name == "" {
+		return fmt.Errorf("consumer name is empty")
+	}
+	// 配置键模式中的 "*" 只匹配单级，名称包含 "." 的配置键不会被分发到重载器
+	if strings.Contains(name, ".") {
+		return fmt.Errorf("consumer name %s must not contain '.'", name)
+	}
+	if consumer == nil {
+		return fmt.Errorf("consumer is nil")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.consumers[name]; exists {
+		return fmt.Errorf("consumer %s already registered", name)
+	}
+	r.consumers[name] = consumer
+	return nil
+}
+
+// Unregister 注销消费者
+func (r *Reloader) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.consumers, name)
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "mqconsumer"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".*.concurrency", r.prefix + ".*.prefetch"}
+}
+
+// Validate 验证并发度配置
+func (r *Reloader) Validate(key, value string) error {
+	_, _, _, err := r.resolve(key, value)
+	return err
+}
+
+// OnChange 调整消费者并发度或预取数
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	consumer, setting, n, err := r.resolve(key, newValue)
+	if err != nil {
+		return err
+	}
+	if setting == "concurrency" {
+		return consumer.SetConcurrency(n)
+	}
+	return consumer.SetPrefetch(n)
+}
+
+// resolve 解析配置键与配置值，返回目标消费者、参数名与取值
+func (r *Reloader) resolve(key, value string) (ScalableConsumer, string, int, error) {
+	rest, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return nil, "", 0, fmt.Errorf("unsupported consumer key: %s", key)
+	}
+	name, setting, ok := strings.Cut(rest, ".")
+	if !ok || name == "" {
+		return nil, "", 0, fmt.Errorf("unsupported consumer key: %s", key)
+	}
+
+	limit := 0
+	switch setting {
+	case "concurrency":
+		limit = r.maxConcurrency
+	case "prefetch":
+		limit = r.maxPrefetch
+	default:
+		return nil, "", 0, fmt.Errorf("unsupported consumer key: %s", key)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("invalid %s: %s", setting, value)
+	}
+	if n < 1 || n > limit {
+		return nil, "", 0, fmt.Errorf("%s out of range [1, %d], got %d", setting, limit, n)
+	}
+
+	r.mu.RLock()
+	consumer, exists := r.consumers[name]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, "", 0, fmt.Errorf("consumer not registered: %s", name)
+	}
+	return consumer, setting, n, nil
+}
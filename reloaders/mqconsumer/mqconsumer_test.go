@@ -0,0 +1,101 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package mqconsumer
+
+import "testing"
+
+// fakeConsumer 记录调整结果的 ScalableConsumer
+type fakeConsumer struct {
+	concurrency int
+	prefetch    int
+}
+
+func (c *fakeConsumer) SetConcurrency(n int) error {
+	c.concurrency = n
+	return nil
+}
+
+func (c *fakeConsumer) SetPrefetch(n int) error {
+	c.prefetch = n
+	return nil
+}
+
+func TestRegisterRejectsInvalidNames(t *testing.T) {
+	r := New()
+	if err := r.Register("orders", &fakeConsumer{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	for _, name := range []string{"", "orders", "eu.orders"} {
+		if err := r.Register(name, &fakeConsumer{}); err == nil {
+			t.Errorf("Register(%q) error = nil", name)
+		}
+	}
+	if err := r.Register("payments", nil); err == nil {
+		t.Error("Register() with nil consumer error = nil")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	r := New(WithLimits(64, 1000))
+	if err := r.Register("orders", &fakeConsumer{}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"consumers.orders.concurrency", "16", false},
+		{"consumers.orders.concurrency", "0", true},
+		{"consumers.orders.concurrency", "65", true},
+		{"consumers.orders.prefetch", "1000", false},
+		{"consumers.orders.prefetch", "lots", true},
+		{"consumers.orders.batch_size", "10", true},
+		{"consumers.payments.concurrency", "4", true},
+		{"consumers.eu.orders.concurrency", "4", true},
+		{"workers.orders.concurrency", "4", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeScalesConsumer(t *testing.T) {
+	r := New()
+	consumer := &fakeConsumer{}
+	if err := r.Register("orders", consumer); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := r.OnChange("consumers.orders.concurrency", "", "8"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("consumers.orders.prefetch", "", "200"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if consumer.concurrency != 8 || consumer.prefetch != 200 {
+		t.Fatalf("consumer = %+v, want concurrency 8 and prefetch 200", consumer)
+	}
+
+	r.Unregister("orders")
+	if err := r.OnChange("consumers.orders.concurrency", "8", "4"); err == nil {
+		t.Fatal("OnChange() after Unregister() error = nil")
+	}
+	if consumer.concurrency != 8 {
+		t.Fatalf("concurrency = %d after rejected change, want 8", consumer.concurrency)
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package redisopts 提供 go-redis 客户端参数热加载重载器
+//
+// 配置键（默认前缀 "redis"，<name> 为注册客户端时使用的名称，不能包含 "."）：
+//
+//	redis.<name>.pool_size       连接池大小
+//	redis.<name>.min_idle_conns  最小空闲连接数（不能超过连接池大小）
+//	redis.<name>.dial_timeout    建立连接超时时间（如 "5s"）
+//	redis.<name>.read_timeout    读超时时间（如 "3s"）
+//	redis.<name>.write_timeout   写超时时间（如 "3s"）
+//	redis.<name>.pool_timeout    从连接池获取连接的超时时间（如 "4s"）
+//
+// go-redis 客户端的参数在创建连接池时确定，运行中修改 Options 存在数据竞争，
+// 因此参数变更时会以新参数创建新客户端并原子替换，旧客户端在延迟一段时间后关闭，
+// 业务代码应每次通过 Client.Get() 获取当前客户端，而不是长期持有 *redis.Client
+package redisopts
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "redis"
+
+const (
+	// DefaultMinTimeout 默认的超时时间下限
+	DefaultMinTimeout = 10 * time.Millisecond
+	// DefaultMaxTimeout 默认的超时时间上限
+	DefaultMaxTimeout = time.Minute
+	// DefaultMaxPoolSize 默认的连接池大小上限
+	DefaultMaxPoolSize = 10000
+	// DefaultCloseDelay 替换后关闭旧客户端的默认延迟，用于等待进行中的命令完成
+	DefaultCloseDelay = 30 * time.Second
+)
+
+// 支持的客户端参数
+const (
+	settingPoolSize     = "pool_size"
+	settingMinIdleConns = "min_idle_conns"
+	settingDialTimeout  = "dial_timeout"
+	settingReadTimeout  = "read_timeout"
+	settingWriteTimeout = "write_timeout"
+	settingPoolTimeout  = "pool_timeout"
+)
+
+var settingNames = []string{
+	settingPoolSize,
+	settingMinIdleConns,
+	settingDialTimeout,
+	settingReadTimeout,
+	settingWriteTimeout,
+	settingPoolTimeout,
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "redis"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithTimeoutBounds 设置超时时间的取值范围（默认 [10ms, 1m]）
+func WithTimeoutBounds(min, max time.Duration) Option {
+	return func(r *Reloader) {
+		if min > 0 && max >= min {
+			r.minTimeout, r.maxTimeout = min, max
+		}
+	}
+}
+
+// WithMaxPoolSize 设置连接池大小上限（默认 10000）
+func WithMaxPoolSize(max int) Option {
+	return func(r *Reloader) {
+		if max > 0 {
+			r.maxPoolSize = max
+		}
+	}
+}
+
+// WithCloseDelay 设置替换后关闭旧客户端的延迟（默认 30s）
+func WithCloseDelay(delay time.Duration) Option {
+	return func(r *Reloader) {
+		if delay >= 0 {
+			r.closeDelay = delay
+		}
+	}
+}
+
+// Client 可热替换的 Redis 客户端
+type Client struct {
+	current atomic.Pointer[redis.Client]
+}
+
+// Get 返回当前使用的 Redis 客户端
+func (c *Client) Get() *redis.Client {
+	return c.current.Load()
+}
+
+// Close 关闭当前使用的 Redis 客户端
+func (c *Client) Close() error {
+	return c.current.Load().Close()
+}
+
+// Reloader Redis 客户端参数重载器
+type Reloader struct {
+	prefix      string
+	minTimeout  time.Duration
+	maxTimeout  time.Duration
+	maxPoolSize int
+	closeDelay  time.Duration
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// New 创建 Redis 客户端参数重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix:      DefaultPrefix,
+		minTimeout:  DefaultMinTimeout,
+		maxTimeout:  DefaultMaxTimeout,
+		maxPoolSize: DefaultMaxPoolSize,
+		closeDelay:  DefaultCloseDelay,
+		clients:     make(map[string]*Client),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Register 以 opts 创建 Redis 客户端并注册到重载器
+func (r *Reloader) Register(name string, opts *redis.Options) (*Client, error) {
+	if name == "" {
+		return nil, fmt.Errorf("redis client name is empty")
+	}
+	// 配置键模式中的 "*" 只匹配单级，名称包含 "." 的配置键不会被分发到重载器
+	if strings.Contains(name, ".") {
+		return nil, fmt.Errorf("redis client name %s must not contain '.'", name)
+	}
+	if opts == nil {
+		return nil, fmt.Errorf("redis options is nil")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.clients[name]; exists {
+		return nil, fmt.Errorf("redis client %s already registered", name)
+	}
+	client := &Client{}
+	client.current.Store(redis.NewClient(opts))
+	r.clients[name] = client
+	return client, nil
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "redisopts"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	patterns := make([]string, 0, len(settingNames))
+	for _, setting := range settingNames {
+		patterns = append(patterns, r.prefix+".*."+setting)
+	}
+	return patterns
+}
+
+// Validate 验证客户端参数
+func (r *Reloader) Validate(key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _, err := r.resolve(key, value)
+	return err
+}
+
+// OnChange 以新参数创建客户端并替换，旧客户端延迟关闭
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client, opts, err := r.resolve(key, newValue)
+	if err != nil {
+		return err
+	}
+
+	old := client.current.Swap(redis.NewClient(opts))
+	if r.closeDelay == 0 {
+		return old.Close()
+	}
+	time.AfterFunc(r.closeDelay, func() {
+		_ = old.Close()
+	})
+	return nil
+}
+
+// resolve 解析配置键与配置值，返回目标客户端与应用后的客户端参数
+func (r *Reloader) resolve(key, value string) (*Client, *redis.Options, error) {
+	name, setting, ok := r.parseKey(key)
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported redis key: %s", key)
+	}
+	client, exists := r.clients[name]
+	if !exists {
+		return nil, nil, fmt.Errorf("redis client not registered: %s", name)
+	}
+
+	opts := cloneOptions(client.Get().Options())
+	value = strings.TrimSpace(value)
+	switch setting {
+	case settingPoolSize, settingMinIdleConns:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %s", setting, value)
+		}
+		if setting == settingPoolSize {
+			if n < 1 || n > r.maxPoolSize {
+				return nil, nil, fmt.Errorf("pool_size out of range [1, %d], got %d", r.maxPoolSize, n)
+			}
+			opts.PoolSize = n
+		} else {
+			if n < 0 {
+				return nil, nil, fmt.Errorf("min_idle_conns must be >= 0, got %d", n)
+			}
+			opts.MinIdleConns = n
+		}
+		if opts.PoolSize > 0 && opts.MinIdleConns > opts.PoolSize {
+			return nil, nil, fmt.Errorf("min_idle_conns (%d) must not exceed pool_size (%d)",
+				opts.MinIdleConns, opts.PoolSize)
+		}
+	default:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %s", setting, value)
+		}
+		if d < r.minTimeout || d > r.maxTimeout {
+			return nil, nil, fmt.Errorf("%s out of range [%s, %s], got %s", setting, r.minTimeout, r.maxTimeout, d)
+		}
+		switch setting {
+		case settingDialTimeout:
+			opts.DialTimeout = d
+		case settingReadTimeout:
+			opts.ReadTimeout = d
+		case settingWriteTimeout:
+			opts.WriteTimeout = d
+		case settingPoolTimeout:
+			opts.PoolTimeout = d
+		}
+	}
+	return client, opts, nil
+}
+
+// parseKey 解析配置键中的客户端名称与参数名
+func (r *Reloader) parseKey(key string) (name, setting string, ok bool) {
+	rest, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return "", "", false
+	}
+	name, setting, ok = strings.Cut(rest, ".")
+	if !ok || name == "" {
+		return "", "", false
+	}
+	for _, s := range settingNames {
+		if s == setting {
+			return name, setting, true
+		}
+	}
+	return "", "", false
+}
+
+// cloneOptions 复制客户端参数
+func cloneOptions(opts *redis.Options) *redis.Options {
+	clone := *opts
+	return &clone
+}
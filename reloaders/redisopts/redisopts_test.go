@@ -0,0 +1,102 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package redisopts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// newReloader 创建注册了 cache 客户端的重载器，替换后立即关闭旧客户端
+func newReloader(t *testing.T) (*Reloader, *Client) {
+	t.Helper()
+	r := New(WithCloseDelay(0))
+	client, err := r.Register("cache", &redis.Options{Addr: "127.0.0.1:0", PoolSize: 10, MinIdleConns: 2})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return r, client
+}
+
+func TestRegisterRejectsInvalidNames(t *testing.T) {
+	r, _ := newReloader(t)
+	for _, name := range []string{"", "cache", "eu.cache"} {
+		if _, err := r.Register(name, &redis.Options{}); err == nil {
+			t.Errorf("Register(%q) error = nil", name)
+		}
+	}
+	if _, err := r.Register("session", nil); err == nil {
+		t.Error("Register() with nil options error = nil")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	r, _ := newReloader(t)
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"redis.cache.pool_size", "20", false},
+		{"redis.cache.pool_size", "0", true},
+		{"redis.cache.pool_size", "100000", true},
+		{"redis.cache.pool_size", "1", true},
+		{"redis.cache.min_idle_conns", "10", false},
+		{"redis.cache.min_idle_conns", "11", true},
+		{"redis.cache.min_idle_conns", "-1", true},
+		{"redis.cache.dial_timeout", "5s", false},
+		{"redis.cache.read_timeout", "1ns", true},
+		{"redis.cache.write_timeout", "fast", true},
+		{"redis.cache.unknown", "1", true},
+		{"redis.session.pool_size", "10", true},
+		{"redis.eu.cache.pool_size", "10", true},
+		{"db.cache.pool_size", "10", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeSwapsClient(t *testing.T) {
+	r, client := newReloader(t)
+	before := client.Get()
+	if err := r.OnChange("redis.cache.read_timeout", "", "2s"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	after := client.Get()
+	if after == before {
+		t.Fatal("Get() returned the replaced client")
+	}
+	opts := after.Options()
+	if opts.ReadTimeout != 2*time.Second || opts.PoolSize != 10 || opts.Addr != "127.0.0.1:0" {
+		t.Fatalf("Options() = %+v, want read_timeout 2s with other options kept", opts)
+	}
+	if err := before.Ping(t.Context()).Err(); err != redis.ErrClosed {
+		t.Fatalf("old client Ping() error = %v, want %v", err, redis.ErrClosed)
+	}
+
+	if err := r.OnChange("redis.cache.pool_size", "10", "1"); err == nil {
+		t.Fatal("OnChange() with pool_size below min_idle_conns error = nil")
+	}
+	if client.Get() != after {
+		t.Fatal("rejected change replaced the client")
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package retrypolicy 提供按下游依赖配置的重试、超时与对冲请求策略重载器
+//
+// 配置键（默认前缀 "deps"，<name> 为下游依赖名称，不能包含 "."）：
+//
+//	deps.<name>.max_retries   最大重试次数
+//	deps.<name>.timeout       单次请求超时时间（如 "2s"）
+//	deps.<name>.backoff_base  重试退避基础时间（如 "100ms"）
+//	deps.<name>.backoff_max   重试退避最大时间（如 "2s"）
+//	deps.<name>.hedge_delay   发起对冲请求前的等待时间（0 表示不对冲）
+//	deps.<name>.max_hedges    最大对冲请求数
+//
+// 未单独配置的依赖与参数使用默认策略（见 WithDefault），客户端包装器通过 Lookup 在每次调用前读取策略
+package retrypolicy
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "deps"
+
+const (
+	// MaxRetries 最大重试次数上限
+	MaxRetries = 10
+	// MaxHedges 最大对冲请求数上限
+	MaxHedges = 5
+	// MaxTimeout 单次请求超时时间上限
+	MaxTimeout = 5 * time.Minute
+)
+
+// 支持的策略参数
+var settingNames = []string{
+	"max_retries",
+	"timeout",
+	"backoff_base",
+	"backoff_max",
+	"hedge_delay",
+	"max_hedges",
+}
+
+// Policy 下游依赖调用策略
+type Policy struct {
+	// 最大重试次数
+	MaxRetries int `json:"max_retries"`
+	// 单次请求超时时间
+	Timeout time.Duration `json:"timeout"`
+	// 重试退避基础时间
+	BackoffBase time.Duration `json:"backoff_base"`
+	// 重试退避最大时间
+	BackoffMax time.Duration `json:"backoff_max"`
+	// 发起对冲请求前的等待时间（0 表示不对冲）
+	HedgeDelay time.Duration `json:"hedge_delay"`
+	// 最大对冲请求数
+	MaxHedges int `json:"max_hedges"`
+}
+
+// DefaultPolicy 默认调用策略：重试 2 次，超时 3s，退避 100ms~2s，不对冲
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxRetries:  2,
+		Timeout:     3 * time.Second,
+		BackoffBase: 100 * time.Millisecond,
+		BackoffMax:  2 * time.Second,
+	}
+}
+
+// Backoff 返回第 attempt 次重试（从 1 开始）前的指数退避时间
+func (p Policy) Backoff(attempt int) time.Duration {
+	if attempt < 1 || p.BackoffBase <= 0 {
+		return 0
+	}
+	d := p.BackoffBase
+	for i := 1; i < attempt && (p.BackoffMax <= 0 || d < p.BackoffMax); i++ {
+		d *= 2
+	}
+	if p.BackoffMax > 0 && d > p.BackoffMax {
+		d = p.BackoffMax
+	}
+	return d
+}
+
+// validate 验证调用策略
+func (p Policy) validate() error {
+	if p.MaxRetries < 0 || p.MaxRetries > MaxRetries {
+		return fmt.Errorf("max_retries out of range [0, %d], got %d", MaxRetries, p.MaxRetries)
+	}
+	if p.MaxHedges < 0 || p.MaxHedges > MaxHedges {
+		return fmt.Errorf("max_hedges out of range [0, %d], got %d", MaxHedges, p.MaxHedges)
+	}
+	if p.Timeout <= 0 || p.Timeout > MaxTimeout {
+		return fmt.Errorf("timeout out of range (0, %s], got %s", MaxTimeout, p.Timeout)
+	}
+	if p.BackoffBase < 0 || p.BackoffMax < 0 || p.HedgeDelay < 0 {
+		return fmt.Errorf("durations must be >= 0")
+	}
+	if p.BackoffMax > 0 && p.BackoffBase > p.BackoffMax {
+		return fmt.Errorf("backoff_base (%s) must not exceed backoff_max (%s)", p.BackoffBase, p.BackoffMax)
+	}
+	if p.HedgeDelay > 0 && p.HedgeDelay >= p.Timeout {
+		return fmt.Errorf("hedge_delay (%s) must be less than timeout (%s)", p.HedgeDelay, p.Timeout)
+	}
+	return nil
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "deps"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithDefault 设置未单独配置的依赖使用的默认策略
+func WithDefault(policy Policy) Option {
+	return func(r *Reloader) {
+		r.fallback = policy
+	}
+}
+
+// Reloader 调用策略重载器
+type Reloader struct {
+	prefix   string
+	fallback Policy
+
+	// 串行化配置变更
+	mu       sync.Mutex
+	policies atomic.Pointer[map[string]Policy]
+}
+
+// New 创建调用策略重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix, fallback: DefaultPolicy()}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	policies := make(map[string]Policy)
+	r.policies.Store(&policies)
+	return r
+}
+
+// Lookup 返回下游依赖的调用策略，未单独配置时返回默认策略
+func (r *Reloader) Lookup(name string) Policy {
+	if policy, ok := (*r.policies.Load())[name]; ok {
+		return policy
+	}
+	return r.fallback
+}
+
+// Policies 返回所有单独配置的调用策略
+func (r *Reloader) Policies() map[string]Policy {
+	return maps.Clone(*r.policies.Load())
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "retrypolicy"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	patterns := make([]string, 0, len(settingNames))
+	for _, setting := range settingNames {
+		patterns = append(patterns, r.prefix+".*."+setting)
+	}
+	return patterns
+}
+
+// Validate 验证调用策略配置
+func (r *Reloader) Validate(key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _, err := r.next(key, value)
+	return err
+}
+
+// OnChange 以新策略原子替换依赖的调用策略
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name, policy, err := r.next(key, newValue)
+	if err != nil {
+		return err
+	}
+	policies := maps.Clone(*r.policies.Load())
+	policies[name] = policy
+	r.policies.Store(&policies)
+	return nil
+}
+
+// next 基于依赖当前策略与配置变更构建新策略
+func (r *Reloader) next(key, value string) (string, Policy, error) {
+	rest, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return "", Policy{}, fmt.Errorf("unsupported dependency policy key: %s", key)
+	}
+	// 配置键模式中的 "*" 只匹配单级，依赖名称不能包含 "."
+	name, setting, ok := strings.Cut(rest, ".")
+	if !ok || name == "" {
+		return "", Policy{}, fmt.Errorf("unsupported dependency policy key: %s", key)
+	}
+
+	policy := r.Lookup(name)
+	value = strings.TrimSpace(value)
+	var err error
+	switch setting {
+	case "max_retries":
+		policy.MaxRetries, err = strconv.Atoi(value)
+	case "max_hedges":
+		policy.MaxHedges, err = strconv.Atoi(value)
+	case "timeout":
+		policy.Timeout, err = time.ParseDuration(value)
+	case "backoff_base":
+		policy.BackoffBase, err = time.ParseDuration(value)
+	case "backoff_max":
+		policy.BackoffMax, err = time.ParseDuration(value)
+	case "hedge_delay":
+		policy.HedgeDelay, err = time.ParseDuration(value)
+	default:
+		return "", Policy{}, fmt.Errorf("unsupported dependency policy key: %s", key)
+	}
+	if err != nil {
+		return "", Policy{}, fmt.Errorf("invalid %s: %s", setting, value)
+	}
+	if err := policy.validate(); err != nil {
+		return "", Policy{}, fmt.Errorf("invalid policy for %s: %w", name, err)
+	}
+	return name, policy, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package retrypolicy
+
+import (
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"deps.billing.max_retries", "3", false},
+		{"deps.billing.max_retries", "11", true},
+		{"deps.billing.max_hedges", "-1", true},
+		{"deps.billing.timeout", "0", true},
+		{"deps.billing.timeout", "10m", true},
+		{"deps.billing.backoff_base", "5s", true},
+		{"deps.billing.backoff_max", "50ms", true},
+		{"deps.billing.hedge_delay", "500ms", false},
+		{"deps.billing.hedge_delay", "3s", true},
+		{"deps.billing.timeout", "soon", true},
+		{"deps.billing.retries", "3", true},
+		{"deps.eu.billing.max_retries", "3", true},
+		{"upstreams.billing.max_retries", "3", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeOverridesDefault(t *testing.T) {
+	r := New()
+	if err := r.OnChange("deps.billing.max_retries", "", "5"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("deps.billing.timeout", "", "1s"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	want := DefaultPolicy()
+	want.MaxRetries, want.Timeout = 5, time.Second
+	if got := r.Lookup("billing"); got != want {
+		t.Fatalf("Lookup(billing) = %+v, want %+v", got, want)
+	}
+	if got := r.Lookup("search"); got != DefaultPolicy() {
+		t.Fatalf("Lookup(search) = %+v, want default policy", got)
+	}
+
+	// 校验基于该依赖的当前策略：对冲等待时间不能超过已缩短的超时时间
+	if err := r.OnChange("deps.billing.hedge_delay", "", "2s"); err == nil {
+		t.Fatal("OnChange() with hedge_delay above timeout error = nil")
+	}
+	if got := r.Policies(); len(got) != 1 || got["billing"] != want {
+		t.Fatalf("Policies() = %+v", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	p := Policy{BackoffBase: 100 * time.Millisecond, BackoffMax: time.Second}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, 0},
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{100, time.Second},
+	}
+	for _, tt := range tests {
+		if got := p.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,272 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package sqlpool 提供 database/sql 连接池参数热加载重载器
+//
+// 配置键（默认前缀 "db"，<name> 为注册数据库时使用的名称，不能包含 "."）：
+//
+//	db.<name>.max_open_conns      最大打开连接数（0 表示不限制）
+//	db.<name>.max_idle_conns      最大空闲连接数（不能超过最大打开连接数）
+//	db.<name>.conn_max_lifetime   连接最大存活时间（如 "30m"，0 表示不限制）
+//	db.<name>.conn_max_idle_time  连接最大空闲时间（如 "5m"，0 表示不限制）
+package sqlpool
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "db"
+
+const (
+	// MinDuration 连接存活/空闲时间的最小值（0 除外）
+	MinDuration = time.Second
+	// MaxDuration 连接存活/空闲时间的最大值
+	MaxDuration = 24 * time.Hour
+)
+
+// 支持的连接池参数
+const (
+	settingMaxOpenConns    = "max_open_conns"
+	settingMaxIdleConns    = "max_idle_conns"
+	settingConnMaxLifetime = "conn_max_lifetime"
+	settingConnMaxIdleTime = "conn_max_idle_time"
+)
+
+var settingNames = []string{
+	settingMaxOpenConns,
+	settingMaxIdleConns,
+	settingConnMaxLifetime,
+	settingConnMaxIdleTime,
+}
+
+// Settings 连接池参数
+type Settings struct {
+	// 最大打开连接数（0 表示不限制）
+	MaxOpenConns int
+	// 最大空闲连接数
+	MaxIdleConns int
+	// 连接最大存活时间（0 表示不限制）
+	ConnMaxLifetime time.Duration
+	// 连接最大空闲时间（0 表示不限制）
+	ConnMaxIdleTime time.Duration
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "db"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// pool 已注册的数据库连接池
+type pool struct {
+	db       *sql.DB
+	settings Settings
+}
+
+// Reloader 连接池参数重载器
+type Reloader struct {
+	prefix string
+
+	mu    sync.Mutex
+	pools map[string]*pool
+}
+
+// New 创建连接池参数重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix: DefaultPrefix,
+		pools:  make(map[string]*pool),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Register 注册数据库连接池
+// settings 为数据库当前使用的连接池参数，作为校验（如空闲连接数不超过打开连接数）的基准
+func (r *Reloader) Register(name string, db *sql.DB, settings Settings) error {
+	if name == "" {
+		return fmt.Errorf("db name is empty")
+	}
+	// 配置键模式中的 "*" 只匹配单级，名称包含 "." 的配置键不会被分发到重载器
+	if strings.Contains(name, ".") {
+		return fmt.Errorf("db name %s must not contain '.'", name)
+	}
+	if db == nil {
+		return fmt.Errorf("db is nil")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.pools[name]; exists {
+		return fmt.Errorf("db %s already registered", name)
+	}
+	r.pools[name] = &pool{db: db, settings: settings}
+	return nil
+}
+
+// Settings 返回已注册数据库当前的连接池参数
+func (r *Reloader) Settings(name string) (Settings, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.pools[name]
+	if !ok {
+		return Settings{}, false
+	}
+	return p.settings, true
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "sqlpool"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	patterns := make([]string, 0, len(settingNames))
+	for _, setting := range settingNames {
+		patterns = append(patterns, r.prefix+".*."+setting)
+	}
+	return patterns
+}
+
+// Validate 验证连接池参数
+func (r *Reloader) Validate(key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, _, err := r.resolve(key, value)
+	return err
+}
+
+// OnChange 将连接池参数应用到数据库
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, settings, err := r.resolve(key, newValue)
+	if err != nil {
+		return err
+	}
+
+	_, setting, _ := r.parseKey(key)
+	switch setting {
+	case settingMaxOpenConns:
+		p.db.SetMaxOpenConns(settings.MaxOpenConns)
+	case settingMaxIdleConns:
+		p.db.SetMaxIdleConns(settings.MaxIdleConns)
+	case settingConnMaxLifetime:
+		p.db.SetConnMaxLifetime(settings.ConnMaxLifetime)
+	case settingConnMaxIdleTime:
+		p.db.SetConnMaxIdleTime(settings.ConnMaxIdleTime)
+	}
+	p.settings = settings
+	return nil
+}
+
+// resolve 解析配置键与配置值，返回目标连接池与应用后的连接池参数
+func (r *Reloader) resolve(key, value string) (*pool, Settings, error) {
+	name, setting, ok := r.parseKey(key)
+	if !ok {
+		return nil, Settings{}, fmt.Errorf("unsupported db pool key: %s", key)
+	}
+	p, exists := r.pools[name]
+	if !exists {
+		return nil, Settings{}, fmt.Errorf("db not registered: %s", name)
+	}
+
+	settings := p.settings
+	value = strings.TrimSpace(value)
+	switch setting {
+	case settingMaxOpenConns, settingMaxIdleConns:
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, Settings{}, fmt.Errorf("invalid %s: %s", setting, value)
+		}
+		if n < 0 {
+			return nil, Settings{}, fmt.Errorf("%s must be >= 0, got %d", setting, n)
+		}
+		if setting == settingMaxOpenConns {
+			settings.MaxOpenConns = n
+		} else {
+			settings.MaxIdleConns = n
+		}
+		if settings.MaxOpenConns > 0 && settings.MaxIdleConns > settings.MaxOpenConns {
+			return nil, Settings{}, fmt.Errorf("max_idle_conns (%d) must not exceed max_open_conns (%d)",
+				settings.MaxIdleConns, settings.MaxOpenConns)
+		}
+	case settingConnMaxLifetime, settingConnMaxIdleTime:
+		d, err := parseDuration(value)
+		if err != nil {
+			return nil, Settings{}, fmt.Errorf("invalid %s: %w", setting, err)
+		}
+		if setting == settingConnMaxLifetime {
+			settings.ConnMaxLifetime = d
+		} else {
+			settings.ConnMaxIdleTime = d
+		}
+	}
+	return p, settings, nil
+}
+
+// parseKey 解析配置键中的数据库名称与参数名
+func (r *Reloader) parseKey(key string) (name, setting string, ok bool) {
+	rest, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return "", "", false
+	}
+	name, setting, ok = strings.Cut(rest, ".")
+	if !ok || name == "" {
+		return "", "", false
+	}
+	for _, s := range settingNames {
+		if s == setting {
+			return name, setting, true
+		}
+	}
+	return "", "", false
+}
+
+// parseDuration 解析时长，0 表示不限制，其余取值须在 [MinDuration, MaxDuration] 范围内
+func parseDuration(value string) (time.Duration, error) {
+	if value == "0" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d == 0 {
+		return 0, nil
+	}
+	if d < MinDuration || d > MaxDuration {
+		return 0, fmt.Errorf("%s out of range [%s, %s]", d, MinDuration, MaxDuration)
+	}
+	return d, nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package sqlpool
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeConnector 不建立真实连接的 driver.Connector，只用于构造 *sql.DB
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not connectable")
+}
+
+func (fakeConnector) Driver() driver.Driver { return nil }
+
+// newReloader 创建注册了 primary 数据库的重载器
+func newReloader(t *testing.T) (*Reloader, *sql.DB) {
+	t.Helper()
+	db := sql.OpenDB(fakeConnector{})
+	t.Cleanup(func() { db.Close() })
+	r := New()
+	if err := r.Register("primary", db, Settings{MaxOpenConns: 10, MaxIdleConns: 5}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	return r, db
+}
+
+func TestRegisterRejectsInvalidNames(t *testing.T) {
+	r, db := newReloader(t)
+	for _, name := range []string{"", "primary", "eu.primary"} {
+		if err := r.Register(name, db, Settings{}); err == nil {
+			t.Errorf("Register(%q) error = nil", name)
+		}
+	}
+	if err := r.Register("replica", nil, Settings{}); err == nil {
+		t.Error("Register() with nil db error = nil")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	r, _ := newReloader(t)
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"db.primary.max_open_conns", "20", false},
+		{"db.primary.max_open_conns", "0", false},
+		{"db.primary.max_open_conns", "4", true},
+		{"db.primary.max_open_conns", "-1", true},
+		{"db.primary.max_idle_conns", "many", true},
+		{"db.primary.conn_max_lifetime", "30m", false},
+		{"db.primary.conn_max_lifetime", "0", false},
+		{"db.primary.conn_max_lifetime", "10ms", true},
+		{"db.primary.conn_max_idle_time", "48h", true},
+		{"db.primary.unknown", "1", true},
+		{"db.replica.max_open_conns", "1", true},
+		{"db.eu.primary.max_open_conns", "20", true},
+		{"cache.primary.max_open_conns", "20", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeAppliesSettings(t *testing.T) {
+	r, db := newReloader(t)
+	if err := r.OnChange("db.primary.max_open_conns", "10", "32"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("db.primary.conn_max_lifetime", "", "30m"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := db.Stats().MaxOpenConnections; got != 32 {
+		t.Fatalf("MaxOpenConnections = %d, want 32", got)
+	}
+	settings, _ := r.Settings("primary")
+	want := Settings{MaxOpenConns: 32, MaxIdleConns: 5, ConnMaxLifetime: 30 * time.Minute}
+	if settings != want {
+		t.Fatalf("Settings() = %+v, want %+v", settings, want)
+	}
+
+	if err := r.OnChange("db.primary.max_idle_conns", "5", "64"); err == nil {
+		t.Fatal("OnChange() with max_idle_conns above max_open_conns error = nil")
+	}
+	if settings, _ := r.Settings("primary"); settings != want {
+		t.Fatalf("Settings() after rejected change = %+v, want %+v", settings, want)
+	}
+}
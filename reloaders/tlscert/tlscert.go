@@ -0,0 +1,305 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package tlscert 提供 TLS 证书热轮换重载器
+//
+// 配置键（默认前缀 "tls"）：
+//
+//	tls.cert  证书（PEM 内容或文件路径）
+//	tls.key   私钥（PEM 内容或文件路径）
+//
+// 证书与私钥通常分两次变更推送，重载器会暂存先到达的一方，
+// 直到两者组成有效的证书对后才原子地切换正在使用的证书
+package tlscert
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "tls"
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "tls"），对应配置键 "<prefix>.cert"、"<prefix>.key"
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.certKey = prefix + ".cert"
+			r.keyKey = prefix + ".key"
+		}
+	}
+}
+
+// WithCertificate 设置初始证书（通常为启动时从文件加载的证书）
+func WithCertificate(cert *tls.Certificate) Option {
+	return func(r *Reloader) {
+		if cert != nil {
+			r.active.Store(cert)
+		}
+	}
+}
+
+// Reloader TLS 证书重载器
+type Reloader struct {
+	certKey string
+	keyKey  string
+
+	mu sync.Mutex
+	// 暂存的证书与私钥配置值（PEM 内容或文件路径）
+	certValue string
+	keyValue  string
+
+	active atomic.Pointer[tls.Certificate]
+}
+
+// New 创建 TLS 证书重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{
+		certKey: DefaultPrefix + ".cert",
+		keyKey:  DefaultPrefix + ".key",
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	return r
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "tlscert"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.certKey, r.keyKey}
+}
+
+// Validate 验证证书或私钥能够被解析
+func (r *Reloader) Validate(key, value string) error {
+	data, err := loadPEM(value)
+	if err != nil {
+		return err
+	}
+	switch key {
+	case r.certKey:
+		_, err = parseLeaf(data)
+	case r.keyKey:
+		err = checkPrivateKey(data)
+	default:
+		err = fmt.Errorf("unsupported tls key: %s", key)
+	}
+	return err
+}
+
+// OnChange 暂存证书或私钥，组成有效的证书对后切换正在使用的证书
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch key {
+	case r.certKey:
+		r.certValue = newValue
+	case r.keyKey:
+		r.keyValue = newValue
+	default:
+		return fmt.Errorf("unsupported tls key: %s", key)
+	}
+	return r.activate(false)
+}
+
+// Reload 重新读取暂存的证书与私钥（配置值为文件路径时用于响应磁盘上的证书文件更新）
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.certValue == "" || r.keyValue == "" {
+		return fmt.Errorf("tls certificate and key are not both configured")
+	}
+	return r.activate(true)
+}
+
+// activate 尝试以暂存的证书与私钥构建证书对并切换
+// 证书与私钥尚未到齐或不匹配时视为轮换进行中，strict 为 true 时返回错误
+func (r *Reloader) activate(strict bool) error {
+	if r.certValue == "" || r.keyValue == "" {
+		return nil
+	}
+
+	certPEM, err := loadPEM(r.certValue)
+	if err != nil {
+		return err
+	}
+	keyPEM, err := loadPEM(r.keyValue)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		if strict {
+			return fmt.Errorf("invalid tls key pair: %w", err)
+		}
+		// 与另一方暂未匹配，等待其变更到达
+		return nil
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+	}
+	if time.Now().After(cert.Leaf.NotAfter) {
+		return fmt.Errorf("certificate expired at %s", cert.Leaf.NotAfter.Format(time.RFC3339))
+	}
+
+	r.active.Store(&cert)
+	return nil
+}
+
+// Pending 返回是否存在尚未生效的证书或私钥（轮换进行中）
+func (r *Reloader) Pending() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.certValue == "" || r.keyValue == "" {
+		return r.certValue != "" || r.keyValue != ""
+	}
+	certPEM, err := loadPEM(r.certValue)
+	if err != nil {
+		return true
+	}
+	cert := r.active.Load()
+	if cert == nil || len(cert.Certificate) == 0 {
+		return true
+	}
+	block, _ := pem.Decode(certPEM)
+	return block == nil || !bytes.Equal(block.Bytes, cert.Certificate[0])
+}
+
+// Certificate 返回正在使用的证书，尚未加载时返回 nil
+func (r *Reloader) Certificate() *tls.Certificate {
+	return r.active.Load()
+}
+
+// GetCertificate 用作 tls.Config.GetCertificate，每次握手返回正在使用的证书
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert := r.active.Load()
+	if cert == nil {
+		return nil, fmt.Errorf("no tls certificate loaded")
+	}
+	return cert, nil
+}
+
+// GetClientCertificate 用作 tls.Config.GetClientCertificate，供双向 TLS 客户端轮换证书
+func (r *Reloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	cert := r.active.Load()
+	if cert == nil {
+		return nil, fmt.Errorf("no tls certificate loaded")
+	}
+	return cert, nil
+}
+
+// GetConfigForClient 返回用作 tls.Config.GetConfigForClient 的函数
+// 每次握手基于 base 的副本设置正在使用的证书，适用于需要按连接定制配置的场景
+func (r *Reloader) GetConfigForClient(base *tls.Config) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		cert, err := r.GetCertificate(hello)
+		if err != nil {
+			return nil, err
+		}
+		var config *tls.Config
+		if base != nil {
+			config = base.Clone()
+		} else {
+			config = &tls.Config{}
+		}
+		config.Certificates = []tls.Certificate{*cert}
+		config.GetCertificate = nil
+		config.GetConfigForClient = nil
+		return config, nil
+	}
+}
+
+// loadPEM 读取配置值对应的 PEM 内容，值不是 PEM 内容时按文件路径读取
+func loadPEM(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("value is empty")
+	}
+	if strings.HasPrefix(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	data, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", value, err)
+	}
+	return data, nil
+}
+
+// parseLeaf 解析 PEM 中的第一张证书
+func parseLeaf(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no certificate found in PEM data")
+		}
+		if block.Type == "CERTIFICATE" {
+			leaf, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate: %w", err)
+			}
+			if time.Now().After(leaf.NotAfter) {
+				return nil, fmt.Errorf("certificate expired at %s", leaf.NotAfter.Format(time.RFC3339))
+			}
+			return leaf, nil
+		}
+	}
+}
+
+// checkPrivateKey 检查 PEM 中是否包含可解析的私钥
+func checkPrivateKey(data []byte) error {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return fmt.Errorf("no private key found in PEM data")
+		}
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+		if _, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		if _, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		if _, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+			return nil
+		}
+		return fmt.Errorf("failed to parse private key")
+	}
+}
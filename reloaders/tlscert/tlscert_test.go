@@ -0,0 +1,155 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package tlscert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newPair 生成在 notAfter 过期的自签名证书与私钥（PEM）
+func newPair(t *testing.T, notAfter time.Time) (certPEM, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    notAfter.Add(-48 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))
+}
+
+func TestValidate(t *testing.T) {
+	r := New()
+	cert, key := newPair(t, time.Now().Add(24*time.Hour))
+	expired, _ := newPair(t, time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name, key, value string
+		wantErr          bool
+	}{
+		{"cert", "tls.cert", cert, false},
+		{"key", "tls.key", key, false},
+		{"empty", "tls.cert", " ", true},
+		{"missing file", "tls.cert", filepath.Join(t.TempDir(), "missing.pem"), true},
+		{"expired cert", "tls.cert", expired, true},
+		{"key as cert", "tls.cert", key, true},
+		{"cert as key", "tls.key", cert, true},
+		{"unsupported key", "tls.ca", cert, true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestOnChangeSwitchesOnlyOnMatchingPair(t *testing.T) {
+	r := New()
+	cert1, key1 := newPair(t, time.Now().Add(24*time.Hour))
+	cert2, key2 := newPair(t, time.Now().Add(48*time.Hour))
+
+	if err := r.OnChange("tls.cert", "", cert1); err != nil {
+		t.Fatalf("OnChange(cert) error = %v", err)
+	}
+	if r.Certificate() != nil || !r.Pending() {
+		t.Fatal("certificate activated before its key arrived")
+	}
+	if err := r.OnChange("tls.key", "", key1); err != nil {
+		t.Fatalf("OnChange(key) error = %v", err)
+	}
+	first := r.Certificate()
+	if first == nil || r.Pending() {
+		t.Fatal("certificate not activated after the pair arrived")
+	}
+
+	// 新证书先到达时与旧私钥不匹配，保持使用旧证书
+	if err := r.OnChange("tls.cert", cert1, cert2); err != nil {
+		t.Fatalf("OnChange(cert) error = %v", err)
+	}
+	if r.Certificate() != first || !r.Pending() {
+		t.Fatal("mismatched pair replaced the active certificate")
+	}
+	if err := r.OnChange("tls.key", key1, key2); err != nil {
+		t.Fatalf("OnChange(key) error = %v", err)
+	}
+	if got, _ := r.GetCertificate(nil); got == first || r.Pending() {
+		t.Fatal("certificate not rotated after the new pair arrived")
+	}
+}
+
+func TestReloadReadsFiles(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	write := func(cert, key string) {
+		t.Helper()
+		if err := os.WriteFile(certPath, []byte(cert), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if err := os.WriteFile(keyPath, []byte(key), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+	}
+
+	r := New(WithPrefix("server.tls"))
+	if err := r.Reload(); err == nil {
+		t.Fatal("Reload() before configuration error = nil")
+	}
+	cert1, key1 := newPair(t, time.Now().Add(24*time.Hour))
+	write(cert1, key1)
+	if err := r.OnChange("server.tls.cert", "", certPath); err != nil {
+		t.Fatalf("OnChange(cert) error = %v", err)
+	}
+	if err := r.OnChange("server.tls.key", "", keyPath); err != nil {
+		t.Fatalf("OnChange(key) error = %v", err)
+	}
+	first := r.Certificate()
+	if first == nil {
+		t.Fatal("certificate not loaded from files")
+	}
+
+	cert2, _ := newPair(t, time.Now().Add(24*time.Hour))
+	write(cert2, key1)
+	if err := r.Reload(); err == nil {
+		t.Fatal("Reload() with mismatched files error = nil")
+	}
+	if r.Certificate() != first {
+		t.Fatal("failed Reload() replaced the active certificate")
+	}
+}
@@ -0,0 +1,221 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package tracesampler 提供 OpenTelemetry 采样率热加载重载器
+//
+// 配置键（默认前缀 "tracing"）：
+//
+//	tracing.sample_ratio         全局采样率，取值范围 [0, 1]
+//	tracing.route_sample_ratios  按路由覆盖的采样率，JSON 对象（如 {"/api/orders": 1, "/healthz": 0}）
+//
+// 路由取自 span 的 http.route 属性，未设置时使用 span 名称
+package tracesampler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "tracing"
+
+// routeKey span 的路由属性（与 OpenTelemetry 语义约定 http.route 一致）
+const routeKey = attribute.Key("http.route")
+
+// policy 不可修改的采样策略
+type policy struct {
+	ratio   float64
+	base    sdktrace.Sampler
+	routes  map[string]float64
+	samples map[string]sdktrace.Sampler
+}
+
+// newPolicy 创建采样策略
+func newPolicy(ratio float64, routes map[string]float64) *policy {
+	p := &policy{
+		ratio:   ratio,
+		base:    sdktrace.TraceIDRatioBased(ratio),
+		routes:  routes,
+		samples: make(map[string]sdktrace.Sampler, len(routes)),
+	}
+	for route, r := range routes {
+		p.samples[route] = sdktrace.TraceIDRatioBased(r)
+	}
+	return p
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "tracing"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithRatio 设置初始全局采样率（默认 1，即全部采样）
+func WithRatio(ratio float64) Option {
+	return func(r *Reloader) {
+		if ratio >= 0 && ratio <= 1 {
+			r.initialRatio = ratio
+		}
+	}
+}
+
+// Reloader 采样率重载器，同时实现 sdktrace.Sampler
+type Reloader struct {
+	prefix       string
+	initialRatio float64
+
+	// 串行化配置变更
+	mu     sync.Mutex
+	policy atomic.Pointer[policy]
+}
+
+// New 创建采样率重载器
+// 通常以 sdktrace.WithSampler(sdktrace.ParentBased(r)) 接入 TracerProvider
+func New(opts ...Option) *Reloader {
+	r := &Reloader{prefix: DefaultPrefix, initialRatio: 1}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	r.policy.Store(newPolicy(r.initialRatio, nil))
+	return r
+}
+
+// ShouldSample 实现 sdktrace.Sampler 接口
+func (r *Reloader) ShouldSample(params sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	p := r.policy.Load()
+	if len(p.samples) > 0 {
+		route := params.Name
+		for _, attr := range params.Attributes {
+			if attr.Key == routeKey && attr.Value.Type() == attribute.STRING {
+				route = attr.Value.AsString()
+				break
+			}
+		}
+		if sampler, ok := p.samples[route]; ok {
+			return sampler.ShouldSample(params)
+		}
+	}
+	return p.base.ShouldSample(params)
+}
+
+// Description 实现 sdktrace.Sampler 接口
+func (r *Reloader) Description() string {
+	p := r.policy.Load()
+	return fmt.Sprintf("HotReloadSampler{ratio=%g,routes=%d}", p.ratio, len(p.routes))
+}
+
+// Ratio 返回当前全局采样率
+func (r *Reloader) Ratio() float64 {
+	return r.policy.Load().ratio
+}
+
+// RouteRatio 返回指定路由的采样率，未覆盖时返回全局采样率
+func (r *Reloader) RouteRatio(route string) float64 {
+	p := r.policy.Load()
+	if ratio, ok := p.routes[route]; ok {
+		return ratio
+	}
+	return p.ratio
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "tracesampler"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{r.prefix + ".sample_ratio", r.prefix + ".route_sample_ratios"}
+}
+
+// Validate 验证采样率配置
+func (r *Reloader) Validate(key, value string) error {
+	switch key {
+	case r.prefix + ".sample_ratio":
+		_, err := parseRatio(value)
+		return err
+	case r.prefix + ".route_sample_ratios":
+		_, err := parseRoutes(value)
+		return err
+	default:
+		return fmt.Errorf("unsupported tracing key: %s", key)
+	}
+}
+
+// OnChange 以新采样策略原子替换当前策略
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	if err := r.Validate(key, newValue); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.policy.Load()
+	if key == r.prefix+".sample_ratio" {
+		ratio, _ := parseRatio(newValue)
+		r.policy.Store(newPolicy(ratio, current.routes))
+		return nil
+	}
+	routes, _ := parseRoutes(newValue)
+	r.policy.Store(newPolicy(current.ratio, routes))
+	return nil
+}
+
+// parseRatio 解析采样率
+func parseRatio(value string) (float64, error) {
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sample ratio: %s", value)
+	}
+	// 取反判断以同时拒绝 NaN
+	if !(ratio >= 0 && ratio <= 1) {
+		return 0, fmt.Errorf("sample ratio must be in [0, 1], got %g", ratio)
+	}
+	return ratio, nil
+}
+
+// parseRoutes 解析按路由覆盖的采样率
+func parseRoutes(value string) (map[string]float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	var routes map[string]float64
+	if err := json.Unmarshal([]byte(value), &routes); err != nil {
+		return nil, fmt.Errorf("invalid route_sample_ratios: %w", err)
+	}
+	for route, ratio := range routes {
+		if ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("sample ratio for route %s must be in [0, 1], got %g", route, ratio)
+		}
+	}
+	return routes, nil
+}
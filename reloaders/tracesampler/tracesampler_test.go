@@ -0,0 +1,93 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package tracesampler
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestValidate(t *testing.T) {
+	r := New()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"tracing.sample_ratio", "0.25", false},
+		{"tracing.sample_ratio", "1.5", true},
+		{"tracing.sample_ratio", "NaN", true},
+		{"tracing.sample_ratio", "half", true},
+		{"tracing.route_sample_ratios", `{"/healthz": 0}`, false},
+		{"tracing.route_sample_ratios", "", false},
+		{"tracing.route_sample_ratios", `{"/orders": 2}`, true},
+		{"tracing.route_sample_ratios", "/orders=1", true},
+		{"tracing.unknown", "1", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+// sample 返回 route 路由的采样决策
+func sample(r *Reloader, route string) sdktrace.SamplingDecision {
+	return r.ShouldSample(sdktrace.SamplingParameters{
+		TraceID:    trace.TraceID{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		Name:       "GET",
+		Attributes: []attribute.KeyValue{routeKey.String(route)},
+	}).Decision
+}
+
+func TestOnChangeAppliesRouteRatios(t *testing.T) {
+	r := New()
+	if err := r.OnChange("tracing.route_sample_ratios", "", `{"/healthz": 0}`); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := sample(r, "/healthz"); got != sdktrace.Drop {
+		t.Fatalf("ShouldSample(/healthz) = %v, want %v", got, sdktrace.Drop)
+	}
+	if got := sample(r, "/orders"); got != sdktrace.RecordAndSample {
+		t.Fatalf("ShouldSample(/orders) = %v, want %v", got, sdktrace.RecordAndSample)
+	}
+
+	// 修改全局比例时保留路由比例
+	if err := r.OnChange("tracing.sample_ratio", "1", "0"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := sample(r, "/orders"); got != sdktrace.Drop {
+		t.Fatalf("ShouldSample(/orders) = %v, want %v", got, sdktrace.Drop)
+	}
+	if got := r.RouteRatio("/healthz"); got != 0 {
+		t.Fatalf("RouteRatio(/healthz) = %g, want 0", got)
+	}
+	if got := r.RouteRatio("/orders"); got != 0 {
+		t.Fatalf("RouteRatio(/orders) = %g, want 0", got)
+	}
+	if err := r.OnChange("tracing.route_sample_ratios", "", `{"/orders": 1}`); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := r.RouteRatio("/orders"); got != 1 {
+		t.Fatalf("RouteRatio(/orders) = %g, want 1", got)
+	}
+	if got := r.Description(); got != "HotReloadSampler{ratio=0,routes=1}" {
+		t.Fatalf("Description() = %q", got)
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package upstreams 提供上游节点权重与启停状态热加载重载器，并实现平滑加权轮询选择
+//
+// 配置键（默认前缀 "upstreams"，<name> 为上游节点名称，不能包含 "."）：
+//
+//	upstreams.<name>.address  节点地址（配置新节点名称的地址即可新增节点）
+//	upstreams.<name>.weight   权重（非负整数，0 表示不分配流量）
+//	upstreams.<name>.enabled  是否启用（true/false）
+//
+// 变更后若没有任何可分配流量的节点，变更会被拒绝，避免流量被全部切走
+package upstreams
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "upstreams"
+
+// MaxWeight 权重上限
+const MaxWeight = 10000
+
+// ErrNoUpstream 没有可用的上游节点
+var ErrNoUpstream = errors.New("no available upstream")
+
+// Upstream 上游节点
+type Upstream struct {
+	// 节点名称
+	Name string `json:"name"`
+	// 节点地址
+	Address string `json:"address"`
+	// 权重
+	Weight int `json:"weight"`
+	// 是否启用
+	Enabled bool `json:"enabled"`
+}
+
+// available 返回节点是否可分配流量
+func (u Upstream) available() bool {
+	return u.Enabled && u.Weight > 0 && u.Address != ""
+}
+
+// Picker 上游节点选择器，供代理与客户端在每次请求前选择节点
+type Picker interface {
+	Pick() (Upstream, error)
+}
+
+// Option 重载器配置选项
+type Option func(*Reloader)
+
+// WithPrefix 设置配置键前缀（默认 "upstreams"）
+func WithPrefix(prefix string) Option {
+	return func(r *Reloader) {
+		if prefix != "" {
+			r.prefix = prefix
+		}
+	}
+}
+
+// WithUpstream 添加初始上游节点
+func WithUpstream(upstream Upstream) Option {
+	return func(r *Reloader) {
+		if upstream.Name != "" {
+			r.upstreams[upstream.Name] = upstream
+		}
+	}
+}
+
+// Reloader 上游节点重载器，实现 Picker
+type Reloader struct {
+	prefix string
+
+	mu        sync.Mutex
+	upstreams map[string]Upstream
+	// 平滑加权轮询状态，节点集合变更时重建
+	pool    []Upstream
+	current []int
+}
+
+// New 创建上游节点重载器
+func New(opts ...Option) *Reloader {
+	r := &Reloader{
+		prefix:    DefaultPrefix,
+		upstreams: make(map[string]Upstream),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(r)
+		}
+	}
+	r.rebuild()
+	return r
+}
+
+// Pick 按平滑加权轮询选择上游节点
+func (r *Reloader) Pick() (Upstream, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.pool) == 0 {
+		return Upstream{}, ErrNoUpstream
+	}
+
+	total, best := 0, 0
+	for i, u := range r.pool {
+		r.current[i] += u.Weight
+		total += u.Weight
+		if r.current[i] > r.current[best] {
+			best = i
+		}
+	}
+	r.current[best] -= total
+	return r.pool[best], nil
+}
+
+// Upstreams 返回所有上游节点，按名称排序
+func (r *Reloader) Upstreams() []Upstream {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := make([]Upstream, 0, len(r.upstreams))
+	for _, u := range r.upstreams {
+		result = append(result, u)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Name 返回重载器名称
+func (r *Reloader) Name() string {
+	return "upstreams"
+}
+
+// Patterns 返回配置键模式列表
+func (r *Reloader) Patterns() []string {
+	return []string{
+		r.prefix + ".*.address",
+		r.prefix + ".*.weight",
+		r.prefix + ".*.enabled",
+	}
+}
+
+// Validate 验证上游节点配置
+func (r *Reloader) Validate(key, value string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, err := r.next(key, value)
+	return err
+}
+
+// OnChange 更新上游节点并重建轮询状态
+func (r *Reloader) OnChange(key, oldValue, newValue string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	upstream, err := r.next(key, newValue)
+	if err != nil {
+		return err
+	}
+	r.upstreams[upstream.Name] = upstream
+	r.rebuild()
+	return nil
+}
+
+// next 解析配置变更，返回更新后的上游节点
+func (r *Reloader) next(key, value string) (Upstream, error) {
+	rest, ok := strings.CutPrefix(key, r.prefix+".")
+	if !ok {
+		return Upstream{}, fmt.Errorf("unsupported upstream key: %s", key)
+	}
+	// 配置键模式中的 "*" 只匹配单级，节点名称不能包含 "."
+	name, setting, ok := strings.Cut(rest, ".")
+	if !ok || name == "" {
+		return Upstream{}, fmt.Errorf("unsupported upstream key: %s", key)
+	}
+
+	upstream, exists := r.upstreams[name]
+	if !exists {
+		// 新节点默认启用、权重为 1
+		upstream = Upstream{Name: name, Weight: 1, Enabled: true}
+	}
+	value = strings.TrimSpace(value)
+	switch setting {
+	case "address":
+		if value == "" {
+			return Upstream{}, fmt.Errorf("address of upstream %s is empty", name)
+		}
+		upstream.Address = value
+	case "weight":
+		weight, err := strconv.Atoi(value)
+		if err != nil {
+			return Upstream{}, fmt.Errorf("invalid weight: %s", value)
+		}
+		if weight < 0 || weight > MaxWeight {
+			return Upstream{}, fmt.Errorf("weight out of range [0, %d], got %d", MaxWeight, weight)
+		}
+		upstream.Weight = weight
+	case "enabled":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return Upstream{}, fmt.Errorf("invalid enabled: %s", value)
+		}
+		upstream.Enabled = enabled
+	default:
+		return Upstream{}, fmt.Errorf("unsupported upstream key: %s", key)
+	}
+	if !exists && setting != "address" {
+		return Upstream{}, fmt.Errorf("upstream not found: %s (set %s.%s.address first)", name, r.prefix, name)
+	}
+
+	// 变更后至少保留一个可分配流量的节点
+	if exists && r.hasAvailable() && !upstream.available() {
+		remaining := false
+		for n, u := range r.upstreams {
+			if n != name && u.available() {
+				remaining = true
+				break
+			}
+		}
+		if !remaining {
+			return Upstream{}, fmt.Errorf("change would leave no available upstream")
+		}
+	}
+	return upstream, nil
+}
+
+// hasAvailable 返回当前是否存在可分配流量的节点
+func (r *Reloader) hasAvailable() bool {
+	for _, u := range r.upstreams {
+		if u.available() {
+			return true
+		}
+	}
+	return false
+}
+
+// rebuild 重建平滑加权轮询状态
+func (r *Reloader) rebuild() {
+	pool := make([]Upstream, 0, len(r.upstreams))
+	for _, u := range r.upstreams {
+		if u.available() {
+			pool = append(pool, u)
+		}
+	}
+	sort.Slice(pool, func(i, j int) bool { return pool[i].Name < pool[j].Name })
+	r.pool = pool
+	r.current = make([]int, len(pool))
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package upstreams
+
+import (
+	"errors"
+	"testing"
+)
+
+// newReloader 创建包含 a（权重 1）与 b（权重 3）两个节点的重载器
+func newReloader() *Reloader {
+	return New(
+		WithUpstream(Upstream{Name: "a", Address: "10.0.0.1:80", Weight: 1, Enabled: true}),
+		WithUpstream(Upstream{Name: "b", Address: "10.0.0.2:80", Weight: 3, Enabled: true}),
+	)
+}
+
+func TestValidate(t *testing.T) {
+	r := newReloader()
+	tests := []struct {
+		key, value string
+		wantErr    bool
+	}{
+		{"upstreams.c.address", "10.0.0.3:80", false},
+		{"upstreams.c.weight", "2", true},
+		{"upstreams.a.address", "", true},
+		{"upstreams.a.weight", "0", false},
+		{"upstreams.a.weight", "-1", true},
+		{"upstreams.a.weight", "10001", true},
+		{"upstreams.a.enabled", "off", true},
+		{"upstreams.a.port", "80", true},
+		{"upstreams.eu.a.weight", "2", true},
+		{"backends.a.weight", "2", true},
+	}
+	for _, tt := range tests {
+		if err := r.Validate(tt.key, tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q, %q) error = %v, wantErr %v", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+// picks 返回 n 次选择中各节点被选中的次数
+func picks(t *testing.T, r *Reloader, n int) map[string]int {
+	t.Helper()
+	counts := make(map[string]int)
+	for range n {
+		u, err := r.Pick()
+		if err != nil {
+			t.Fatalf("Pick() error = %v", err)
+		}
+		counts[u.Name]++
+	}
+	return counts
+}
+
+func TestPickFollowsWeights(t *testing.T) {
+	r := newReloader()
+	if got := picks(t, r, 8); got["a"] != 2 || got["b"] != 6 {
+		t.Fatalf("picks = %v, want a:2 b:6", got)
+	}
+
+	if err := r.OnChange("upstreams.c.address", "", "10.0.0.3:80"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("upstreams.b.enabled", "true", "false"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if got := picks(t, r, 4); got["a"] != 2 || got["c"] != 2 {
+		t.Fatalf("picks = %v, want a:2 c:2", got)
+	}
+}
+
+func TestOnChangeKeepsOneAvailableUpstream(t *testing.T) {
+	r := newReloader()
+	if err := r.OnChange("upstreams.a.weight", "1", "0"); err != nil {
+		t.Fatalf("OnChange() error = %v", err)
+	}
+	if err := r.OnChange("upstreams.b.enabled", "true", "false"); err == nil {
+		t.Fatal("OnChange() disabling the last available upstream error = nil")
+	}
+	if u, err := r.Pick(); err != nil || u.Name != "b" {
+		t.Fatalf("Pick() = %v, %v, want b", u.Name, err)
+	}
+	if _, err := New().Pick(); !errors.Is(err, ErrNoUpstream) {
+		t.Fatalf("Pick() error = %v, want %v", err, ErrNoUpstream)
+	}
+}
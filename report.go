@@ -0,0 +1,116 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HandlerStatus 单个处理器在一次分发中的执行状态
+type HandlerStatus string
+
+const (
+	// HandlerApplied 处理器已成功应用配置变更
+	HandlerApplied HandlerStatus = "applied"
+	// HandlerRestartRequired 处理器已接受配置变更，但需重启服务才能生效
+	HandlerRestartRequired HandlerStatus = "restart_required"
+	// HandlerFailed 处理器返回错误、超时或 panic
+	HandlerFailed HandlerStatus = "failed"
+	// HandlerQuarantined 处理器处于隔离状态，未被调用
+	HandlerQuarantined HandlerStatus = "quarantined"
+	// HandlerNotRun 处理器因之前的步骤（解压、过滤器、转换或其他处理器）失败而未被调用
+	HandlerNotRun HandlerStatus = "not_run"
+)
+
+// HandlerReport 单个匹配处理器的执行明细
+type HandlerReport struct {
+	// 处理器注册时使用的配置键模式
+	Pattern string
+	// 重载器/处理器名称
+	Name string
+	// 执行方式
+	Mode ExecutionMode
+	// 执行状态
+	Status HandlerStatus
+	// 执行耗时（未被调用时为 0）
+	Duration time.Duration
+	// 执行失败时的错误（*ChangeError）
+	Err error
+}
+
+// ApplyWithReport 投递携带来源信息的配置变更，并返回包含每个匹配处理器执行明细的处理结果
+// 用于发布工具确认配置变更具体在哪些处理器上生效；返回的错误与 Apply 相同（即 ChangeResult.Err）
+func (m *Manager) ApplyWithReport(ctx context.Context, change Change) (ChangeResult, error) {
+	if m == nil {
+		return ChangeResult{}, fmt.Errorf("manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reports := make([]HandlerReport, 0)
+	result := m.dispatchResult(ctx, change, 0, &reports).ownedHandlers()
+	return result, result.Err
+}
+
+// HandleChangeWithReport 处理配置变更，并返回包含每个匹配处理器执行明细的处理结果（见 ApplyWithReport）
+func (m *Manager) HandleChangeWithReport(key, oldValue, newValue string) (ChangeResult, error) {
+	return m.ApplyWithReport(context.Background(), Change{
+		Key:      key,
+		OldValue: oldValue,
+		NewValue: newValue,
+	})
+}
+
+// reportHandler 记录处理器的执行明细（reports 为 nil 时不记录）
+func reportHandler(reports *[]HandlerReport, reg *registration, status HandlerStatus, duration time.Duration, err error) {
+	if reports == nil {
+		return
+	}
+	*reports = append(*reports, HandlerReport{
+		Pattern:  reg.pattern,
+		Name:     reg.name,
+		Mode:     reg.mode(),
+		Status:   status,
+		Duration: duration,
+		Err:      err,
+	})
+}
+
+// reportNotRun 将未被调用的处理器记录为 HandlerNotRun（处于隔离状态的记录为 HandlerQuarantined）
+func reportNotRun(reports *[]HandlerReport, regs []*registration) {
+	if reports == nil {
+		return
+	}
+	for _, reg := range regs {
+		status := HandlerNotRun
+		if reg.quarantined.Load() {
+			status = HandlerQuarantined
+		}
+		reportHandler(reports, reg, status, 0, nil)
+	}
+}
+
+// settledStatus 根据处理器执行前后的重启原因数判断成功处理器的执行状态
+func settledStatus(before int, restartReasons []string) HandlerStatus {
+	if len(restartReasons) > before {
+		return HandlerRestartRequired
+	}
+	return HandlerApplied
+}
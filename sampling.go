@@ -0,0 +1,138 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"sync"
+	"time"
+)
+
+// LogSamplingRule 日志采样规则
+// 在每个 Interval 窗口内，同一配置键的前 First 条日志全部输出，之后每 Thereafter 条输出 1 条
+// （Thereafter <= 0 表示窗口内超出 First 的日志全部丢弃）
+type LogSamplingRule struct {
+	// 采样窗口
+	Interval time.Duration
+	// 每个窗口内全部输出的条数
+	First int
+	// 超出 First 后每多少条输出 1 条
+	Thereafter int
+}
+
+// logSamplingRoute 按模式配置的日志采样规则
+type logSamplingRoute struct {
+	pattern string
+	rule    LogSamplingRule
+}
+
+// WithLogSampling 为匹配 pattern 的配置键设置日志采样规则
+// 仅对每次变更都会输出的 Info 日志（如 "Config change applied"、"System config updated via hot-reload"）采样，
+// 错误与告警日志不受影响，指标与统计仍会记录每一次变更。
+// 适用于频繁抖动的配置键（如每隔几秒推送一次的权重），多条规则按添加顺序匹配第一条
+func WithLogSampling(pattern string, rule LogSamplingRule) Option {
+	return func(m *Manager) {
+		if pattern == "" || rule.Interval <= 0 {
+			return
+		}
+		if rule.First < 0 {
+			rule.First = 0
+		}
+		m.logSampler.routes = append(m.logSampler.routes, logSamplingRoute{
+			pattern: pattern,
+			rule:    rule,
+		})
+	}
+}
+
+// logSampleState 单个配置键的采样状态
+type logSampleState struct {
+	// 当前窗口的开始时间
+	windowStart time.Time
+	// 当前窗口内的日志条数
+	count int
+	// 自上次输出以来被丢弃的日志条数
+	suppressed int
+}
+
+// logSampler 按配置键的日志采样器
+type logSampler struct {
+	routes []logSamplingRoute
+	states map[string]*logSampleState
+
+	mu sync.Mutex
+}
+
+// allow 判断配置键的本条日志是否应当输出，返回是否输出以及此前被丢弃的条数
+func (s *logSampler) allow(key string, now time.Time) (bool, int) {
+	if len(s.routes) == 0 {
+		return true, 0
+	}
+
+	var rule *LogSamplingRule
+	for i := range s.routes {
+		if s.routes[i].pattern == "*" || matchPattern(s.routes[i].pattern, key) {
+			rule = &s.routes[i].rule
+			break
+		}
+	}
+	if rule == nil {
+		return true, 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.states == nil {
+		s.states = make(map[string]*logSampleState)
+	}
+	state, ok := s.states[key]
+	if !ok {
+		state = &logSampleState{windowStart: now}
+		s.states[key] = state
+	}
+	if now.Sub(state.windowStart) >= rule.Interval {
+		state.windowStart = now
+		state.count = 0
+	}
+	state.count++
+
+	allowed := state.count <= rule.First
+	if !allowed && rule.Thereafter > 0 {
+		allowed = (state.count-rule.First)%rule.Thereafter == 0
+	}
+	if !allowed {
+		state.suppressed++
+		return false, 0
+	}
+
+	suppressed := state.suppressed
+	state.suppressed = 0
+	return true, suppressed
+}
+
+// logChange 按采样规则输出配置变更的 Info 日志
+// 有日志因采样被丢弃时，在下一条输出的日志中附带 suppressed 字段
+func (m *Manager) logChange(key, msg string, keysAndValues ...any) {
+	allowed, suppressed := m.logSampler.allow(key, time.Now())
+	if !allowed {
+		return
+	}
+	if suppressed > 0 {
+		keysAndValues = append(keysAndValues, "suppressed", suppressed)
+	}
+	m.logger.Info(msg, keysAndValues...)
+}
@@ -0,0 +1,265 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package scripting 提供通过配置下发的表达式脚本，在分发前改写或拦截配置值
+//
+// 脚本定义在 "scripts.<name>" 配置键下（JSON），使用 expr 表达式语言（github.com/expr-lang/expr）：
+//
+//	{"patterns": ["server.http.timeout"], "expr": "clamp(number, 1, 60)"}
+//	{"patterns": ["db.*.dsn"], "expr": "{\"prod\": \"production\"}[value] ?? value"}
+//	{"patterns": ["server.replicas"], "expr": "number <= 100", "message": "replicas must not exceed 100"}
+//
+// 表达式可使用的变量：key（配置键）、old（旧值）、value（新值）、number（新值按数字解析，失败为 0）、
+// is_number（新值是否为数字）、env（WithEnv 设置的环境变量）；额外函数：clamp(x, lo, hi)。
+// 结果为字符串或数字时改写新值，为 true 或 nil 时保持不变，为 false 时拒绝变更
+package scripting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// DefaultPrefix 默认的配置键前缀
+const DefaultPrefix = "scripts"
+
+// Script 脚本定义
+type Script struct {
+	// 脚本作用的配置键模式（支持通配符）
+	Patterns []string `json:"patterns"`
+	// 表达式
+	Expr string `json:"expr"`
+	// 表达式结果为 false 时的拒绝原因
+	Message string `json:"message,omitempty"`
+}
+
+// Option 脚本引擎配置选项
+type Option func(*Engine)
+
+// WithPrefix 设置配置键前缀（默认 "scripts"）
+func WithPrefix(prefix string) Option {
+	return func(e *Engine) {
+		if prefix != "" {
+			e.prefix = prefix
+		}
+	}
+}
+
+// WithEnv 设置表达式中可通过 env 访问的变量（如部署环境、区域）
+func WithEnv(env map[string]any) Option {
+	return func(e *Engine) {
+		e.env = maps.Clone(env)
+	}
+}
+
+// compiled 已编译的脚本
+type compiled struct {
+	script  Script
+	program *vm.Program
+	// 从管理器移除该脚本过滤器的函数
+	remove func()
+}
+
+// Engine 脚本引擎，既是 "scripts.*" 的重载器，也为每个脚本注册配置变更过滤器
+type Engine struct {
+	manager *hotreload.Manager
+	prefix  string
+	env     map[string]any
+
+	mu      sync.Mutex
+	scripts map[string]*compiled
+}
+
+// New 创建脚本引擎并注册到管理器
+func New(manager *hotreload.Manager, opts ...Option) (*Engine, error) {
+	if manager == nil {
+		return nil, fmt.Errorf("manager is nil")
+	}
+	e := &Engine{
+		manager: manager,
+		prefix:  DefaultPrefix,
+		env:     map[string]any{},
+		scripts: make(map[string]*compiled),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(e)
+		}
+	}
+	if err := manager.RegisterReloader(e); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Name 返回重载器名称
+func (e *Engine) Name() string {
+	return "scripting"
+}
+
+// Patterns 返回配置键模式列表
+func (e *Engine) Patterns() []string {
+	return []string{e.prefix + ".*"}
+}
+
+// Scripts 返回当前生效的脚本
+func (e *Engine) Scripts() map[string]Script {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	result := make(map[string]Script, len(e.scripts))
+	for name, c := range e.scripts {
+		result[name] = c.script
+	}
+	return result
+}
+
+// Validate 验证脚本定义并编译表达式
+func (e *Engine) Validate(key, value string) error {
+	name, err := e.scriptName(key)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	_, err = e.compile(name, value)
+	return err
+}
+
+// OnChange 编译并替换脚本，值为空时移除脚本
+func (e *Engine) OnChange(key, oldValue, newValue string) error {
+	name, err := e.scriptName(key)
+	if err != nil {
+		return err
+	}
+
+	var c *compiled
+	if strings.TrimSpace(newValue) != "" {
+		if c, err = e.compile(name, newValue); err != nil {
+			return err
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if old, ok := e.scripts[name]; ok {
+		old.remove()
+		delete(e.scripts, name)
+	}
+	if c != nil {
+		c.remove = e.manager.AddFilter("script:"+name, e.filter(name, c), c.script.Patterns...)
+		e.scripts[name] = c
+	}
+	return nil
+}
+
+// scriptName 从配置键中解析脚本名称
+func (e *Engine) scriptName(key string) (string, error) {
+	name, ok := strings.CutPrefix(key, e.prefix+".")
+	if !ok || name == "" {
+		return "", fmt.Errorf("unsupported script key: %s", key)
+	}
+	return name, nil
+}
+
+// compile 解析脚本定义并编译表达式
+func (e *Engine) compile(name, value string) (*compiled, error) {
+	var script Script
+	if err := json.Unmarshal([]byte(value), &script); err != nil {
+		return nil, fmt.Errorf("invalid script %s: %w", name, err)
+	}
+	if len(script.Patterns) == 0 {
+		return nil, fmt.Errorf("script %s has no patterns", name)
+	}
+	for _, pattern := range script.Patterns {
+		// 避免脚本拦截脚本自身的配置
+		if pattern == "*" || strings.HasPrefix(pattern, e.prefix+".") {
+			return nil, fmt.Errorf("script %s pattern %s must not cover %s.*", name, pattern, e.prefix)
+		}
+	}
+	if strings.TrimSpace(script.Expr) == "" {
+		return nil, fmt.Errorf("script %s has no expr", name)
+	}
+
+	program, err := expr.Compile(script.Expr,
+		expr.Env(e.vars(hotreload.Change{})),
+		expr.Function("clamp", clamp, new(func(float64, float64, float64) float64)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile script %s: %w", name, err)
+	}
+	return &compiled{script: script, program: program}, nil
+}
+
+// filter 返回脚本对应的配置变更过滤器
+func (e *Engine) filter(name string, c *compiled) hotreload.ChangeFilter {
+	return func(ctx context.Context, change *hotreload.Change) error {
+		out, err := expr.Run(c.program, e.vars(*change))
+		if err != nil {
+			return fmt.Errorf("script %s failed: %w", name, err)
+		}
+		switch v := out.(type) {
+		case nil:
+		case bool:
+			if !v {
+				if c.script.Message != "" {
+					return fmt.Errorf("rejected by script %s: %s", name, c.script.Message)
+				}
+				return fmt.Errorf("rejected by script %s", name)
+			}
+		case string:
+			change.NewValue = v
+		case int:
+			change.NewValue = strconv.Itoa(v)
+		case float64:
+			change.NewValue = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return fmt.Errorf("script %s returned unsupported type %T", name, out)
+		}
+		return nil
+	}
+}
+
+// vars 构造表达式变量
+func (e *Engine) vars(change hotreload.Change) map[string]any {
+	number, err := strconv.ParseFloat(strings.TrimSpace(change.NewValue), 64)
+	return map[string]any{
+		"key":       change.Key,
+		"old":       change.OldValue,
+		"value":     change.NewValue,
+		"number":    number,
+		"is_number": err == nil,
+		"env":       e.env,
+	}
+}
+
+// clamp 将数字钳制到 [lo, hi] 范围内
+func clamp(params ...any) (any, error) {
+	x, lo, hi := params[0].(float64), params[1].(float64), params[2].(float64)
+	if lo > hi {
+		return nil, fmt.Errorf("clamp: lo %v greater than hi %v", lo, hi)
+	}
+	return min(max(x, lo), hi), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package scripting
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// newEngine 创建脚本引擎，并注册记录 app.* 最新值的处理器
+func newEngine(t *testing.T, opts ...Option) (*hotreload.Manager, *Engine, map[string]string) {
+	t.Helper()
+	m := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	t.Cleanup(func() { m.Close(context.Background()) })
+	e, err := New(m, opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	values := make(map[string]string)
+	if err := m.RegisterHandler("app.*", func(key, _, newValue string) error {
+		values[key] = newValue
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	return m, e, values
+}
+
+// apply 应用单个配置变更
+func apply(m *hotreload.Manager, key, value string) error {
+	return m.Apply(context.Background(), hotreload.Change{Key: key, NewValue: value})
+}
+
+func TestValidate(t *testing.T) {
+	_, e, _ := newEngine(t)
+	tests := []struct {
+		value   string
+		wantErr bool
+	}{
+		{`{"patterns": ["app.*"], "expr": "is_number && number > 0"}`, false},
+		{"", false},
+		{`{"patterns": [], "expr": "true"}`, true},
+		{`{"patterns": ["*"], "expr": "true"}`, true},
+		{`{"patterns": ["scripts.other"], "expr": "true"}`, true},
+		{`{"patterns": ["app.*"], "expr": " "}`, true},
+		{`{"patterns": ["app.*"], "expr": "unknown_var > 1"}`, true},
+		{`not json`, true},
+	}
+	for _, tt := range tests {
+		if err := e.Validate("scripts.positive", tt.value); (err != nil) != tt.wantErr {
+			t.Errorf("Validate(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+	}
+	if err := e.Validate("rules.positive", `{"patterns": ["app.*"], "expr": "true"}`); err == nil {
+		t.Error("Validate() with unsupported key error = nil")
+	}
+}
+
+func TestScriptRejectsAndTransformsChanges(t *testing.T) {
+	m, e, values := newEngine(t, WithEnv(map[string]any{"max_workers": 64.0}))
+	// 过滤器按注册顺序执行，先校验再截断
+	scripts := []struct{ name, script string }{
+		{"positive", `{"patterns": ["app.workers"], "expr": "is_number && number > 0", "message": "workers must be positive"}`},
+		{"cap", `{"patterns": ["app.workers"], "expr": "clamp(number, 1, env.max_workers)"}`},
+		{"lower", `{"patterns": ["app.region"], "expr": "lower(value)"}`},
+	}
+	for _, s := range scripts {
+		if err := apply(m, "scripts."+s.name, s.script); err != nil {
+			t.Fatalf("Apply(scripts.%s) error = %v", s.name, err)
+		}
+	}
+	if got := len(e.Scripts()); got != 3 {
+		t.Fatalf("len(Scripts()) = %d, want 3", got)
+	}
+
+	err := apply(m, "app.workers", "-1")
+	if err == nil || !strings.Contains(err.Error(), "workers must be positive") {
+		t.Fatalf("Apply() error = %v, want rejection message", err)
+	}
+	if err := apply(m, "app.workers", "500"); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := apply(m, "app.region", "EU-West"); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if values["app.workers"] != "64" || values["app.region"] != "eu-west" {
+		t.Fatalf("values = %v, want workers clamped to 64 and region lowercased", values)
+	}
+
+	// 删除脚本后不再拦截变更
+	if err := apply(m, "scripts.positive", ""); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := apply(m, "scripts.cap", ""); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := apply(m, "app.workers", "-1"); err != nil {
+		t.Fatalf("Apply() after script removed error = %v", err)
+	}
+	if values["app.workers"] != "-1" {
+		t.Fatalf("app.workers = %q, want -1", values["app.workers"])
+	}
+}
+
+func TestClamp(t *testing.T) {
+	if got, err := clamp(5.0, 1.0, 3.0); err != nil || got != 3.0 {
+		t.Fatalf("clamp(5, 1, 3) = %v, %v, want 3", got, err)
+	}
+	if _, err := clamp(1.0, 3.0, 1.0); err == nil {
+		t.Fatal("clamp() with lo > hi error = nil")
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// shadowQueueSize 等待镜像到影子管理器的配置变更队列长度
+	shadowQueueSize = 256
+	// shadowRecentSize 保留的最近不一致比较结果条数
+	shadowRecentSize = 50
+)
+
+// ShadowComparison 一次配置变更在实时管理器与影子管理器上的处理结果比较
+type ShadowComparison struct {
+	// 配置键
+	Key string `json:"key"`
+	// 新值
+	NewValue string `json:"new_value"`
+	// 配置键已被删除
+	Deleted bool `json:"deleted,omitempty"`
+	// 变更来源
+	Source string `json:"source,omitempty"`
+	// 实时管理器的处理结果
+	LiveOutcome ChangeOutcome `json:"live_outcome"`
+	// 实时管理器的错误分类
+	LiveErrorKind ErrorKind `json:"live_error_kind,omitempty"`
+	// 实时管理器执行的处理器
+	LiveHandlers []string `json:"live_handlers,omitempty"`
+	// 影子管理器的处理结果
+	ShadowOutcome ChangeOutcome `json:"shadow_outcome"`
+	// 影子管理器的错误分类
+	ShadowErrorKind ErrorKind `json:"shadow_error_kind,omitempty"`
+	// 影子管理器的错误信息
+	ShadowError string `json:"shadow_error,omitempty"`
+	// 影子管理器执行的处理器
+	ShadowHandlers []string `json:"shadow_handlers,omitempty"`
+	// 两者的处理结果与错误分类是否一致
+	Match bool `json:"match"`
+	// 比较时间
+	Time time.Time `json:"time"`
+}
+
+// ShadowReport 影子应用的统计与最近的不一致结果
+type ShadowReport struct {
+	// 已比较的配置变更数
+	Compared uint64 `json:"compared"`
+	// 处理结果不一致的配置变更数
+	Mismatched uint64 `json:"mismatched"`
+	// 因镜像队列已满而未镜像的配置变更数
+	Dropped uint64 `json:"dropped"`
+	// 最近的不一致比较结果（按时间顺序）
+	RecentMismatches []ShadowComparison `json:"recent_mismatches,omitempty"`
+}
+
+// shadowItem 等待镜像的配置变更及其实时处理结果
+type shadowItem struct {
+	change Change
+	live   ChangeResult
+}
+
+// shadowRunner 影子应用状态
+type shadowRunner struct {
+	target *Manager
+	queue  chan shadowItem
+
+	compared   atomic.Uint64
+	mismatched atomic.Uint64
+	dropped    atomic.Uint64
+
+	recent []ShadowComparison
+	mu     sync.Mutex
+}
+
+// WithShadow 开启影子应用：实时管理器处理的每个配置变更都会异步镜像到影子管理器（使用其自己的重载器或记录型处理器），
+// 不影响实时组件；两者的处理结果按顺序比较，不一致时输出日志并发布 EventShadowMismatch 事件（见 ShadowReport）
+// 适用于迁移重载器实现，或在强制执行新的校验规则之前验证其效果；影子管理器的生命周期由调用方管理，
+// 实时管理器关闭时尚未镜像的配置变更被丢弃并计入 Dropped
+func WithShadow(shadow *Manager) Option {
+	return func(m *Manager) {
+		if shadow == nil || shadow == m {
+			return
+		}
+		m.shadow = &shadowRunner{target: shadow, queue: make(chan shadowItem, shadowQueueSize)}
+	}
+}
+
+// startShadow 开启影子应用时启动镜像循环，管理器关闭时退出
+func (m *Manager) startShadow() {
+	s := m.shadow
+	if s == nil {
+		return
+	}
+
+	m.goBackground(func() {
+		for {
+			select {
+			case <-m.lifecycle.done:
+				return
+			case item := <-s.queue:
+				m.compareShadow(item)
+			}
+		}
+	})
+}
+
+// mirrorShadow 将已由实时管理器处理的配置变更加入镜像队列，队列已满时丢弃（不阻塞实时分发）
+func (m *Manager) mirrorShadow(change Change, live ChangeResult) {
+	s := m.shadow
+	if s == nil {
+		return
+	}
+
+	select {
+	case s.queue <- shadowItem{change: change, live: live}:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// compareShadow 在影子管理器上应用配置变更并与实时处理结果比较
+func (m *Manager) compareShadow(item shadowItem) {
+	s := m.shadow
+	ctx := context.Background()
+	if item.change.Reloaded {
+		// 实时管理器已验证过重新分发标记，影子管理器上同样按重新分发处理
+		ctx = context.WithValue(ctx, reloadContextKey{}, true)
+	}
+	shadow, _ := s.target.ApplyWithReport(ctx, item.change)
+
+	comparison := ShadowComparison{
+		Key:             item.change.Key,
+		NewValue:        item.change.NewValue,
+		Deleted:         item.change.Deleted,
+		Source:          item.change.Source,
+		LiveOutcome:     item.live.Outcome,
+		LiveErrorKind:   ErrorKindOf(item.live.Err),
+		LiveHandlers:    slices.Clone(item.live.Handlers),
+		ShadowOutcome:   shadow.Outcome,
+		ShadowErrorKind: ErrorKindOf(shadow.Err),
+		ShadowHandlers:  shadow.Handlers,
+		Time:            time.Now(),
+	}
+	if shadow.Err != nil {
+		comparison.ShadowError = shadow.Err.Error()
+	}
+	comparison.Match = comparison.LiveOutcome == comparison.ShadowOutcome &&
+		comparison.LiveErrorKind == comparison.ShadowErrorKind
+
+	s.compared.Add(1)
+	if comparison.Match {
+		return
+	}
+
+	s.mismatched.Add(1)
+	s.mu.Lock()
+	s.recent = append(s.recent, comparison)
+	if len(s.recent) > shadowRecentSize {
+		s.recent = s.recent[len(s.recent)-shadowRecentSize:]
+	}
+	s.mu.Unlock()
+
+	m.logger.Warn("Shadow apply outcome differs from live",
+		"key", comparison.Key,
+		"new_value", comparison.NewValue,
+		"live_outcome", comparison.LiveOutcome,
+		"live_error_kind", comparison.LiveErrorKind,
+		"shadow_outcome", comparison.ShadowOutcome,
+		"shadow_error_kind", comparison.ShadowErrorKind,
+		"shadow_error", comparison.ShadowError)
+	m.publishEvent(Event{
+		Type:      EventShadowMismatch,
+		Key:       comparison.Key,
+		NewValue:  comparison.NewValue,
+		Deleted:   comparison.Deleted,
+		Source:    comparison.Source,
+		ErrorKind: comparison.ShadowErrorKind,
+		Error:     comparison.ShadowError,
+		Time:      comparison.Time,
+	})
+}
+
+// discardQueue 丢弃关闭时尚未镜像的配置变更，计入 Dropped，返回丢弃数
+// 须在镜像循环退出、且不再有在途变更时调用
+func (s *shadowRunner) discardQueue() int {
+	dropped := 0
+	for {
+		select {
+		case <-s.queue:
+			dropped++
+		default:
+			s.dropped.Add(uint64(dropped))
+			return dropped
+		}
+	}
+}
+
+// ShadowReport 返回影子应用的统计与最近的不一致结果（未开启 WithShadow 时返回零值）
+func (m *Manager) ShadowReport() ShadowReport {
+	if m == nil || m.shadow == nil {
+		return ShadowReport{}
+	}
+
+	s := m.shadow
+	s.mu.Lock()
+	recent := append([]ShadowComparison(nil), s.recent...)
+	s.mu.Unlock()
+	return ShadowReport{
+		Compared:         s.compared.Load(),
+		Mismatched:       s.mismatched.Load(),
+		Dropped:          s.dropped.Load(),
+		RecentMismatches: recent,
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitCompared 等待影子应用比较完 n 个配置变更
+func waitCompared(t *testing.T, m *Manager, n uint64) ShadowReport {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		report := m.ShadowReport()
+		if report.Compared >= n {
+			return report
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("ShadowReport().Compared = %d, want %d", report.Compared, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestShadowKeepsReloadedFlag(t *testing.T) {
+	var deny atomic.Bool
+	authorizer := AuthorizerFunc(func(ctx context.Context, request AuthorizationRequest) error {
+		if deny.Load() {
+			return errors.New("denied")
+		}
+		return nil
+	})
+	shadow := NewManager(WithLogger(NopLogger()), WithAuthorizer(authorizer))
+	defer shadow.Close(context.Background())
+	m := NewManager(WithLogger(NopLogger()), WithAuthorizer(authorizer), WithShadow(shadow))
+	defer m.Close(context.Background())
+	for _, target := range []*Manager{m, shadow} {
+		if err := target.RegisterHandler("app.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+			t.Fatalf("RegisterHandler() error = %v", err)
+		}
+	}
+
+	ctx := context.Background()
+	if err := m.Apply(ctx, Change{Key: "app.key", NewValue: "v1"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	waitCompared(t, m, 1)
+
+	// 重新分发跳过授权，影子管理器上同样应跳过
+	deny.Store(true)
+	if _, err := m.ReloadPrefix(ctx, "app"); err != nil {
+		t.Fatalf("ReloadPrefix() error = %v", err)
+	}
+	if report := waitCompared(t, m, 2); report.Mismatched != 0 {
+		t.Fatalf("ShadowReport().RecentMismatches = %+v, want none", report.RecentMismatches)
+	}
+}
+
+func TestShadowCountsQueuedChangesDiscardedOnClose(t *testing.T) {
+	shadow := NewManager(WithLogger(NopLogger()))
+	defer shadow.Close(context.Background())
+	unblock := make(chan struct{})
+	if err := shadow.RegisterHandler("app.*", func(key, oldValue, newValue string) error {
+		<-unblock
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	m := NewManager(WithLogger(NopLogger()), WithShadow(shadow))
+	if err := m.RegisterHandler("app.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+
+	const changes = 3
+	for _, value := range []string{"v1", "v2", "v3"} {
+		if err := m.Apply(context.Background(), Change{Key: "app.key", NewValue: value}); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	}
+	closed := make(chan error, 1)
+	go func() { closed <- m.Close(context.Background()) }()
+	time.Sleep(20 * time.Millisecond)
+	close(unblock)
+	if err := <-closed; err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	report := m.ShadowReport()
+	if report.Compared+report.Dropped != changes {
+		t.Fatalf("ShadowReport() compared %d and dropped %d, want %d in total", report.Compared, report.Dropped, changes)
+	}
+}
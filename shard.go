@@ -0,0 +1,127 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"hash/maphash"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// defaultShardCount 默认分片数量
+const defaultShardCount = 1
+
+// WithShards 设置精确模式处理器登记、已应用配置记录与快照的分片数量（默认 1）
+// 配置键按哈希分配到分片，分发时只锁定或复制配置键所在的分片；
+// 配置键数量达到数万且变更频繁的服务可设置为 CPU 核数的数倍，避免所有变更争用同一把锁
+func WithShards(n int) Option {
+	return func(m *Manager) {
+		if n > 0 {
+			m.shardCount = n
+		}
+	}
+}
+
+// sharder 按配置键哈希计算分片序号
+type sharder struct {
+	seed maphash.Seed
+	n    int
+}
+
+// newSharder 创建分片函数
+func newSharder(n int) sharder {
+	if n <= 0 {
+		n = defaultShardCount
+	}
+	return sharder{seed: maphash.MakeSeed(), n: n}
+}
+
+// index 返回配置键所在的分片序号
+func (s sharder) index(key string) int {
+	if s.n <= 1 {
+		return 0
+	}
+	return int(maphash.String(s.seed, key) % uint64(s.n))
+}
+
+// registryShard 精确模式处理器登记的分片
+type registryShard struct {
+	exact map[string][]*registration
+
+	mu sync.RWMutex
+}
+
+// routeTable 通配符模式与字段设置器的只读路由表
+// 注册时整体替换，分发时无锁读取
+type routeTable struct {
+	// 含通配符的模式（按首次注册顺序）
+	wildcards []wildcardRoute
+	// 全局字段设置器登记及其允许的配置前缀
+	fieldSetter fieldTarget
+	// 按模块的字段设置器
+	moduleFieldSetters map[string]fieldTarget
+}
+
+// wildcardRoute 通配符模式及其处理器登记
+type wildcardRoute struct {
+	pattern string
+	regs    []*registration
+}
+
+// newRegistry 创建精确模式处理器登记的分片
+func newRegistry(n int) []registryShard {
+	registry := make([]registryShard, n)
+	for i := range registry {
+		registry[i].exact = make(map[string][]*registration)
+	}
+	return registry
+}
+
+// indexRegistrationLocked 更新处理器登记的分发索引（调用方需持有写锁）
+// 精确模式写入所在分片，通配符模式重建路由表
+func (m *Manager) indexRegistrationLocked(pattern string) {
+	if strings.Contains(pattern, "*") {
+		m.rebuildRoutesLocked()
+		return
+	}
+	shard := &m.registry[m.sharder.index(pattern)]
+	regs := slices.Clone(m.handlers[pattern])
+	shard.mu.Lock()
+	shard.exact[pattern] = regs
+	shard.mu.Unlock()
+}
+
+// rebuildRoutesLocked 重建通配符模式与字段设置器的路由表（调用方需持有写锁）
+func (m *Manager) rebuildRoutesLocked() {
+	table := &routeTable{
+		wildcards: make([]wildcardRoute, 0, len(m.wildcards)),
+		fieldSetter: fieldTarget{
+			reg:             m.fieldSetterReg,
+			allowedPrefixes: slices.Clone(m.allowedPrefixes),
+		},
+		moduleFieldSetters: maps.Clone(m.moduleFieldSetters),
+	}
+	for _, pattern := range m.wildcards {
+		table.wildcards = append(table.wildcards, wildcardRoute{
+			pattern: pattern,
+			regs:    slices.Clone(m.handlers[pattern]),
+		})
+	}
+	m.routes.Store(table)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"time"
+)
+
+// SlowHandlerMetrics 可选的指标扩展接口
+// Metrics 实现该接口时，耗时超过慢处理器阈值的调用会额外通过 ObserveSlowHandler 上报
+type SlowHandlerMetrics interface {
+	ObserveSlowHandler(pattern, reloader string, duration time.Duration)
+}
+
+// WithSlowHandlerThreshold 设置慢处理器阈值
+// 单次调用耗时超过 threshold 的处理器会输出告警日志、发布 EventSlowHandler 事件并计入统计，
+// 用于发现阻塞分发的重载器（如在回调中执行同步 DNS 查询）；threshold <= 0 表示不检测（默认）
+func WithSlowHandlerThreshold(threshold time.Duration) Option {
+	return func(m *Manager) {
+		m.slowHandlerThreshold = threshold
+	}
+}
+
+// checkSlowHandler 检查处理器调用是否超过慢处理器阈值
+func (m *Manager) checkSlowHandler(reg *registration, change Change, duration time.Duration) {
+	if m.slowHandlerThreshold <= 0 || duration < m.slowHandlerThreshold {
+		return
+	}
+
+	reg.stats.slowInvocations.Add(1)
+
+	m.logger.Warn("Slow config handler",
+		"key", change.Key,
+		"pattern", reg.pattern,
+		"reloader", reg.name,
+		"duration", duration,
+		"threshold", m.slowHandlerThreshold)
+
+	m.publishEvent(Event{
+		Type:           EventSlowHandler,
+		Key:            change.Key,
+		OldValue:       change.OldValue,
+		NewValue:       change.NewValue,
+		Source:         change.Source,
+		Actor:          change.Actor,
+		SourceRevision: change.SourceRevision,
+		Pattern:        reg.pattern,
+		Reloader:       reg.name,
+		Duration:       duration,
+	})
+
+	if slow, ok := m.metrics.(SlowHandlerMetrics); ok {
+		slow.ObserveSlowHandler(reg.pattern, reg.name, duration)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Snapshot 已应用配置值的不可变快照
+// 每次发布快照时纪元号（Epoch）递增；请求处理器可在开始时获取一次快照，
+// 之后从同一快照读取多个相关配置键，避免批量变更应用过程中读到新旧混杂的值
+type Snapshot struct {
+	epoch uint64
+	// 按配置键哈希分片的配置值，发布新快照时只复制发生变化的分片
+	shards  []map[string]string
+	sharder sharder
+	// 配置键规范化函数（与所属管理器一致）
+	normalizer KeyNormalizer
+}
+
+// newSnapshot 创建空快照
+func newSnapshot(sharder sharder, normalizer KeyNormalizer) *Snapshot {
+	shards := make([]map[string]string, sharder.n)
+	for i := range shards {
+		shards[i] = map[string]string{}
+	}
+	return &Snapshot{shards: shards, sharder: sharder, normalizer: normalizer}
+}
+
+// Epoch 返回快照的纪元号
+func (s *Snapshot) Epoch() uint64 {
+	if s == nil {
+		return 0
+	}
+	return s.epoch
+}
+
+// Get 返回配置键在快照中的值（配置键按所属管理器的规则规范化）
+func (s *Snapshot) Get(key string) (string, bool) {
+	if s == nil || len(s.shards) == 0 {
+		return "", false
+	}
+	if s.normalizer != nil {
+		key = s.normalizer(key)
+	}
+	value, ok := s.shards[s.sharder.index(key)][key]
+	return value, ok
+}
+
+// Len 返回快照中的配置键数量
+func (s *Snapshot) Len() int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for _, values := range s.shards {
+		n += len(values)
+	}
+	return n
+}
+
+// Keys 按字典序返回快照中的所有配置键
+func (s *Snapshot) Keys() []string {
+	if s == nil {
+		return nil
+	}
+	keys := make([]string, 0, s.Len())
+	for _, values := range s.shards {
+		for key := range values {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Map 返回快照中所有配置值的副本
+func (s *Snapshot) Map() map[string]string {
+	if s == nil {
+		return map[string]string{}
+	}
+	result := make(map[string]string, s.Len())
+	for _, values := range s.shards {
+		maps.Copy(result, values)
+	}
+	return result
+}
+
+// Snapshot 返回当前已应用配置值的快照（无锁读取）
+func (m *Manager) Snapshot() *Snapshot {
+	if m == nil {
+		return nil
+	}
+	return m.snapshot.Load()
+}
+
+// snapshotUpdate 待发布到快照的配置值
+type snapshotUpdate struct {
+	value string
+	// 配置键已被删除，从快照中移除
+	deleted bool
+}
+
+// publishSnapshot 发布包含 updates 的新快照，只复制发生变化的分片
+func (m *Manager) publishSnapshot(updates map[string]snapshotUpdate) {
+	if len(updates) == 0 {
+		return
+	}
+	m.snapshotMu.Lock()
+	defer m.snapshotMu.Unlock()
+
+	current := m.Snapshot()
+	shards := slices.Clone(current.shards)
+	copied := make(map[int]bool)
+	for key, update := range updates {
+		idx := current.sharder.index(key)
+		existing, ok := shards[idx][key]
+		if (update.deleted && !ok) || (!update.deleted && ok && existing == update.value) {
+			continue
+		}
+		if !copied[idx] {
+			shards[idx] = maps.Clone(shards[idx])
+			copied[idx] = true
+		}
+		if update.deleted {
+			delete(shards[idx], key)
+		} else {
+			shards[idx][key] = update.value
+		}
+	}
+	if len(copied) == 0 {
+		return
+	}
+	m.snapshot.Store(&Snapshot{
+		epoch:      current.epoch + 1,
+		shards:     shards,
+		sharder:    current.sharder,
+		normalizer: current.normalizer,
+	})
+	m.markSnapshotDirty()
+}
+
+// batch 批量应用期间收集成功应用的配置值
+type batch struct {
+	// 所属管理器（避免在同一 context 下调用的其他管理器误用）
+	manager *Manager
+
+	mu      sync.Mutex
+	updates map[string]snapshotUpdate
+}
+
+// batchContextKey 批量应用在 context 中的键
+type batchContextKey struct{}
+
+// recordApplied 记录成功应用的配置值（deleted 表示配置键已被删除）：批量应用期间暂存，否则立即发布新快照
+func (m *Manager) recordApplied(ctx context.Context, key, value string, deleted bool) {
+	update := snapshotUpdate{value: value, deleted: deleted}
+	if b, ok := ctx.Value(batchContextKey{}).(*batch); ok && b.manager == m {
+		b.mu.Lock()
+		b.updates[key] = update
+		b.mu.Unlock()
+		return
+	}
+	// 重复投递相同的值时保留当前快照，避免复制整个快照
+	if current, ok := m.Snapshot().Get(key); ok && !deleted && current == value {
+		return
+	}
+	m.publishSnapshot(map[string]snapshotUpdate{key: update})
+}
+
+// ApplyBatch 按顺序应用一组配置变更，所有变更处理完成后才以一个新纪元发布快照，
+// 因此通过 Snapshot 读取的一组相关配置键不会出现部分更新
+// 单个变更失败不影响其余变更，失败的变更不会进入快照；返回所有失败的合并错误。
+// 匹配的处理器均为并行处理器（见 WithExecutionMode）的变更会并发分发
+func (m *Manager) ApplyBatch(ctx context.Context, changes []Change) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	b := &batch{manager: m, updates: make(map[string]snapshotUpdate, len(changes))}
+	batchCtx := context.WithValue(ctx, batchContextKey{}, b)
+
+	errs := m.dispatchBatch(batchCtx, changes)
+
+	b.mu.Lock()
+	updates := b.updates
+	b.mu.Unlock()
+	m.publishSnapshot(updates)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Source 配置源生命周期接口
+// 配置源在 Start 后开始监听配置中心并将变更交给管理器（Apply/HandleChange），Stop 后停止监听；
+// 依赖注入集成（hotreloadfx、hotreloadwire）据此将配置源的启停绑定到应用生命周期
+type Source interface {
+	// Start 开始监听配置变更，不应阻塞（监听在后台 goroutine 中进行）
+	Start(ctx context.Context) error
+
+	// Stop 停止监听配置变更
+	Stop(ctx context.Context) error
+}
+
+// StartSources 依次启动配置源，任一启动失败时按相反顺序停止已启动的配置源并返回错误
+func StartSources(ctx context.Context, sources ...Source) error {
+	for i, source := range sources {
+		if source == nil {
+			continue
+		}
+		if err := source.Start(ctx); err != nil {
+			_ = StopSources(ctx, sources[:i]...)
+			return fmt.Errorf("failed to start config source %T: %w", source, err)
+		}
+	}
+	return nil
+}
+
+// StopSources 按相反顺序停止配置源，返回所有失败的合并错误
+func StopSources(ctx context.Context, sources ...Source) error {
+	var errs []error
+	for i := len(sources) - 1; i >= 0; i-- {
+		if sources[i] == nil {
+			continue
+		}
+		if err := sources[i].Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop config source %T: %w", sources[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RegisterReloaders 依次注册一组重载器，返回所有失败的合并错误
+func (m *Manager) RegisterReloaders(reloaders ...Reloader) error {
+	if m == nil {
+		return fmt.Errorf("manager is nil")
+	}
+	var errs []error
+	for _, reloader := range reloaders {
+		if err := m.RegisterReloader(reloader); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
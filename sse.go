@@ -0,0 +1,93 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// sseHeartbeatInterval SSE 心跳间隔（防止代理因空闲断开连接）
+const sseHeartbeatInterval = 15 * time.Second
+
+// EventStreamHandler 返回以 Server-Sent Events 推送热加载事件的 HTTP 处理器
+// 支持通过查询参数 pattern 按配置键模式过滤事件（如 ?pattern=server.features.*）
+// 每个事件以 "event: <type>" 和 JSON 编码的 data 推送，便于看板实时展示配置变更
+func (m *Manager) EventStreamHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		pattern := r.URL.Query().Get("pattern")
+
+		events, cancel := m.SubscribeEvents(defaultEventBuffer)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if pattern != "" && pattern != "*" && !matchPattern(pattern, event.Key) {
+					continue
+				}
+				if err := writeSSEEvent(w, event); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
+
+// writeSSEEvent 以 SSE 格式写出事件
+func writeSSEEvent(w http.ResponseWriter, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if event.Revision > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.Revision); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// counters 热加载核心计数器
+// 所有字段均为原子操作，可在不持有 Manager 锁的情况下读写
+type counters struct {
+	// 收到的配置变更总数
+	changesTotal atomic.Uint64
+	// 成功应用的配置变更数
+	changesApplied atomic.Uint64
+	// 处理失败的配置变更数
+	changesFailed atomic.Uint64
+	// 没有任何处理器匹配的配置变更数
+	changesUnmatched atomic.Uint64
+	// 处理器调用次数
+	handlerInvocations atomic.Uint64
+	// 按错误分类统计的失败次数，下标与 errorKinds 一致
+	failuresByKind [9]atomic.Uint64
+
+	// 最近一次成功应用的修订号（每成功应用一次配置变更递增 1）
+	revision atomic.Uint64
+	// 最近一次成功应用的时间（UnixNano，0 表示尚未应用过）
+	lastAppliedAt atomic.Int64
+}
+
+// markApplied 记录一次成功应用的配置变更，返回新的修订号
+func (c *counters) markApplied(now time.Time) uint64 {
+	c.changesApplied.Add(1)
+	c.lastAppliedAt.Store(now.UnixNano())
+	return c.revision.Add(1)
+}
+
+// recordFailure 记录一次处理失败的配置变更
+func (c *counters) recordFailure(kind ErrorKind) {
+	c.changesFailed.Add(1)
+	for i, k := range errorKinds {
+		if k == kind {
+			c.failuresByKind[i].Add(1)
+			return
+		}
+	}
+}
+
+// Revision 返回最近一次成功应用的修订号
+// 修订号由 Manager 在每次成功应用配置变更后递增，0 表示尚未应用过任何变更
+func (m *Manager) Revision() uint64 {
+	if m == nil {
+		return 0
+	}
+	return m.counters.revision.Load()
+}
+
+// LastAppliedAt 返回最近一次成功应用配置变更的时间
+// 尚未应用过任何变更时返回零值
+func (m *Manager) LastAppliedAt() time.Time {
+	if m == nil {
+		return time.Time{}
+	}
+	ns := m.counters.lastAppliedAt.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AppliedValue 配置键最近一次成功应用的信息
+type AppliedValue struct {
+	// 配置键
+	Key string `json:"key"`
+	// 最近一次应用的值
+	Value string `json:"value"`
+	// 应用后的修订号
+	Revision uint64 `json:"revision"`
+	// 变更来源
+	Source string `json:"source,omitempty"`
+	// 操作者身份
+	Actor string `json:"actor,omitempty"`
+	// 配置源的修订号
+	SourceRevision string `json:"source_revision,omitempty"`
+	// 值是否来自默认值注册表（见 SetDefault）
+	Defaulted bool `json:"defaulted,omitempty"`
+	// 应用时间
+	AppliedAt time.Time `json:"applied_at"`
+	// 实际执行的重载器/处理器名称（按执行顺序）
+	Handlers []string `json:"handlers"`
+}
+
+// store 按配置键记录最近一次成功应用的信息（按配置键哈希分片）
+type store struct {
+	sharder sharder
+	shards  []storeShard
+}
+
+// storeShard 配置键存储的分片
+type storeShard struct {
+	entries map[string]AppliedValue
+
+	mu sync.RWMutex
+}
+
+// newStore 创建配置键存储
+func newStore(sharder sharder) *store {
+	s := &store{sharder: sharder, shards: make([]storeShard, sharder.n)}
+	for i := range s.shards {
+		s.shards[i].entries = make(map[string]AppliedValue)
+	}
+	return s
+}
+
+// set 记录配置键最近一次成功应用的信息，返回此前是否已有记录
+func (s *store) set(value AppliedValue) bool {
+	shard := &s.shards[s.sharder.index(value.Key)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	_, existed := shard.entries[value.Key]
+	shard.entries[value.Key] = value
+	return existed
+}
+
+// delete 删除配置键的记录，返回此前是否已有记录
+func (s *store) delete(key string) bool {
+	shard := &s.shards[s.sharder.index(key)]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	_, existed := shard.entries[key]
+	delete(shard.entries, key)
+	return existed
+}
+
+// get 查询配置键最近一次成功应用的信息
+func (s *store) get(key string) (AppliedValue, bool) {
+	shard := &s.shards[s.sharder.index(key)]
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+	value, ok := shard.entries[key]
+	return value, ok
+}
+
+// list 按配置键排序返回所有记录
+func (s *store) list() []AppliedValue {
+	result := make([]AppliedValue, 0)
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.RLock()
+		for _, value := range shard.entries {
+			result = append(result, value)
+		}
+		shard.mu.RUnlock()
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Key < result[j].Key })
+	return result
+}
+
+// storeApplied 记录成功应用的配置变更，配置键被删除时移除其记录
+// 返回配置键在该变更前是否已有应用的值
+func (m *Manager) storeApplied(deleted bool, value AppliedValue) bool {
+	if deleted {
+		return m.store.delete(value.Key)
+	}
+	return m.store.set(value)
+}
+
+// LastApplied 查询配置键最近一次成功应用的值、修订号、时间以及执行的处理器（Handlers 为副本）
+// 用于确认新配置是否真正生效（如“新的超时时间到底有没有落地”）
+func (m *Manager) LastApplied(key string) (AppliedValue, bool) {
+	if m == nil {
+		return AppliedValue{}, false
+	}
+	value, ok := m.store.get(m.NormalizeKey(key))
+	value.Handlers = slices.Clone(value.Handlers)
+	return value, ok
+}
+
+// AppliedValues 按配置键排序返回所有配置键最近一次成功应用的信息
+func (m *Manager) AppliedValues() []AppliedValue {
+	if m == nil {
+		return nil
+	}
+	values := m.store.list()
+	for i := range values {
+		values[i].Handlers = slices.Clone(values[i].Handlers)
+	}
+	return values
+}
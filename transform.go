@@ -0,0 +1,172 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValueTransform 配置值转换函数，返回规范化后的值
+// 用于单位换算、旧格式转换、去除空白等，使处理器无论变更来自哪个配置源都收到规范的值
+type ValueTransform func(key, value string) (string, error)
+
+// transformRoute 转换函数路由规则
+type transformRoute struct {
+	// 转换函数标识（用于移除）
+	id uint64
+	// 转换函数名称（用于日志与错误信息）
+	name string
+	// 配置键前缀列表（为空表示转换所有配置键）
+	prefixes []string
+	// 转换函数
+	transform ValueTransform
+}
+
+// matches 检查配置键是否位于转换函数的前缀之下，返回匹配的前缀
+func (r *transformRoute) matches(key string) (string, bool) {
+	if len(r.prefixes) == 0 {
+		return "", true
+	}
+	for _, prefix := range r.prefixes {
+		if underPrefix(key, prefix) {
+			return prefix, true
+		}
+	}
+	return "", false
+}
+
+// AddTransform 按前缀注册配置值转换函数，按注册顺序组成流水线，前一个函数的输出作为下一个函数的输入
+// 转换在过滤器之后、处理器（包括重载器的 Validate）之前执行，同时作用于新值与旧值，
+// 已应用记录、快照与变更历史中保存的也是转换后的值；新值转换失败时按验证失败拒绝该变更，旧值转换失败时保留原值。
+// prefixes 为空时转换所有配置键；返回用于移除该转换函数的函数
+func (m *Manager) AddTransform(name string, transform ValueTransform, prefixes ...string) (remove func()) {
+	if m == nil || transform == nil {
+		return func() {}
+	}
+
+	normalized := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		normalized = append(normalized, m.NormalizeKey(strings.TrimSuffix(prefix, ".")))
+	}
+
+	m.mu.Lock()
+	m.transformSeq++
+	route := &transformRoute{
+		id:        m.transformSeq,
+		name:      name,
+		prefixes:  normalized,
+		transform: transform,
+	}
+	m.transforms = append(m.transforms, route)
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		// 写时复制，避免影响正在执行的转换遍历
+		transforms := make([]*transformRoute, 0, len(m.transforms))
+		for _, r := range m.transforms {
+			if r.id != route.id {
+				transforms = append(transforms, r)
+			}
+		}
+		m.transforms = transforms
+	}
+}
+
+// runTransforms 依次执行匹配的转换函数，新值转换失败时返回验证失败错误
+func (m *Manager) runTransforms(change *Change) *ChangeError {
+	m.mu.RLock()
+	transforms := m.transforms
+	m.mu.RUnlock()
+
+	for _, route := range transforms {
+		prefix, ok := route.matches(change.Key)
+		if !ok {
+			continue
+		}
+		if !change.Deleted {
+			value, err := m.callTransform(route, change.Key, change.NewValue)
+			if err != nil {
+				return &ChangeError{
+					Kind:     ErrorKindValidation,
+					Key:      change.Key,
+					Pattern:  prefix,
+					Reloader: route.name,
+					Err:      err,
+				}
+			}
+			change.NewValue = value
+		}
+		if change.OldValue != "" {
+			if value, err := m.callTransform(route, change.Key, change.OldValue); err == nil {
+				change.OldValue = value
+			}
+		}
+	}
+	return nil
+}
+
+// transformValue 执行匹配的转换函数（用于预演）
+func (m *Manager) transformValue(key, value string) (string, error) {
+	change := Change{Key: key, NewValue: value}
+	if cerr := m.runTransforms(&change); cerr != nil {
+		return value, cerr
+	}
+	return change.NewValue, nil
+}
+
+// callTransform 调用单个转换函数，panic 视为转换失败
+func (m *Manager) callTransform(route *transformRoute, key, value string) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			m.logger.Error("Config value transform panicked",
+				"transform", route.name,
+				"key", key,
+				"panic", r)
+			err = fmt.Errorf("transform panicked: %v", r)
+		}
+	}()
+	return route.transform(key, value)
+}
+
+// TrimSpaceTransform 去除配置值首尾空白
+func TrimSpaceTransform(key, value string) (string, error) {
+	return strings.TrimSpace(value), nil
+}
+
+// DurationTransform 返回将时长规范化为 time.Duration 字符串（如 "1m30s"）的转换函数
+// 支持 Go 时长格式（"90s"、"1.5m"）以及不带单位的数字（按 unit 解释，如旧格式的毫秒数 "1500"）
+func DurationTransform(unit time.Duration) ValueTransform {
+	return func(key, value string) (string, error) {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return value, nil
+		}
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return time.Duration(n * float64(unit)).String(), nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid duration %q: %w", value, err)
+		}
+		return d.String(), nil
+	}
+}
@@ -16,6 +16,10 @@
 
 package hotreload
 
+import (
+	"context"
+)
+
 // ConfigChangeHandler 配置变更处理器
 // key: 配置键（如 "server.features.rate_limit.rate"）
 // oldValue: 旧值
@@ -23,6 +27,11 @@ package hotreload
 // 返回错误时，配置变更不会生效，并记录错误日志
 type ConfigChangeHandler func(key, oldValue, newValue string) error
 
+// ChangeHandler 携带来源信息的配置变更处理器
+// change 中包含配置键、新旧值以及来源、修订号、时间、操作者等来源信息
+// 返回错误时，配置变更不会生效，并记录错误日志
+type ChangeHandler func(ctx context.Context, change Change) error
+
 // Reloader 配置重载器接口
 // 用于实现自定义配置热加载逻辑
 type Reloader interface {
@@ -38,6 +47,36 @@ type Reloader interface {
 	Validate(key, value string) error
 }
 
+// NamedReloader 可选的重载器扩展接口
+// 实现该接口的重载器以 Name() 作为名称出现在日志、指标、统计与管理接口中，
+// 未实现时使用重载器的类型名
+type NamedReloader interface {
+	Reloader
+
+	// Name 返回重载器名称
+	Name() string
+}
+
+// ChangeReloader 可选的重载器扩展接口
+// 实现该接口的重载器会以 OnChangeContext 代替 OnChange 接收配置变更，
+// 从而获得来源、修订号、时间、操作者等来源信息（例如拒绝非指定配置中心发起的变更）
+type ChangeReloader interface {
+	Reloader
+
+	// OnChangeContext 携带来源信息的配置变更回调
+	OnChangeContext(ctx context.Context, change Change) error
+}
+
+// DeleteAwareReloader 可选的重载器扩展接口
+// 配置键被删除（Change.Deleted）时，实现该接口的重载器以 OnDelete 代替 Validate 与 OnChange 接收删除事件，
+// 可据此回退到默认值，而不是把空字符串当作新的配置值
+type DeleteAwareReloader interface {
+	Reloader
+
+	// OnDelete 配置键删除回调，oldValue 为删除前的值
+	OnDelete(key, oldValue string) error
+}
+
 // FieldSetter 字段设置函数
 // 用于将配置值设置到目标对象上
 // module: 模块名称（如 "server", "gateway", "features"）
@@ -0,0 +1,242 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Value 热加载配置值容器
+// Load 无锁读取最近一次通过验证并成功应用的值，适合在请求路径上直接读取热加载配置
+type Value[T any] struct {
+	key     string
+	parse   func(value string) (T, error)
+	current atomic.Pointer[T]
+	// 默认值（配置键被删除时回退到默认值）
+	def *T
+	// 值更新后的回调（ObservableValue 使用）
+	onStore func(value T)
+}
+
+// ValueOption 配置值容器选项
+type ValueOption[T any] func(*Value[T])
+
+// WithDefault 设置配置键尚未下发或被删除时的默认值
+func WithDefault[T any](def T) ValueOption[T] {
+	return func(v *Value[T]) {
+		v.def = &def
+		v.current.Store(&def)
+	}
+}
+
+// NewValue 创建绑定到配置键的配置值容器并注册到管理器
+// parse 解析并验证配置值，解析失败时变更被拒绝，容器保留原值；
+// 配置键此前已成功应用过时，以最近一次应用的值初始化容器
+func NewValue[T any](m *Manager, key string, parse func(value string) (T, error), opts ...ValueOption[T]) (*Value[T], error) {
+	return newValue(m, key, parse, nil, opts)
+}
+
+// newValue 创建配置值容器，onStore 在值更新后调用
+func newValue[T any](m *Manager, key string, parse func(value string) (T, error), onStore func(value T), opts []ValueOption[T]) (*Value[T], error) {
+	if parse == nil {
+		return nil, fmt.Errorf("parse func is nil")
+	}
+	v := &Value[T]{key: m.NormalizeKey(key), parse: parse, onStore: onStore}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(v)
+		}
+	}
+	if applied, ok := m.LastApplied(key); ok {
+		if parsed, err := parse(applied.Value); err == nil {
+			v.current.Store(&parsed)
+		}
+	}
+	if err := m.RegisterReloader(valueReloader[T]{v}); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Load 返回当前值，配置键尚未下发且未设置默认值时返回零值
+func (v *Value[T]) Load() T {
+	if current := v.current.Load(); current != nil {
+		return *current
+	}
+	var zero T
+	return zero
+}
+
+// Key 返回绑定的配置键
+func (v *Value[T]) Key() string {
+	return v.key
+}
+
+// valueReloader 配置值容器的重载器适配
+type valueReloader[T any] struct {
+	v *Value[T]
+}
+
+// Name 返回重载器名称
+func (r valueReloader[T]) Name() string {
+	return "value[" + reflect.TypeFor[T]().String() + "]"
+}
+
+// Patterns 返回配置键模式
+func (r valueReloader[T]) Patterns() []string {
+	return []string{r.v.key}
+}
+
+// Validate 验证配置值可被解析
+func (r valueReloader[T]) Validate(key, value string) error {
+	_, err := r.v.parse(value)
+	return err
+}
+
+// OnChange 解析配置值并原子替换
+func (r valueReloader[T]) OnChange(key, oldValue, newValue string) error {
+	parsed, err := r.v.parse(newValue)
+	if err != nil {
+		return err
+	}
+	r.v.store(parsed)
+	return nil
+}
+
+// OnDelete 配置键被删除时回退到默认值（未设置默认值时为零值）
+func (r valueReloader[T]) OnDelete(key, oldValue string) error {
+	var value T
+	if r.v.def != nil {
+		value = *r.v.def
+	}
+	r.v.store(value)
+	return nil
+}
+
+// store 原子替换当前值并通知订阅者
+func (v *Value[T]) store(value T) {
+	v.current.Store(&value)
+	if v.onStore != nil {
+		v.onStore(value)
+	}
+}
+
+// ObservableValue 可订阅变化的配置值容器
+// 除 Load 读取当前值外，还可通过 Changes 在 select 中等待配置更新，无需注册处理器并自行管理共享状态
+type ObservableValue[T any] struct {
+	*Value[T]
+
+	mu   sync.Mutex
+	subs map[*valueSubscriber[T]]struct{}
+}
+
+// valueSubscriber 配置值订阅者
+type valueSubscriber[T any] struct {
+	ch chan T
+}
+
+// ChangesOption 配置值订阅选项
+type ChangesOption func(*changesOptions)
+
+// changesOptions 订阅配置
+type changesOptions struct {
+	buffer  int
+	current bool
+}
+
+// WithChangesBuffer 设置订阅缓冲区大小（默认 1）
+// 缓冲区已满时丢弃最旧的值，因此默认只保留最新值（多次更新合并为一次）
+func WithChangesBuffer(size int) ChangesOption {
+	return func(o *changesOptions) {
+		if size > 0 {
+			o.buffer = size
+		}
+	}
+}
+
+// WithCurrentValue 订阅时先推送当前值（当前值为零值且未设置默认值时也会推送）
+func WithCurrentValue() ChangesOption {
+	return func(o *changesOptions) {
+		o.current = true
+	}
+}
+
+// NewObservableValue 创建可订阅变化的配置值容器并注册到管理器（参数与 NewValue 相同）
+func NewObservableValue[T any](m *Manager, key string, parse func(value string) (T, error), opts ...ValueOption[T]) (*ObservableValue[T], error) {
+	o := &ObservableValue[T]{subs: make(map[*valueSubscriber[T]]struct{})}
+	v, err := newValue(m, key, parse, o.publish, opts)
+	if err != nil {
+		return nil, err
+	}
+	o.Value = v
+	return o, nil
+}
+
+// Changes 订阅配置值更新，返回更新通道与取消订阅函数，取消订阅后通道会被关闭
+func (o *ObservableValue[T]) Changes(opts ...ChangesOption) (<-chan T, func()) {
+	co := &changesOptions{buffer: 1}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(co)
+		}
+	}
+
+	sub := &valueSubscriber[T]{ch: make(chan T, co.buffer)}
+	o.mu.Lock()
+	o.subs[sub] = struct{}{}
+	if co.current {
+		sub.send(o.Load())
+	}
+	o.mu.Unlock()
+
+	var once sync.Once
+	return sub.ch, func() {
+		once.Do(func() {
+			o.mu.Lock()
+			delete(o.subs, sub)
+			close(sub.ch)
+			o.mu.Unlock()
+		})
+	}
+}
+
+// publish 向所有订阅者推送新值
+func (o *ObservableValue[T]) publish(value T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for sub := range o.subs {
+		sub.send(value)
+	}
+}
+
+// send 推送新值，缓冲区已满时丢弃最旧的值（调用方需持有 ObservableValue.mu）
+func (s *valueSubscriber[T]) send(value T) {
+	for {
+		select {
+		case s.ch <- value:
+			return
+		default:
+		}
+		select {
+		case <-s.ch:
+		default:
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package viperbridge 提供 viper 与热加载管理器之间的双向桥接
+//
+// 方向一：以管理器作为 viper 的变更接收端。Bridge 记录 viper 配置的扁平化快照，
+// 配置文件变化（viper.OnConfigChange）或调用 Sync 时计算按配置键的差异，并逐个交给管理器分发：
+//
+//	b, _ := viperbridge.New(v, manager)
+//	_ = b.Sync(ctx) // 首次同步会以空旧值分发全部配置键
+//	b.Watch(ctx)    // 调用 v.WatchConfig 并在配置文件变化时自动同步
+//
+// 方向二：Snapshot 将管理器已成功应用的配置值构造为一个新的 viper 实例，
+// 便于已依赖 viper 读取 API（GetInt、GetDuration、Unmarshal 等）的代码平滑迁移
+package viperbridge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/go-anyway/framework-hotreload/internal/bridge"
+	"github.com/go-anyway/framework-hotreload/internal/values"
+	"github.com/spf13/viper"
+)
+
+// DefaultSource 默认的配置变更来源
+const DefaultSource = "viper"
+
+// Option 桥接配置选项
+type Option func(*Bridge)
+
+// WithSource 设置配置变更来源（默认 "viper"）
+func WithSource(source string) Option {
+	return func(b *Bridge) {
+		if source != "" {
+			b.source = source
+		}
+	}
+}
+
+// WithErrorHandler 设置自动同步失败时的回调（默认忽略，失败详情已记录在管理器日志中）
+func WithErrorHandler(fn func(err error)) Option {
+	return func(b *Bridge) {
+		b.onError = fn
+	}
+}
+
+// Bridge viper 到热加载管理器的桥接
+type Bridge struct {
+	viper   *viper.Viper
+	source  string
+	onError func(err error)
+	syncer  *bridge.Syncer
+}
+
+// New 创建 viper 桥接
+func New(v *viper.Viper, manager *hotreload.Manager, opts ...Option) (*Bridge, error) {
+	if v == nil {
+		return nil, fmt.Errorf("viper is nil")
+	}
+	if manager == nil {
+		return nil, fmt.Errorf("manager is nil")
+	}
+	b := &Bridge{viper: v, source: DefaultSource}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(b)
+		}
+	}
+	b.syncer = bridge.NewSyncer(manager, b.source)
+	return b, nil
+}
+
+// Sync 比较 viper 当前配置与上次同步的快照，将新增、修改与删除的配置键交给管理器分发
+// 删除的配置键以 Change.Deleted 投递（已登记默认值时回退到默认值，见 hotreload.Manager.SetDefault）
+// 分发失败的配置键保留旧快照，下次同步时重试；返回所有失败的合并错误
+func (b *Bridge) Sync(ctx context.Context) error {
+	current, err := flatten(b.viper)
+	if err != nil {
+		return err
+	}
+	return b.syncer.Sync(ctx, current)
+}
+
+// Watch 监听 viper 配置文件变化并自动同步，直到 ctx 结束（结束后的文件变化被忽略）
+// 会调用 v.WatchConfig，因此 viper 需已通过 SetConfigFile/ReadInConfig 加载配置文件
+func (b *Bridge) Watch(ctx context.Context) {
+	b.viper.OnConfigChange(func(fsnotify.Event) {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := b.Sync(ctx); err != nil && b.onError != nil {
+			b.onError(err)
+		}
+	})
+	b.viper.WatchConfig()
+}
+
+// Snapshot 以管理器已成功应用的配置值构造新的 viper 实例
+// 每次调用返回独立的实例，可在并发读取时安全使用；配置值后续变化不会反映到已返回的实例中
+func Snapshot(manager *hotreload.Manager) *viper.Viper {
+	v := viper.New()
+	for _, applied := range manager.AppliedValues() {
+		v.Set(applied.Key, applied.Value)
+	}
+	return v
+}
+
+// flatten 将 viper 配置扁平化为配置键到字符串值的映射
+func flatten(v *viper.Viper) (map[string]string, error) {
+	keys := v.AllKeys()
+	result := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, err := values.Format(v.Get(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to format key %s: %w", key, err)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package viperbridge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/spf13/viper"
+)
+
+// readYAML 以 YAML 内容替换 viper 的配置
+func readYAML(t *testing.T, v *viper.Viper, content string) {
+	t.Helper()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(strings.NewReader(content)); err != nil {
+		t.Fatalf("ReadConfig() error = %v", err)
+	}
+}
+
+func TestSyncDispatchesDiffs(t *testing.T) {
+	m := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	defer m.Close(context.Background())
+	var changes []hotreload.Change
+	reject := ""
+	if err := m.RegisterChangeHandler("app.*", func(_ context.Context, change hotreload.Change) error {
+		if change.NewValue == reject {
+			return fmt.Errorf("rejected %s", change.NewValue)
+		}
+		changes = append(changes, change)
+		return nil
+	}); err != nil {
+		t.Fatalf("RegisterChangeHandler() error = %v", err)
+	}
+
+	v := viper.New()
+	b, err := New(v, m, WithSource("file"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+	readYAML(t, v, "app:\n  name: demo\n  hosts: [a, b]\n")
+	if err := b.Sync(ctx); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, c := range changes {
+		got[c.Key] = c.NewValue
+		if c.Source != "file" {
+			t.Fatalf("Source = %q, want file", c.Source)
+		}
+	}
+	if got["app.name"] != "demo" || got["app.hosts"] != `["a","b"]` {
+		t.Fatalf("initial sync = %v", got)
+	}
+
+	// 未变化的配置键不会重复分发
+	changes = nil
+	if err := b.Sync(ctx); err != nil || len(changes) != 0 {
+		t.Fatalf("Sync() without changes = %v, %v", changes, err)
+	}
+
+	reject = "v2"
+	readYAML(t, v, "app:\n  name: v2\n")
+	if err := b.Sync(ctx); err == nil {
+		t.Fatal("Sync() with rejected change error = nil")
+	}
+	reject = ""
+	changes = nil
+	if err := b.Sync(ctx); err != nil {
+		t.Fatalf("Sync() retry error = %v", err)
+	}
+	// 删除在上次同步中已成功分发，重试时只分发被拒绝的 app.name
+	if len(changes) != 1 || changes[0].Key != "app.name" || changes[0].OldValue != "demo" || changes[0].NewValue != "v2" {
+		t.Fatalf("retried changes = %+v", changes)
+	}
+
+	snapshot := Snapshot(m)
+	if snapshot.GetString("app.name") != "v2" {
+		t.Fatalf("Snapshot().GetString(app.name) = %q, want v2", snapshot.GetString("app.name"))
+	}
+	if snapshot.IsSet("app.hosts") {
+		t.Fatal("Snapshot() contains deleted app.hosts")
+	}
+}
+
+func TestNewRejectsNilArguments(t *testing.T) {
+	m := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	defer m.Close(context.Background())
+	if _, err := New(nil, m); err == nil {
+		t.Error("New() with nil viper error = nil")
+	}
+	if _, err := New(viper.New(), nil); err == nil {
+		t.Error("New() with nil manager error = nil")
+	}
+}
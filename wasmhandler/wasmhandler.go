@@ -0,0 +1,411 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+// Package wasmhandler 提供以 WASM 模块实现的配置变更处理器
+//
+// WASM 模块本身也通过配置下发（配置键 "wasm.<name>.module"，值为文件路径或 "base64:<内容>"），
+// 因此行为扩展可以与普通配置一样经过验证、分发与回滚流程热部署。
+// 模块在 wazero 沙箱中运行：默认不提供任何宿主能力（文件、网络、环境变量），
+// 内存页数、单次调用耗时与模块大小均有上限，且每次调用使用全新的模块实例，调用之间不共享状态。
+//
+// 模块需导出以下函数（字符串以 UTF-8 写入模块线性内存，按指针与长度传递）：
+//
+//	memory                                  线性内存
+//	alloc(size i32) i32                     分配 size 字节并返回指针
+//	on_change(kp, kl, op, ol, np, nl i32) i32  处理配置变更，返回非 0 表示失败
+//	validate(kp, kl, vp, vl i32) i32        可选，验证配置值，返回非 0 表示无效
+//
+// 模块可导入 "hotreload" 模块的 set_error(ptr, len i32) 设置失败原因
+package wasmhandler
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	// DefaultPrefix 默认的模块配置键前缀
+	DefaultPrefix = "wasm"
+	// DefaultMemoryLimitPages 默认的内存页数上限（每页 64KiB，共 16MiB）
+	DefaultMemoryLimitPages = 256
+	// DefaultTimeout 默认的单次调用耗时上限
+	DefaultTimeout = time.Second
+	// DefaultMaxModuleSize 默认的模块大小上限
+	DefaultMaxModuleSize = 16 << 20
+
+	// hostModule 宿主函数所在的模块名
+	hostModule = "hotreload"
+	// base64Prefix 内联模块内容的值前缀
+	base64Prefix = "base64:"
+)
+
+// Option 处理器配置选项
+type Option func(*options)
+
+// options 处理器配置
+type options struct {
+	prefix           string
+	memoryLimitPages uint32
+	timeout          time.Duration
+	maxModuleSize    int
+	wasi             bool
+	module           []byte
+}
+
+// WithPrefix 设置模块配置键前缀（默认 "wasm"）
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		if prefix != "" {
+			o.prefix = prefix
+		}
+	}
+}
+
+// WithMemoryLimitPages 设置模块内存页数上限（每页 64KiB，默认 256）
+func WithMemoryLimitPages(pages uint32) Option {
+	return func(o *options) {
+		if pages > 0 {
+			o.memoryLimitPages = pages
+		}
+	}
+}
+
+// WithTimeout 设置单次调用耗时上限（默认 1s），超时后模块执行被中断
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+// WithMaxModuleSize 设置模块大小上限（默认 16MiB）
+func WithMaxModuleSize(size int) Option {
+	return func(o *options) {
+		if size > 0 {
+			o.maxModuleSize = size
+		}
+	}
+}
+
+// WithWASI 为模块提供 WASI 接口（如 TinyGo 编译的模块需要）
+// 只提供最小运行环境，不挂载文件系统，也不透传环境变量与命令行参数
+func WithWASI() Option {
+	return func(o *options) {
+		o.wasi = true
+	}
+}
+
+// WithModule 设置初始模块内容（在配置下发模块前使用）
+func WithModule(module []byte) Option {
+	return func(o *options) {
+		o.module = module
+	}
+}
+
+// Handler WASM 配置变更处理器
+type Handler struct {
+	name      string
+	moduleKey string
+	patterns  []string
+	opts      *options
+
+	runtime wazero.Runtime
+
+	// 读锁保护调用中的模块，写锁用于替换模块
+	mu       sync.RWMutex
+	compiled wazero.CompiledModule
+	// 当前模块是否导出 validate
+	hasValidate bool
+}
+
+// callState 单次调用的状态
+type callState struct {
+	err string
+}
+
+// callStateKey 单次调用状态在 context 中的键
+type callStateKey struct{}
+
+// New 创建 WASM 处理器，patterns 为模块处理的配置键模式
+// 使用完毕后需调用 Close 释放运行时
+func New(name string, patterns []string, opts ...Option) (*Handler, error) {
+	if name == "" {
+		return nil, fmt.Errorf("wasm handler name is empty")
+	}
+	if len(patterns) == 0 {
+		return nil, fmt.Errorf("wasm handler %s has no patterns", name)
+	}
+	o := &options{
+		prefix:           DefaultPrefix,
+		memoryLimitPages: DefaultMemoryLimitPages,
+		timeout:          DefaultTimeout,
+		maxModuleSize:    DefaultMaxModuleSize,
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	ctx := context.Background()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(o.memoryLimitPages).
+		WithCloseOnContextDone(true))
+	_, err := runtime.NewHostModuleBuilder(hostModule).
+		NewFunctionBuilder().WithFunc(setError).Export("set_error").
+		Instantiate(ctx)
+	if err == nil && o.wasi {
+		_, err = wasi_snapshot_preview1.Instantiate(ctx, runtime)
+	}
+	if err != nil {
+		_ = runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to initialize wasm runtime: %w", err)
+	}
+
+	h := &Handler{
+		name:      name,
+		moduleKey: o.prefix + "." + name + ".module",
+		patterns:  append([]string(nil), patterns...),
+		opts:      o,
+		runtime:   runtime,
+	}
+	if len(o.module) > 0 {
+		if err := h.Load(o.module); err != nil {
+			_ = runtime.Close(ctx)
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Register 创建 WASM 处理器并注册到管理器
+func Register(manager *hotreload.Manager, name string, patterns []string, opts ...Option) (*Handler, error) {
+	if manager == nil {
+		return nil, fmt.Errorf("manager is nil")
+	}
+	h, err := New(name, patterns, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := manager.RegisterReloader(h); err != nil {
+		_ = h.Close(context.Background())
+		return nil, err
+	}
+	return h, nil
+}
+
+// Close 释放运行时与已编译的模块
+func (h *Handler) Close(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.compiled = nil
+	return h.runtime.Close(ctx)
+}
+
+// Name 返回处理器名称
+func (h *Handler) Name() string {
+	return "wasm:" + h.name
+}
+
+// Patterns 返回配置键模式列表（模块配置键与模块处理的配置键模式）
+func (h *Handler) Patterns() []string {
+	return append([]string{h.moduleKey}, h.patterns...)
+}
+
+// Validate 验证模块配置或调用模块的 validate 验证配置值
+func (h *Handler) Validate(key, value string) error {
+	if key == h.moduleKey {
+		module, err := h.readModule(value)
+		if err != nil {
+			return err
+		}
+		ctx := context.Background()
+		compiled, _, err := h.compile(ctx, module)
+		if err != nil {
+			return err
+		}
+		return compiled.Close(ctx)
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.compiled == nil || !h.hasValidate {
+		return nil
+	}
+	return h.call(context.Background(), "validate", key, value)
+}
+
+// OnChange 处理配置变更
+func (h *Handler) OnChange(key, oldValue, newValue string) error {
+	return h.OnChangeContext(context.Background(), hotreload.Change{Key: key, OldValue: oldValue, NewValue: newValue})
+}
+
+// OnChangeContext 处理配置变更：模块配置变更时替换模块，其余变更交给模块的 on_change 处理
+func (h *Handler) OnChangeContext(ctx context.Context, change hotreload.Change) error {
+	if change.Key == h.moduleKey {
+		module, err := h.readModule(change.NewValue)
+		if err != nil {
+			return err
+		}
+		return h.Load(module)
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.compiled == nil {
+		return fmt.Errorf("wasm handler %s has no module loaded", h.name)
+	}
+	return h.call(ctx, "on_change", change.Key, change.OldValue, change.NewValue)
+}
+
+// Load 编译并替换当前模块，失败时保留原模块
+func (h *Handler) Load(module []byte) error {
+	if len(module) > h.opts.maxModuleSize {
+		return fmt.Errorf("wasm module size %d exceeds limit %d", len(module), h.opts.maxModuleSize)
+	}
+	ctx := context.Background()
+	compiled, hasValidate, err := h.compile(ctx, module)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	old := h.compiled
+	h.compiled = compiled
+	h.hasValidate = hasValidate
+	h.mu.Unlock()
+
+	if old != nil {
+		_ = old.Close(ctx)
+	}
+	return nil
+}
+
+// readModule 读取模块内容（文件路径或 "base64:" 内联内容）
+func (h *Handler) readModule(value string) ([]byte, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, fmt.Errorf("wasm module is empty")
+	}
+	var module []byte
+	var err error
+	if encoded, ok := strings.CutPrefix(value, base64Prefix); ok {
+		module, err = base64.StdEncoding.DecodeString(encoded)
+	} else {
+		module, err = os.ReadFile(value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wasm module: %w", err)
+	}
+	if len(module) > h.opts.maxModuleSize {
+		return nil, fmt.Errorf("wasm module size %d exceeds limit %d", len(module), h.opts.maxModuleSize)
+	}
+	return module, nil
+}
+
+// compile 编译模块并检查导出函数
+func (h *Handler) compile(ctx context.Context, module []byte) (wazero.CompiledModule, bool, error) {
+	compiled, err := h.runtime.CompileModule(ctx, module)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to compile wasm module: %w", err)
+	}
+	exports := compiled.ExportedFunctions()
+	for _, name := range []string{"alloc", "on_change"} {
+		if _, ok := exports[name]; !ok {
+			_ = compiled.Close(ctx)
+			return nil, false, fmt.Errorf("wasm module does not export %s", name)
+		}
+	}
+	if _, ok := compiled.ExportedMemories()["memory"]; !ok {
+		_ = compiled.Close(ctx)
+		return nil, false, fmt.Errorf("wasm module does not export memory")
+	}
+	_, hasValidate := exports["validate"]
+	return compiled, hasValidate, nil
+}
+
+// call 在全新的模块实例中调用导出函数，调用方需持有读锁
+func (h *Handler) call(ctx context.Context, fn string, args ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, h.opts.timeout)
+	defer cancel()
+	state := &callState{}
+	ctx = context.WithValue(ctx, callStateKey{}, state)
+
+	mod, err := h.runtime.InstantiateModule(ctx, h.compiled, wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize"))
+	if err != nil {
+		return fmt.Errorf("failed to instantiate wasm module: %w", err)
+	}
+	defer mod.Close(context.Background())
+
+	params := make([]uint64, 0, len(args)*2)
+	for _, arg := range args {
+		ptr, err := writeString(ctx, mod, arg)
+		if err != nil {
+			return err
+		}
+		params = append(params, uint64(ptr), uint64(len(arg)))
+	}
+
+	results, err := mod.ExportedFunction(fn).Call(ctx, params...)
+	if err != nil {
+		return fmt.Errorf("wasm %s failed: %w", fn, err)
+	}
+	if len(results) > 0 && api.DecodeI32(results[0]) != 0 {
+		if state.err != "" {
+			return fmt.Errorf("wasm %s returned %d: %s", fn, api.DecodeI32(results[0]), state.err)
+		}
+		return fmt.Errorf("wasm %s returned %d", fn, api.DecodeI32(results[0]))
+	}
+	return nil
+}
+
+// writeString 通过模块的 alloc 分配内存并写入字符串
+func writeString(ctx context.Context, mod api.Module, s string) (uint32, error) {
+	results, err := mod.ExportedFunction("alloc").Call(ctx, uint64(len(s)))
+	if err != nil {
+		return 0, fmt.Errorf("wasm alloc failed: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, fmt.Errorf("wasm alloc returned no pointer")
+	}
+	ptr := api.DecodeU32(results[0])
+	if !mod.Memory().WriteString(ptr, s) {
+		return 0, fmt.Errorf("wasm alloc returned out of range pointer %d", ptr)
+	}
+	return ptr, nil
+}
+
+// setError 宿主函数：模块设置失败原因
+func setError(ctx context.Context, mod api.Module, ptr, length uint32) {
+	state, ok := ctx.Value(callStateKey{}).(*callState)
+	if !ok {
+		return
+	}
+	if b, ok := mod.Memory().Read(ptr, length); ok {
+		state.err = string(b)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package wasmhandler
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-anyway/framework-hotreload"
+)
+
+// section 编码 wasm 模块段（测试模块各段均小于 128 字节，长度只占一个字节）
+func section(id byte, content ...byte) []byte {
+	return append([]byte{id, byte(len(content))}, content...)
+}
+
+// name 编码 wasm 名称
+func name(s string) []byte {
+	return append([]byte{byte(len(s))}, s...)
+}
+
+// body 编码无局部变量的函数体
+func body(code ...byte) []byte {
+	return append([]byte{byte(len(code) + 1), 0x00}, code...)
+}
+
+// concat 拼接字节切片
+func concat(parts ...[]byte) []byte {
+	var b []byte
+	for _, p := range parts {
+		b = append(b, p...)
+	}
+	return b
+}
+
+// testModule 返回手工编码的测试模块：
+//   - alloc 从 1024 开始顺序分配内存
+//   - validate 在新值为空时调用 set_error("empty value") 并返回 1
+//   - on_change 在新值长度为 4 时死循环，长度超过 5 时返回 2，否则返回 0
+func testModule() []byte {
+	const i32 = 0x7f
+	return concat(
+		[]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00},
+		section(1, 4,
+			0x60, 1, i32, 1, i32,
+			0x60, 6, i32, i32, i32, i32, i32, i32, 1, i32,
+			0x60, 4, i32, i32, i32, i32, 1, i32,
+			0x60, 2, i32, i32, 0),
+		section(2, concat([]byte{1}, name(hostModule), name("set_error"), []byte{0x00, 3})...),
+		section(3, 3, 0, 1, 2),
+		section(5, 1, 0x00, 1),
+		section(6, 1, i32, 0x01, 0x41, 0x80, 0x08, 0x0b),
+		section(7, concat([]byte{4},
+			name("memory"), []byte{0x02, 0},
+			name("alloc"), []byte{0x00, 1},
+			name("on_change"), []byte{0x00, 2},
+			name("validate"), []byte{0x00, 3})...),
+		section(10, concat([]byte{3},
+			body(0x23, 0, 0x23, 0, 0x20, 0, 0x6a, 0x24, 0, 0x0b),
+			body(0x20, 5, 0x41, 4, 0x46, 0x04, 0x40, 0x03, 0x40, 0x0c, 0, 0x0b, 0x0b,
+				0x20, 5, 0x41, 5, 0x4b, 0x04, 0x40, 0x41, 2, 0x0f, 0x0b,
+				0x41, 0, 0x0b),
+			body(0x20, 3, 0x45, 0x04, 0x40, 0x41, 0, 0x41, 11, 0x10, 0, 0x41, 1, 0x0f, 0x0b,
+				0x41, 0, 0x0b))...),
+		section(11, concat([]byte{1, 0x00, 0x41, 0, 0x0b}, name("empty value"))...),
+	)
+}
+
+// moduleValue 返回以 base64 编码的模块配置值
+func moduleValue(module []byte) string {
+	return base64Prefix + base64.StdEncoding.EncodeToString(module)
+}
+
+func TestNewRejectsInvalidArguments(t *testing.T) {
+	if _, err := New("", []string{"app.*"}); err == nil {
+		t.Error("New() with empty name error = nil")
+	}
+	if _, err := New("checker", nil); err == nil {
+		t.Error("New() without patterns error = nil")
+	}
+	if _, err := New("checker", []string{"app.*"}, WithModule([]byte("not wasm"))); err == nil {
+		t.Error("New() with invalid module error = nil")
+	}
+}
+
+func TestValidateModule(t *testing.T) {
+	h, err := New("checker", []string{"app.*"}, WithMaxModuleSize(1024))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer h.Close(context.Background())
+
+	// 缺少 on_change 导出的模块
+	missingExport := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	tests := []struct {
+		value   string
+		wantErr string
+	}{
+		{moduleValue(testModule()), ""},
+		{"", "wasm module is empty"},
+		{"base64:!!", "failed to read wasm module"},
+		{moduleValue(missingExport), "does not export alloc"},
+		{moduleValue(make([]byte, 2048)), "exceeds limit"},
+	}
+	for _, tt := range tests {
+		err := h.Validate("wasm.checker.module", tt.value)
+		if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+			t.Errorf("Validate(%.20q) error = %v, want %q", tt.value, err, tt.wantErr)
+		}
+	}
+}
+
+func TestHandlerRunsModule(t *testing.T) {
+	m := hotreload.NewManager(hotreload.WithLogger(hotreload.NopLogger()))
+	defer m.Close(context.Background())
+	h, err := Register(m, "checker", []string{"app.*"}, WithTimeout(100*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	defer h.Close(context.Background())
+
+	if err := h.OnChange("app.mode", "", "on"); err == nil {
+		t.Fatal("OnChange() without module error = nil")
+	}
+	apply := func(key, value string) error {
+		return m.Apply(context.Background(), hotreload.Change{Key: key, NewValue: value})
+	}
+	if err := apply("wasm.checker.module", moduleValue(testModule())); err != nil {
+		t.Fatalf("Apply() module error = %v", err)
+	}
+
+	if err := apply("app.mode", "on"); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if err := h.Validate("app.mode", ""); err == nil || !strings.Contains(err.Error(), "empty value") {
+		t.Fatalf("Validate() error = %v, want message from set_error", err)
+	}
+	if err := h.OnChange("app.mode", "on", "toolong"); err == nil || !strings.Contains(err.Error(), "returned 2") {
+		t.Fatalf("OnChange() error = %v, want non-zero return code", err)
+	}
+
+	start := time.Now()
+	if err := h.OnChange("app.mode", "on", "loop"); err == nil {
+		t.Fatal("OnChange() running forever error = nil")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("OnChange() took %s, want it interrupted after timeout", elapsed)
+	}
+}
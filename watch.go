@@ -0,0 +1,254 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"sync"
+)
+
+// OverflowPolicy Watch 通道缓冲区已满时的处理策略
+type OverflowPolicy string
+
+const (
+	// OverflowBlock 阻塞配置变更分发，直至消费者取走事件（慢消费者会拖慢配置变更处理）
+	// 阻塞期间分发方仍持有该配置键的顺序锁（见 WithSourceOrdering）与并发槽位（见 WithMaxConcurrentChanges），
+	// 同一配置键的后续变更以及等待槽位的其他配置变更都会随之等待
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropOldest 丢弃最早的未消费事件（默认）
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowConflate 同一配置键只保留最新的未消费事件，缓冲区仍满时丢弃最早的事件
+	// 适用于只关心配置键最终值的消费者
+	OverflowConflate OverflowPolicy = "conflate"
+)
+
+// WatchOption Watch 选项
+type WatchOption func(*watchOptions)
+
+// watchOptions Watch 选项
+type watchOptions struct {
+	buffer int
+	policy OverflowPolicy
+}
+
+// WithWatchBuffer 设置 Watch 的缓冲区大小（<= 0 时使用默认值 64）
+func WithWatchBuffer(n int) WatchOption {
+	return func(o *watchOptions) {
+		o.buffer = n
+	}
+}
+
+// WithOverflowPolicy 设置 Watch 缓冲区已满时的处理策略（默认 OverflowDropOldest，无法识别的策略同样按默认处理）
+func WithOverflowPolicy(policy OverflowPolicy) WatchOption {
+	return func(o *watchOptions) {
+		o.policy = policy
+	}
+}
+
+// watcher Watch 订阅者
+// 事件先写入有界队列，再由独立的 goroutine 转发到消费者通道，以便按溢出策略丢弃或合并未消费的事件
+type watcher struct {
+	pattern string
+	policy  OverflowPolicy
+	buffer  int
+	out     chan Event
+	done    chan struct{}
+
+	queue  []Event
+	closed bool
+
+	mu   sync.Mutex
+	cond *sync.Cond
+}
+
+// watchHub Watch 订阅者集合
+type watchHub struct {
+	watchers map[uint64]*watcher
+	nextID   uint64
+	// 管理器关闭后不再接受订阅
+	closed bool
+
+	mu sync.RWMutex
+}
+
+// Watch 订阅匹配配置键模式（支持通配符，为空或 "*" 时订阅所有配置键）的已应用配置变更（EventChangeApplied）
+// 适用于偏好拉取方式消费配置变更、而不是注册处理器的场景；缓冲区大小与溢出策略见 WithWatchBuffer、WithOverflowPolicy。
+// 返回事件通道和取消订阅函数，取消订阅或管理器关闭后通道会被关闭，未消费的事件被丢弃；管理器为 nil 时返回已关闭的通道
+func (m *Manager) Watch(pattern string, opts ...WatchOption) (<-chan Event, func()) {
+	if m == nil {
+		out := make(chan Event)
+		close(out)
+		return out, func() {}
+	}
+
+	options := watchOptions{policy: OverflowDropOldest}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.buffer <= 0 {
+		options.buffer = defaultEventBuffer
+	}
+	switch options.policy {
+	case OverflowBlock, OverflowDropOldest, OverflowConflate:
+	default:
+		options.policy = OverflowDropOldest
+	}
+
+	w := &watcher{
+		pattern: m.NormalizeKey(pattern),
+		policy:  options.policy,
+		buffer:  options.buffer,
+		out:     make(chan Event),
+		done:    make(chan struct{}),
+	}
+	w.cond = sync.NewCond(&w.mu)
+
+	hub := &m.watches
+	hub.mu.Lock()
+	if hub.closed {
+		hub.mu.Unlock()
+		close(w.out)
+		return w.out, func() {}
+	}
+	if hub.watchers == nil {
+		hub.watchers = make(map[uint64]*watcher)
+	}
+	hub.nextID++
+	id := hub.nextID
+	hub.watchers[id] = w
+	hub.mu.Unlock()
+
+	go w.pump()
+
+	cancel := func() {
+		hub.mu.Lock()
+		delete(hub.watchers, id)
+		hub.mu.Unlock()
+		w.stop()
+	}
+	return w.out, cancel
+}
+
+// matches 检查配置键是否匹配订阅的模式
+func (w *watcher) matches(key string) bool {
+	return w.pattern == "" || w.pattern == "*" || matchPattern(w.pattern, key)
+}
+
+// push 按溢出策略将事件加入队列，返回因队列已满而丢弃的事件数
+func (w *watcher) push(event Event) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.policy == OverflowConflate {
+		for i := range w.queue {
+			if w.queue[i].Key == event.Key {
+				w.queue[i] = event
+				return 0
+			}
+		}
+	}
+
+	dropped := 0
+	for !w.closed && len(w.queue) >= w.buffer {
+		if w.policy == OverflowBlock {
+			w.cond.Wait()
+			continue
+		}
+		w.queue = w.queue[1:]
+		dropped++
+	}
+	if w.closed {
+		return dropped
+	}
+	w.queue = append(w.queue, event)
+	w.cond.Broadcast()
+	return dropped
+}
+
+// pump 将队列中的事件依次转发到消费者通道，停止后关闭消费者通道
+func (w *watcher) pump() {
+	defer close(w.out)
+	for {
+		w.mu.Lock()
+		for len(w.queue) == 0 && !w.closed {
+			w.cond.Wait()
+		}
+		if w.closed {
+			w.mu.Unlock()
+			return
+		}
+		event := w.queue[0]
+		w.queue = w.queue[1:]
+		// 唤醒因队列已满而阻塞的发布方
+		w.cond.Broadcast()
+		w.mu.Unlock()
+
+		select {
+		case w.out <- event:
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// stop 停止转发事件（可重复调用）
+func (w *watcher) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return
+	}
+	w.closed = true
+	w.queue = nil
+	close(w.done)
+	w.cond.Broadcast()
+}
+
+// publish 将已应用的配置变更事件推送给匹配的订阅者
+// 推送前释放订阅者集合的锁，OverflowBlock 策略下阻塞的发布方不会妨碍取消订阅
+func (h *watchHub) publish(event Event) int {
+	h.mu.RLock()
+	if len(h.watchers) == 0 {
+		h.mu.RUnlock()
+		return 0
+	}
+	matched := make([]*watcher, 0, len(h.watchers))
+	for _, w := range h.watchers {
+		if w.matches(event.Key) {
+			matched = append(matched, w)
+		}
+	}
+	h.mu.RUnlock()
+
+	dropped := 0
+	for _, w := range matched {
+		dropped += w.push(event)
+	}
+	return dropped
+}
+
+// close 停止所有订阅者并关闭其事件通道
+func (h *watchHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for id, w := range h.watchers {
+		delete(h.watchers, id)
+		w.stop()
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 zampo.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// @contact  zampo3380@gmail.com
+
+package hotreload
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newWatchManager 创建为 app.* 注册了处理器的管理器
+func newWatchManager(t *testing.T) *Manager {
+	t.Helper()
+	m := NewManager(WithLogger(NopLogger()))
+	t.Cleanup(func() { m.Close(context.Background()) })
+	if err := m.RegisterHandler("app.*", func(key, oldValue, newValue string) error { return nil }); err != nil {
+		t.Fatalf("RegisterHandler() error = %v", err)
+	}
+	return m
+}
+
+// applyValues 依次将配置键设置为 v1..vn
+func applyValues(t *testing.T, m *Manager, key string, n int) {
+	t.Helper()
+	for i := 1; i <= n; i++ {
+		if err := m.Apply(context.Background(), Change{Key: key, NewValue: fmt.Sprintf("v%d", i)}); err != nil {
+			t.Fatalf("Apply() error = %v", err)
+		}
+	}
+}
+
+// receiveValues 读取事件直至通道空闲，返回各事件的新值
+func receiveValues(events <-chan Event) []string {
+	var values []string
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return values
+			}
+			values = append(values, event.NewValue)
+		case <-time.After(100 * time.Millisecond):
+			return values
+		}
+	}
+}
+
+func TestWatchNilManager(t *testing.T) {
+	var m *Manager
+	events, cancel := m.Watch("*")
+	defer cancel()
+	if _, ok := <-events; ok {
+		t.Fatal("Watch() on nil manager returned an open channel")
+	}
+}
+
+func TestWatchUnknownPolicyDefaultsToDropOldest(t *testing.T) {
+	m := newWatchManager(t)
+	_, cancel := m.Watch("app.*", WithOverflowPolicy("bogus"))
+	defer cancel()
+
+	m.watches.mu.RLock()
+	defer m.watches.mu.RUnlock()
+	for _, w := range m.watches.watchers {
+		if w.policy != OverflowDropOldest {
+			t.Fatalf("policy = %q, want %q", w.policy, OverflowDropOldest)
+		}
+	}
+}
+
+func TestWatchDropOldest(t *testing.T) {
+	m := newWatchManager(t)
+	events, cancel := m.Watch("app.*", WithWatchBuffer(2), WithOverflowPolicy(OverflowDropOldest))
+	defer cancel()
+
+	applyValues(t, m, "app.key", 6)
+	values := receiveValues(events)
+	// 缓冲区之外最多还有一个事件已交给转发 goroutine
+	if len(values) > 3 || len(values) < 2 {
+		t.Fatalf("received %v, want 2 or 3 events", values)
+	}
+	if got := values[len(values)-2:]; got[0] != "v5" || got[1] != "v6" {
+		t.Fatalf("received %v, want it to end with [v5 v6]", values)
+	}
+}
+
+func TestWatchConflate(t *testing.T) {
+	m := newWatchManager(t)
+	events, cancel := m.Watch("app.*", WithWatchBuffer(8), WithOverflowPolicy(OverflowConflate))
+	defer cancel()
+
+	applyValues(t, m, "app.key", 6)
+	if err := m.Apply(context.Background(), Change{Key: "app.other", NewValue: "x"}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	values := receiveValues(events)
+	if len(values) > 3 || len(values) < 2 {
+		t.Fatalf("received %v, want 2 or 3 events", values)
+	}
+	if got := values[len(values)-2:]; got[0] != "v6" || got[1] != "x" {
+		t.Fatalf("received %v, want it to end with [v6 x]", values)
+	}
+}
+
+func TestWatchBlock(t *testing.T) {
+	m := newWatchManager(t)
+	events, cancel := m.Watch("app.*", WithWatchBuffer(1), WithOverflowPolicy(OverflowBlock))
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 1; i <= 4; i++ {
+			if err := m.Apply(context.Background(), Change{Key: "app.key", NewValue: fmt.Sprintf("v%d", i)}); err != nil {
+				t.Errorf("Apply() error = %v", err)
+			}
+		}
+	}()
+	select {
+	case <-done:
+		t.Fatal("Apply() did not block on a full watch buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	values := receiveValues(events)
+	<-done
+	if fmt.Sprint(values) != "[v1 v2 v3 v4]" {
+		t.Fatalf("received %v, want [v1 v2 v3 v4]", values)
+	}
+}